@@ -6,7 +6,7 @@ import (
 )
 
 var (
-	AugSchemeDst = []byte("BLS_SIG_bls12377G2_XMD:SHA-256_SSWU_RO_AUG_")
+	AugSchemeDst = BLS12377G2.Ciphersuite().WithScheme(AUG).DST()
 )
 
 type AugSchemeMPL struct{}
@@ -41,10 +41,14 @@ func coreSignMpl(sk PrivateKey, pk PublicKey, message, dst []byte) *bls12377.Poi
 
 	q, _ := g2Map.HashToCurve(append(pk.Bytes(), message...), dst)
 
-	return g2Map.MulScalar(g2Map.New(), q, bls12377.NewFr().FromBytes(sk.Bytes()))
+	return g2Map.MulScalarCT(g2Map.New(), q, sk.value)
 }
 
 func coreVerifyMpl(pk PublicKey, message []byte, sig, dst []byte) bool {
+	// KeyValidate: the identity key verifies the identity signature of every message
+	if bls12377.NewG1().IsZero(pk.G1()) {
+		return false
+	}
 
 	g2Map := bls12377.NewG2()
 	q, _ := g2Map.HashToCurve(message, dst)
@@ -87,7 +91,7 @@ func coreAggregateMpl(signatures ...[]byte) ([]byte, error) {
 func coreAggregateVerify(pks, messages [][]byte, sig, dst []byte) bool {
 	pksLen := len(pks)
 
-	if pksLen != len(messages) && pksLen < 1 {
+	if pksLen != len(messages) || pksLen < 1 {
 		return false
 	}
 
@@ -104,7 +108,7 @@ func coreAggregateVerify(pks, messages [][]byte, sig, dst []byte) bool {
 
 	for index, pk := range pks {
 		p, err := bls12377.NewG1().FromCompressed(pk)
-		if err != nil {
+		if err != nil || bls12377.NewG1().IsZero(p) {
 			return false
 		}
 
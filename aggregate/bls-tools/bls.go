@@ -5,7 +5,6 @@ import (
 	"encoding/hex"
 	"gnark/aggregate/bls12377"
 	"golang.org/x/crypto/pbkdf2"
-	"math/big"
 	"strings"
 )
 
@@ -14,12 +13,13 @@ func KeyGen(seed []byte) PrivateKey {
 	L := 48
 	okm := extractExpand(L, append(seed, 0), []byte("BLS-SIG-KEYGEN-SALT-"), []byte{0, byte(L)})
 
-	return PrivateKey{new(big.Int).Mod(new(big.Int).SetBytes(okm), bls12377.NewG1().Q())}
+	return PrivateKey{bls12377.NewFr().FromBytes(okm)}
 }
 
+// KeyFromBytes reads a private key, input is reduced modulo group order.
 func KeyFromBytes(keyBytes []byte) PrivateKey {
 	return PrivateKey{
-		value: new(big.Int).SetBytes(keyBytes),
+		value: bls12377.NewFr().FromBytes(keyBytes),
 	}
 }
 
@@ -0,0 +1,119 @@
+package bls_tools
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Group is the group messages are hashed to, the group of signatures.
+type Group string
+
+const (
+	G1 Group = "G1"
+	G2 Group = "G2"
+)
+
+// SchemeTag is the tag of a signature scheme in a ciphersuite identifier.
+type SchemeTag string
+
+const (
+	// NUL is the tag of the basic scheme.
+	NUL SchemeTag = "NUL"
+	// AUG is the tag of the message augmentation scheme.
+	AUG SchemeTag = "AUG"
+	// POP is the tag of the proof of possession scheme.
+	POP SchemeTag = "POP"
+)
+
+// Hash and mapping names of the hash-to-curve suites of RFC 9380.
+const (
+	XMDSHA256 = "XMD:SHA-256"
+	SSWU      = "SSWU"
+	SVDW      = "SVDW"
+)
+
+// Ciphersuite identifies a ciphersuite of the BLS signature draft: the curve and group messages
+// are hashed to, the hash-to-curve suite and the scheme. Its DST is
+// BLS_SIG_<curve><group>_<hash>_<mapping>_RO_<scheme>_, as BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_.
+type Ciphersuite struct {
+	Curve   string
+	Group   Group
+	Hash    string
+	Mapping string
+	Scheme  SchemeTag
+}
+
+// SuiteID returns the identifier of the hash-to-curve suite.
+func (c Ciphersuite) SuiteID() string {
+	return c.Curve + string(c.Group) + "_" + c.Hash + "_" + c.Mapping + "_RO_"
+}
+
+// DST returns the domain separation tag messages are hashed with.
+func (c Ciphersuite) DST() []byte {
+	return []byte("BLS_SIG_" + c.SuiteID() + string(c.Scheme) + "_")
+}
+
+// PopDST returns the domain separation tag public keys are hashed with in proofs of possession.
+func (c Ciphersuite) PopDST() []byte {
+	return []byte("BLS_POP_" + c.SuiteID() + string(POP) + "_")
+}
+
+// WithScheme returns the ciphersuite of the same hash-to-curve suite for scheme s.
+func (c Ciphersuite) WithScheme(s SchemeTag) Ciphersuite {
+	c.Scheme = s
+	return c
+}
+
+// String returns the DST.
+func (c Ciphersuite) String() string {
+	return string(c.DST())
+}
+
+// MarshalText encodes the ciphersuite as its DST.
+func (c Ciphersuite) MarshalText() ([]byte, error) {
+	return c.DST(), nil
+}
+
+// UnmarshalText decodes a DST.
+func (c *Ciphersuite) UnmarshalText(in []byte) error {
+	v, err := ParseCiphersuite(string(in))
+	if err != nil {
+		return err
+	}
+	*c = v
+	return nil
+}
+
+// ParseCiphersuite returns the ciphersuite of a DST.
+func ParseCiphersuite(dst string) (Ciphersuite, error) {
+	var c Ciphersuite
+	if !strings.HasPrefix(dst, "BLS_SIG_") || !strings.HasSuffix(dst, "_") {
+		return c, fmt.Errorf("%q is not a BLS signature DST", dst)
+	}
+	parts := strings.Split(strings.TrimSuffix(strings.TrimPrefix(dst, "BLS_SIG_"), "_"), "_")
+	if len(parts) != 5 || parts[3] != "RO" {
+		return c, fmt.Errorf("%q is not a BLS signature DST", dst)
+	}
+	curveGroup := parts[0]
+	if len(curveGroup) < 3 {
+		return c, fmt.Errorf("%q has no curve", dst)
+	}
+	c.Curve, c.Group = curveGroup[:len(curveGroup)-2], Group(curveGroup[len(curveGroup)-2:])
+	c.Hash, c.Mapping, c.Scheme = parts[1], parts[2], SchemeTag(parts[4])
+	return c, c.Validate()
+}
+
+// Validate checks the group and the scheme tag.
+func (c Ciphersuite) Validate() error {
+	if c.Curve == "" || c.Hash == "" || c.Mapping == "" {
+		return errors.New("curve, hash and mapping of the ciphersuite must be given")
+	}
+	if c.Group != G1 && c.Group != G2 {
+		return fmt.Errorf("unknown group %q", c.Group)
+	}
+	if c.Scheme != NUL && c.Scheme != AUG && c.Scheme != POP {
+		return fmt.Errorf("unknown scheme tag %q", c.Scheme)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package bls_tools
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCiphersuiteDST(t *testing.T) {
+	for _, tc := range []struct {
+		suite Ciphersuite
+		dst   string
+	}{
+		{BLS12377G2.Ciphersuite().WithScheme(AUG), string(AugSchemeDst)},
+		{BLS12377G1.Ciphersuite(), "BLS_SIG_BLS12377G1_XMD:SHA-256_SSWU_RO_NUL_"},
+		{BLS12381G1.Ciphersuite().WithScheme(POP), "BLS_SIG_BLS12381G1_XMD:SHA-256_SSWU_RO_POP_"},
+		{BN254G1.Ciphersuite(), "BLS_SIG_BN254G1_XMD:SHA-256_SVDW_RO_NUL_"},
+		{Ciphersuite{"BLS12381", G2, XMDSHA256, SSWU, POP}, "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_"},
+	} {
+		if got := string(tc.suite.DST()); got != tc.dst {
+			t.Fatalf("DST %s, expected %s", got, tc.dst)
+		}
+		parsed, err := ParseCiphersuite(tc.dst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if parsed != tc.suite {
+			t.Fatalf("%s parsed as %+v", tc.dst, parsed)
+		}
+	}
+	if got := string(BLS12381G1.Ciphersuite().PopDST()); got != "BLS_POP_BLS12381G1_XMD:SHA-256_SSWU_RO_POP_" {
+		t.Fatalf("PopDST %s", got)
+	}
+}
+
+func TestParseCiphersuiteRejects(t *testing.T) {
+	for _, dst := range []string{
+		"",
+		"BLS12_377_ECC_HASH",
+		"BLS_POP_BLS12381G1_XMD:SHA-256_SSWU_RO_POP_",
+		"BLS_SIG_BLS12381G1_XMD:SHA-256_SSWU_RO_NUL",
+		"BLS_SIG_BLS12381G1_XMD:SHA-256_SSWU_NU_NUL_",
+		"BLS_SIG_BLS12381G3_XMD:SHA-256_SSWU_RO_NUL_",
+		"BLS_SIG_BLS12381G1_XMD:SHA-256_SSWU_RO_FOO_",
+		"BLS_SIG_G1_XMD:SHA-256_SSWU_RO_NUL_",
+	} {
+		if _, err := ParseCiphersuite(dst); err == nil {
+			t.Fatalf("%q must be rejected", dst)
+		}
+	}
+}
+
+func TestCiphersuiteJSON(t *testing.T) {
+	suite := BN254G1.Ciphersuite().WithScheme(POP)
+	b, err := json.Marshal(suite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `"BLS_SIG_BN254G1_XMD:SHA-256_SVDW_RO_POP_"` {
+		t.Fatalf("encoded as %s", b)
+	}
+	var decoded Ciphersuite
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded != suite {
+		t.Fatalf("decoded as %+v", decoded)
+	}
+}
+
+// Every scheme signs with the DST of its ciphersuite.
+func TestSchemeCiphersuites(t *testing.T) {
+	for _, c := range Curves {
+		for _, s := range testSchemes(c) {
+			if string(s.DST()) != s.Ciphersuite().String() {
+				t.Fatalf("%s: DST %s", c.Name(), s.DST())
+			}
+			if s.Ciphersuite().SuiteID() != c.Ciphersuite().SuiteID() {
+				t.Fatalf("%s: scheme hashes with suite %s", c.Name(), s.Ciphersuite().SuiteID())
+			}
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package bls_tools
+
+import (
+	"math/big"
+
+	"gnark/aggregate/bls12377"
+)
+
+// mulCT returns [k]p for a secret k < 2^256 on a curve of gnark-crypto, whose scalar
+// multiplication is variable time, with the ladder of gnark/aggregate/bls12377.MulCT. sel sets
+// z to x0 if c is 0 and to x1 otherwise in constant time.
+func mulCT[A, J any, PA affine[A, J], PJ jacobian[A, J]](p *A, k *[4]uint64, sel func(c int, z, x0, x1 *J)) A {
+	// table = {P, 3P, 5P, ..., (2^w - 1)P}
+	table := make([]J, bls12377.CTTableSize)
+	var double J
+	PJ(&table[0]).FromAffine(p)
+	PJ(&double).Set(&table[0])
+	PJ(&double).DoubleAssign()
+	for i := 1; i < len(table); i++ {
+		PJ(&table[i]).Set(&table[i-1])
+		PJ(&table[i]).AddAssign(&double)
+	}
+
+	acc := bls12377.MulCT[J](ctJacobian[A, J, PJ](sel), table, k)
+	var res A
+	PA(&res).FromJacobian(&acc)
+	return res
+}
+
+// ctJacobian implements bls12377.CTGroup on the Jacobian points of gnark-crypto with a constant
+// time select of the curve.
+type ctJacobian[A, J any, PJ jacobian[A, J]] func(c int, z, x0, x1 *J)
+
+func (sel ctJacobian[A, J, PJ]) Set(r, p *J) { PJ(r).Set(p) }
+
+func (sel ctJacobian[A, J, PJ]) Double(r, p *J) {
+	PJ(r).Set(p)
+	PJ(r).DoubleAssign()
+}
+
+func (sel ctJacobian[A, J, PJ]) Add(r, p, q *J) {
+	var s J
+	PJ(&s).Set(q)
+	PJ(r).Set(p)
+	PJ(r).AddAssign(&s)
+}
+
+func (sel ctJacobian[A, J, PJ]) Neg(r, p *J) { PJ(r).Neg(p) }
+
+func (sel ctJacobian[A, J, PJ]) Select(c uint64, r, x0, x1 *J) { sel(int(c), r, x0, x1) }
+
+// scalar returns sk modulo order in fixed width limbs, see bls12377.ReduceCT.
+func scalar(sk, order *big.Int) *[4]uint64 {
+	k := bls12377.ReduceCT(sk, order)
+	return &k
+}
@@ -0,0 +1,52 @@
+package bls_tools
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	bls12377_ecc "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	bls12381_ecc "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	bn254_ecc "github.com/consensys/gnark-crypto/ecc/bn254"
+)
+
+// testMulCT compares mulCT of g with the variable time scalar multiplication of gnark-crypto.
+func testMulCT[P any](t *testing.T, name string, g eccGroup[P], order *big.Int, mul func(p *P, k *big.Int) P) {
+	scalars := []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(2), big.NewInt(15), big.NewInt(16)}
+	scalars = append(scalars, new(big.Int).Sub(order, big.NewInt(1)), new(big.Int).Sub(order, big.NewInt(2)))
+	for i := 0; i < 8; i++ {
+		k, err := rand.Int(rand.Reader, order)
+		if err != nil {
+			t.Fatal(err)
+		}
+		scalars = append(scalars, k)
+	}
+	for _, k := range scalars {
+		got, expected := g.mulCT(&g.generator, scalar(k, order)), mul(&g.generator, k)
+		if string(g.bytes(&got)) != string(g.bytes(&expected)) {
+			t.Fatalf("%s: [%s]P differs from ScalarMultiplication", name, k)
+		}
+	}
+}
+
+func TestMulCT(t *testing.T) {
+	bn254, bls12381, bls12377 := newBN254G1(), newBLS12381G1(), newBLS12377G1()
+	testMulCT(t, "BN254 G1", bn254.g1, bn254.order, func(p *bn254_ecc.G1Affine, k *big.Int) bn254_ecc.G1Affine {
+		return *new(bn254_ecc.G1Affine).ScalarMultiplication(p, k)
+	})
+	testMulCT(t, "BN254 G2", bn254.g2, bn254.order, func(p *bn254_ecc.G2Affine, k *big.Int) bn254_ecc.G2Affine {
+		return *new(bn254_ecc.G2Affine).ScalarMultiplication(p, k)
+	})
+	testMulCT(t, "BLS12-381 G1", bls12381.g1, bls12381.order, func(p *bls12381_ecc.G1Affine, k *big.Int) bls12381_ecc.G1Affine {
+		return *new(bls12381_ecc.G1Affine).ScalarMultiplication(p, k)
+	})
+	testMulCT(t, "BLS12-381 G2", bls12381.g2, bls12381.order, func(p *bls12381_ecc.G2Affine, k *big.Int) bls12381_ecc.G2Affine {
+		return *new(bls12381_ecc.G2Affine).ScalarMultiplication(p, k)
+	})
+	testMulCT(t, "BLS12-377 G1", bls12377.g1, bls12377.order, func(p *bls12377_ecc.G1Affine, k *big.Int) bls12377_ecc.G1Affine {
+		return *new(bls12377_ecc.G1Affine).ScalarMultiplication(p, k)
+	})
+	testMulCT(t, "BLS12-377 G2", bls12377.g2, bls12377.order, func(p *bls12377_ecc.G2Affine, k *big.Int) bls12377_ecc.G2Affine {
+		return *new(bls12377_ecc.G2Affine).ScalarMultiplication(p, k)
+	})
+}
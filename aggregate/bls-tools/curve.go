@@ -0,0 +1,81 @@
+package bls_tools
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// Curve is a pairing friendly curve with the groups of public keys and signatures chosen.
+// Keys and signatures are handled in their compressed encodings, so that the schemes are
+// written once for all curves.
+type Curve interface {
+	// Name is the name of the curve with the group of signatures, as BLS12381G1.
+	Name() string
+	// Ciphersuite is the basic scheme ciphersuite hashing messages to the group of signatures.
+	Ciphersuite() Ciphersuite
+	// Order is the order of both groups, private keys are reduced modulo it.
+	Order() *big.Int
+	PublicKeySize() int
+	SignatureSize() int
+	// PublicKey returns sk times the generator of the group of public keys. As Sign, it
+	// multiplies by sk in constant time.
+	PublicKey(sk *big.Int) []byte
+	// Sign returns sk times the hash of msg to the group of signatures.
+	Sign(sk *big.Int, msg, dst []byte) ([]byte, error)
+	// ValidatePublicKey checks that pk is a canonical encoding of a point of the subgroup
+	// other than the identity.
+	ValidatePublicKey(pk []byte) error
+	// AddPublicKeys and AddSignatures return the sum of the points.
+	AddPublicKeys(pks ...[]byte) ([]byte, error)
+	AddSignatures(sigs ...[]byte) ([]byte, error)
+	// Verify checks e(g, sig) == ∏ e(pk_i, H(msg_i)) where g generates the group of public keys.
+	// Public keys must be validated before.
+	Verify(pks, msgs [][]byte, sig, dst []byte) bool
+}
+
+var (
+	ErrNoPoints    = errors.New("at least one point is required")
+	ErrIdentityKey = errors.New("public key is the identity")
+)
+
+// Curves are the curves with a scheme implementation. BLS12377G2 is the curve of AugSchemeMPL,
+// the others sign in G1 as the circuits do.
+var Curves = []Curve{BLS12377G2, BLS12377G1, BLS12381G1, BN254G1}
+
+// CurveByName returns the curve of Curves with given name.
+func CurveByName(name string) (Curve, error) {
+	for _, c := range Curves {
+		if c.Name() == name {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown curve %q", name)
+}
+
+// GenerateKey derives a private key of curve c from seed as KeyGen does.
+func GenerateKey(c Curve, seed []byte) *big.Int {
+	L := 48
+	okm := extractExpand(L, append(append([]byte(nil), seed...), 0), []byte("BLS-SIG-KEYGEN-SALT-"), []byte{0, byte(L)})
+	return new(big.Int).Mod(new(big.Int).SetBytes(okm), c.Order())
+}
+
+// decodeAll applies decode to each encoding.
+func decodeAll[T any](in [][]byte, decode func([]byte) (T, error)) ([]T, error) {
+	out := make([]T, len(in))
+	for i := range in {
+		var err error
+		if out[i], err = decode(in[i]); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// checkCanonical fails unless decoding consumed all of in and encoding the point gives in back.
+func checkCanonical(in []byte, n int, encoded []byte) error {
+	if n != len(in) || string(in) != string(encoded) {
+		return errors.New("non canonical point encoding")
+	}
+	return nil
+}
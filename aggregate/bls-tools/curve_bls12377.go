@@ -0,0 +1,164 @@
+package bls_tools
+
+import (
+	"math/big"
+
+	bls12377_ecc "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	bls12377_fr "github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+
+	"gnark/aggregate/bls12377"
+)
+
+var (
+	// BLS12377G2 has public keys in G1 and signatures in G2 with the encodings of
+	// gnark/aggregate/bls12377, as AugSchemeMPL.
+	BLS12377G2 Curve = bls12377G2{}
+	// BLS12377G1 has public keys in G2 and signatures in G1 with the encodings of gnark-crypto,
+	// as the BLS12-377 circuits.
+	BLS12377G1 Curve = newBLS12377G1()
+)
+
+type bls12377G2 struct{}
+
+func (bls12377G2) Name() string { return "BLS12377G2" }
+
+// Ciphersuite names the curve in lower case as AugSchemeDst, for the signatures made with it.
+func (bls12377G2) Ciphersuite() Ciphersuite {
+	return Ciphersuite{Curve: "bls12377", Group: G2, Hash: XMDSHA256, Mapping: SSWU, Scheme: NUL}
+}
+
+func (bls12377G2) Order() *big.Int { return bls12377.NewG1().Q() }
+
+func (bls12377G2) PublicKeySize() int { return 48 }
+
+func (bls12377G2) SignatureSize() int { return 96 }
+
+func (bls12377G2) PublicKey(sk *big.Int) []byte {
+	g1 := bls12377.NewG1()
+	return g1.ToCompressed(g1.MulScalarCT(g1.New(), g1.One(), ctFr(sk)))
+}
+
+func (bls12377G2) Sign(sk *big.Int, msg, dst []byte) ([]byte, error) {
+	g2 := bls12377.NewG2()
+	q, err := g2.HashToCurve(msg, dst)
+	if err != nil {
+		return nil, err
+	}
+	return g2.ToCompressed(g2.MulScalarCT(g2.New(), q, ctFr(sk))), nil
+}
+
+// ctFr returns sk modulo the order in constant time, unlike Fr.SetBig which reduces it with
+// big.Int.
+func ctFr(sk *big.Int) *bls12377.Fr {
+	fr := bls12377.Fr(*scalar(sk, bls12377.NewG1().Q()))
+	return &fr
+}
+
+func (bls12377G2) ValidatePublicKey(pk []byte) error {
+	g1 := bls12377.NewG1()
+	p, err := g1.FromCompressed(pk)
+	if err != nil {
+		return err
+	}
+	if g1.IsZero(p) {
+		return ErrIdentityKey
+	}
+	return nil
+}
+
+func (bls12377G2) AddPublicKeys(pks ...[]byte) ([]byte, error) {
+	g1 := bls12377.NewG1()
+	points, err := decodeAll(pks, g1.FromCompressed)
+	if err != nil {
+		return nil, err
+	}
+	if len(points) == 0 {
+		return nil, ErrNoPoints
+	}
+	sum := g1.Zero()
+	for _, p := range points {
+		g1.Add(sum, sum, p)
+	}
+	return g1.ToCompressed(sum), nil
+}
+
+func (bls12377G2) AddSignatures(sigs ...[]byte) ([]byte, error) {
+	g2 := bls12377.NewG2()
+	points, err := decodeAll(sigs, g2.FromCompressed)
+	if err != nil {
+		return nil, err
+	}
+	if len(points) == 0 {
+		return nil, ErrNoPoints
+	}
+	sum := g2.Zero()
+	for _, p := range points {
+		g2.Add(sum, sum, p)
+	}
+	return g2.ToCompressed(sum), nil
+}
+
+func (bls12377G2) Verify(pks, msgs [][]byte, sig, dst []byte) bool {
+	g1, g2 := bls12377.NewG1(), bls12377.NewG2()
+	signature, err := g2.FromCompressed(sig)
+	if err != nil || len(pks) != len(msgs) || len(pks) < 1 {
+		return false
+	}
+	engine := bls12377.NewEngine()
+	engine.AddPair(g1.Neg(g1.New(), g1.One()), signature)
+	for i := range pks {
+		p, err := g1.FromCompressed(pks[i])
+		if err != nil {
+			return false
+		}
+		q, err := g2.HashToCurve(msgs[i], dst)
+		if err != nil {
+			return false
+		}
+		engine.AddPair(p, q)
+	}
+	return engine.Check()
+}
+
+func newBLS12377G1() eccCurve[bls12377_ecc.G1Affine, bls12377_ecc.G2Affine] {
+	_, _, g1Gen, g2Gen := bls12377_ecc.Generators()
+	return eccCurve[bls12377_ecc.G1Affine, bls12377_ecc.G2Affine]{
+		name:  "BLS12377G1",
+		suite: Ciphersuite{Curve: "BLS12377", Group: G1, Hash: XMDSHA256, Mapping: SSWU, Scheme: NUL},
+		order: bls12377_fr.Modulus(),
+		g1: eccGroup[bls12377_ecc.G1Affine]{
+			size:      bls12377_ecc.SizeOfG1AffineCompressed,
+			generator: g1Gen,
+			bytes:     func(p *bls12377_ecc.G1Affine) []byte { b := p.Bytes(); return b[:] },
+			setBytes:  (*bls12377_ecc.G1Affine).SetBytes,
+			isZero:    (*bls12377_ecc.G1Affine).IsInfinity,
+			neg:       func(p *bls12377_ecc.G1Affine) bls12377_ecc.G1Affine { return *new(bls12377_ecc.G1Affine).Neg(p) },
+			sum:       sumAffine[bls12377_ecc.G1Affine, bls12377_ecc.G1Jac],
+			mulCT: func(p *bls12377_ecc.G1Affine, k *[4]uint64) bls12377_ecc.G1Affine {
+				return mulCT(p, k, func(c int, z, x0, x1 *bls12377_ecc.G1Jac) {
+					z.X.Select(c, &x0.X, &x1.X)
+					z.Y.Select(c, &x0.Y, &x1.Y)
+					z.Z.Select(c, &x0.Z, &x1.Z)
+				})
+			},
+		},
+		g2: eccGroup[bls12377_ecc.G2Affine]{
+			size:      bls12377_ecc.SizeOfG2AffineCompressed,
+			generator: g2Gen,
+			bytes:     func(p *bls12377_ecc.G2Affine) []byte { b := p.Bytes(); return b[:] },
+			setBytes:  (*bls12377_ecc.G2Affine).SetBytes,
+			isZero:    (*bls12377_ecc.G2Affine).IsInfinity,
+			neg:       func(p *bls12377_ecc.G2Affine) bls12377_ecc.G2Affine { return *new(bls12377_ecc.G2Affine).Neg(p) },
+			sum:       sumAffine[bls12377_ecc.G2Affine, bls12377_ecc.G2Jac],
+			mulCT: func(p *bls12377_ecc.G2Affine, k *[4]uint64) bls12377_ecc.G2Affine {
+				return mulCT(p, k, func(c int, z, x0, x1 *bls12377_ecc.G2Jac) {
+					z.X.Select(c, &x0.X, &x1.X)
+					z.Y.Select(c, &x0.Y, &x1.Y)
+					z.Z.Select(c, &x0.Z, &x1.Z)
+				})
+			},
+		},
+		hashToG1:     bls12377_ecc.HashToG1,
+		pairingCheck: bls12377_ecc.PairingCheck,
+	}
+}
@@ -0,0 +1,53 @@
+package bls_tools
+
+import (
+	bls12381_ecc "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	bls12381_fr "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+// BLS12381G1 has public keys in G2 and signatures in G1 with the encodings of gnark-crypto,
+// as the BLS12-381 circuits.
+var BLS12381G1 Curve = newBLS12381G1()
+
+func newBLS12381G1() eccCurve[bls12381_ecc.G1Affine, bls12381_ecc.G2Affine] {
+	_, _, g1Gen, g2Gen := bls12381_ecc.Generators()
+	return eccCurve[bls12381_ecc.G1Affine, bls12381_ecc.G2Affine]{
+		name:  "BLS12381G1",
+		suite: Ciphersuite{Curve: "BLS12381", Group: G1, Hash: XMDSHA256, Mapping: SSWU, Scheme: NUL},
+		order: bls12381_fr.Modulus(),
+		g1: eccGroup[bls12381_ecc.G1Affine]{
+			size:      bls12381_ecc.SizeOfG1AffineCompressed,
+			generator: g1Gen,
+			bytes:     func(p *bls12381_ecc.G1Affine) []byte { b := p.Bytes(); return b[:] },
+			setBytes:  (*bls12381_ecc.G1Affine).SetBytes,
+			isZero:    (*bls12381_ecc.G1Affine).IsInfinity,
+			neg:       func(p *bls12381_ecc.G1Affine) bls12381_ecc.G1Affine { return *new(bls12381_ecc.G1Affine).Neg(p) },
+			sum:       sumAffine[bls12381_ecc.G1Affine, bls12381_ecc.G1Jac],
+			mulCT: func(p *bls12381_ecc.G1Affine, k *[4]uint64) bls12381_ecc.G1Affine {
+				return mulCT(p, k, func(c int, z, x0, x1 *bls12381_ecc.G1Jac) {
+					z.X.Select(c, &x0.X, &x1.X)
+					z.Y.Select(c, &x0.Y, &x1.Y)
+					z.Z.Select(c, &x0.Z, &x1.Z)
+				})
+			},
+		},
+		g2: eccGroup[bls12381_ecc.G2Affine]{
+			size:      bls12381_ecc.SizeOfG2AffineCompressed,
+			generator: g2Gen,
+			bytes:     func(p *bls12381_ecc.G2Affine) []byte { b := p.Bytes(); return b[:] },
+			setBytes:  (*bls12381_ecc.G2Affine).SetBytes,
+			isZero:    (*bls12381_ecc.G2Affine).IsInfinity,
+			neg:       func(p *bls12381_ecc.G2Affine) bls12381_ecc.G2Affine { return *new(bls12381_ecc.G2Affine).Neg(p) },
+			sum:       sumAffine[bls12381_ecc.G2Affine, bls12381_ecc.G2Jac],
+			mulCT: func(p *bls12381_ecc.G2Affine, k *[4]uint64) bls12381_ecc.G2Affine {
+				return mulCT(p, k, func(c int, z, x0, x1 *bls12381_ecc.G2Jac) {
+					z.X.Select(c, &x0.X, &x1.X)
+					z.Y.Select(c, &x0.Y, &x1.Y)
+					z.Z.Select(c, &x0.Z, &x1.Z)
+				})
+			},
+		},
+		hashToG1:     bls12381_ecc.HashToG1,
+		pairingCheck: bls12381_ecc.PairingCheck,
+	}
+}
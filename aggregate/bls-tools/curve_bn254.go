@@ -0,0 +1,53 @@
+package bls_tools
+
+import (
+	bn254_ecc "github.com/consensys/gnark-crypto/ecc/bn254"
+	bn254_fr "github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// BN254G1 has public keys in G2 and signatures in G1 with the encodings of gnark-crypto,
+// as the BN254 circuits.
+var BN254G1 Curve = newBN254G1()
+
+func newBN254G1() eccCurve[bn254_ecc.G1Affine, bn254_ecc.G2Affine] {
+	_, _, g1Gen, g2Gen := bn254_ecc.Generators()
+	return eccCurve[bn254_ecc.G1Affine, bn254_ecc.G2Affine]{
+		name:  "BN254G1",
+		suite: Ciphersuite{Curve: "BN254", Group: G1, Hash: XMDSHA256, Mapping: SVDW, Scheme: NUL},
+		order: bn254_fr.Modulus(),
+		g1: eccGroup[bn254_ecc.G1Affine]{
+			size:      bn254_ecc.SizeOfG1AffineCompressed,
+			generator: g1Gen,
+			bytes:     func(p *bn254_ecc.G1Affine) []byte { b := p.Bytes(); return b[:] },
+			setBytes:  (*bn254_ecc.G1Affine).SetBytes,
+			isZero:    (*bn254_ecc.G1Affine).IsInfinity,
+			neg:       func(p *bn254_ecc.G1Affine) bn254_ecc.G1Affine { return *new(bn254_ecc.G1Affine).Neg(p) },
+			sum:       sumAffine[bn254_ecc.G1Affine, bn254_ecc.G1Jac],
+			mulCT: func(p *bn254_ecc.G1Affine, k *[4]uint64) bn254_ecc.G1Affine {
+				return mulCT(p, k, func(c int, z, x0, x1 *bn254_ecc.G1Jac) {
+					z.X.Select(c, &x0.X, &x1.X)
+					z.Y.Select(c, &x0.Y, &x1.Y)
+					z.Z.Select(c, &x0.Z, &x1.Z)
+				})
+			},
+		},
+		g2: eccGroup[bn254_ecc.G2Affine]{
+			size:      bn254_ecc.SizeOfG2AffineCompressed,
+			generator: g2Gen,
+			bytes:     func(p *bn254_ecc.G2Affine) []byte { b := p.Bytes(); return b[:] },
+			setBytes:  (*bn254_ecc.G2Affine).SetBytes,
+			isZero:    (*bn254_ecc.G2Affine).IsInfinity,
+			neg:       func(p *bn254_ecc.G2Affine) bn254_ecc.G2Affine { return *new(bn254_ecc.G2Affine).Neg(p) },
+			sum:       sumAffine[bn254_ecc.G2Affine, bn254_ecc.G2Jac],
+			mulCT: func(p *bn254_ecc.G2Affine, k *[4]uint64) bn254_ecc.G2Affine {
+				return mulCT(p, k, func(c int, z, x0, x1 *bn254_ecc.G2Jac) {
+					z.X.Select(c, &x0.X, &x1.X)
+					z.Y.Select(c, &x0.Y, &x1.Y)
+					z.Z.Select(c, &x0.Z, &x1.Z)
+				})
+			},
+		},
+		hashToG1:     bn254_ecc.HashToG1,
+		pairingCheck: bn254_ecc.PairingCheck,
+	}
+}
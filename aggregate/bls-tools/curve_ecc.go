@@ -0,0 +1,145 @@
+package bls_tools
+
+import (
+	"math/big"
+)
+
+// eccCurve implements Curve for a curve of gnark-crypto with public keys in G2 and signatures
+// in G1, from the point operations of the curve package. G1 and G2 are affine points.
+type eccCurve[G1, G2 any] struct {
+	name  string
+	suite Ciphersuite
+	order *big.Int
+	g1    eccGroup[G1]
+	g2    eccGroup[G2]
+
+	hashToG1     func(msg, dst []byte) (G1, error)
+	pairingCheck func(P []G1, Q []G2) (bool, error)
+}
+
+// eccGroup are the operations of eccCurve on a group of affine points.
+type eccGroup[P any] struct {
+	size      int
+	generator P
+	bytes     func(p *P) []byte
+	setBytes  func(p *P, in []byte) (int, error)
+	isZero    func(p *P) bool
+	neg       func(p *P) P
+	sum       func(points []P) P
+	// mulCT returns [k]p for a secret k reduced modulo the order.
+	mulCT func(p *P, k *[4]uint64) P
+}
+
+// decode decodes the canonical encoding of a point.
+func (g eccGroup[P]) decode(in []byte) (P, error) {
+	var p P
+	n, err := g.setBytes(&p, in)
+	if err != nil {
+		return p, err
+	}
+	return p, checkCanonical(in, n, g.bytes(&p))
+}
+
+func (c eccCurve[G1, G2]) Name() string { return c.name }
+
+func (c eccCurve[G1, G2]) Ciphersuite() Ciphersuite { return c.suite }
+
+func (c eccCurve[G1, G2]) Order() *big.Int { return new(big.Int).Set(c.order) }
+
+func (c eccCurve[G1, G2]) PublicKeySize() int { return c.g2.size }
+
+func (c eccCurve[G1, G2]) SignatureSize() int { return c.g1.size }
+
+func (c eccCurve[G1, G2]) PublicKey(sk *big.Int) []byte {
+	pk := c.g2.mulCT(&c.g2.generator, scalar(sk, c.order))
+	return c.g2.bytes(&pk)
+}
+
+func (c eccCurve[G1, G2]) Sign(sk *big.Int, msg, dst []byte) ([]byte, error) {
+	q, err := c.hashToG1(msg, dst)
+	if err != nil {
+		return nil, err
+	}
+	sig := c.g1.mulCT(&q, scalar(sk, c.order))
+	return c.g1.bytes(&sig), nil
+}
+
+func (c eccCurve[G1, G2]) ValidatePublicKey(pk []byte) error {
+	p, err := c.g2.decode(pk)
+	if err != nil {
+		return err
+	}
+	if c.g2.isZero(&p) {
+		return ErrIdentityKey
+	}
+	return nil
+}
+
+func (c eccCurve[G1, G2]) AddPublicKeys(pks ...[]byte) ([]byte, error) {
+	return addEncoded(c.g2, pks)
+}
+
+func (c eccCurve[G1, G2]) AddSignatures(sigs ...[]byte) ([]byte, error) {
+	return addEncoded(c.g1, sigs)
+}
+
+func addEncoded[P any](g eccGroup[P], in [][]byte) ([]byte, error) {
+	points, err := decodeAll(in, g.decode)
+	if err != nil {
+		return nil, err
+	}
+	if len(points) == 0 {
+		return nil, ErrNoPoints
+	}
+	sum := g.sum(points)
+	return g.bytes(&sum), nil
+}
+
+func (c eccCurve[G1, G2]) Verify(pks, msgs [][]byte, sig, dst []byte) bool {
+	signature, err := c.g1.decode(sig)
+	if err != nil || len(pks) != len(msgs) || len(pks) < 1 {
+		return false
+	}
+	keys, err := decodeAll(pks, c.g2.decode)
+	if err != nil {
+		return false
+	}
+	P := []G1{signature}
+	Q := []G2{c.g2.neg(&c.g2.generator)}
+	for i := range msgs {
+		h, err := c.hashToG1(msgs[i], dst)
+		if err != nil {
+			return false
+		}
+		P, Q = append(P, h), append(Q, keys[i])
+	}
+	ok, err := c.pairingCheck(P, Q)
+	return err == nil && ok
+}
+
+// affine and jacobian are the methods of gnark-crypto points eccGroup operations are built on.
+type affine[A, J any] interface {
+	*A
+	FromJacobian(*J) *A
+}
+
+type jacobian[A, J any] interface {
+	*J
+	Set(*J) *J
+	Neg(*J) *J
+	AddAssign(*J) *J
+	AddMixed(*A) *J
+	DoubleAssign() *J
+	FromAffine(*A) *J
+}
+
+// sumAffine returns the sum of points.
+func sumAffine[A, J any, PA affine[A, J], PJ jacobian[A, J]](points []A) A {
+	var sum J
+	for i := range points {
+		PJ(&sum).AddMixed(&points[i])
+	}
+	var res A
+	PA(&res).FromJacobian(&sum)
+	return res
+}
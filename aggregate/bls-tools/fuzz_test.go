@@ -0,0 +1,66 @@
+package bls_tools
+
+import (
+	"bytes"
+	"testing"
+
+	"gnark/aggregate/bls12377"
+)
+
+func FuzzNewPublicKey(f *testing.F) {
+	pk := KeyGen(testSeed).GetPublicKey().Bytes()
+	f.Add(pk)
+	for _, flag := range []byte{1 << 7, 1 << 6, 1 << 5} {
+		s := append([]byte(nil), pk...)
+		s[0] ^= flag
+		f.Add(s)
+	}
+	f.Add(bls12377.NewG1().ToCompressed(bls12377.NewG1().Zero()))
+	f.Add(pk[:len(pk)-1])
+	f.Fuzz(func(t *testing.T, data []byte) {
+		pk, err := NewPublicKey(data)
+		if err != nil {
+			return
+		}
+		if !bytes.Equal(pk.Bytes(), data) {
+			t.Fatalf("non canonical public key %x accepted", data)
+		}
+		pk2, err := NewPublicKey(pk.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bls12377.NewG1().Equal(pk.G1(), pk2.G1()) {
+			t.Fatal("decode(encode(pk)) != pk")
+		}
+	})
+}
+
+func FuzzAugSchemeMPLVerify(f *testing.F) {
+	asm := new(AugSchemeMPL)
+	sk := KeyGen(testSeed)
+	pk := sk.GetPublicKey().Bytes()
+	msg := []byte("chuwt")
+	sig := asm.Sign(sk, msg)
+	f.Add(pk, msg, sig)
+	f.Add(pk, []byte("other"), sig)
+	f.Add(pk, msg, sig[:len(sig)-1])
+	for _, flag := range []byte{1 << 7, 1 << 6, 1 << 5} {
+		s := append([]byte(nil), sig...)
+		s[0] ^= flag
+		f.Add(pk, msg, s)
+	}
+	f.Fuzz(func(t *testing.T, pkBytes, message, sig []byte) {
+		key, err := NewPublicKey(pkBytes)
+		if err != nil {
+			return
+		}
+		ok := asm.Verify(key, message, sig)
+		if ok && bls12377.NewG1().IsZero(key.G1()) {
+			t.Fatal("the identity public key must not verify")
+		}
+		// signatures are unique, only the encoding of the one of the test key verifies
+		if bytes.Equal(pkBytes, pk) && ok != bytes.Equal(sig, asm.Sign(sk, message)) {
+			t.Fatalf("verification of %x over %x is %v", sig, message, ok)
+		}
+	})
+}
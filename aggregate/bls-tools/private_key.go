@@ -2,7 +2,6 @@ package bls_tools
 
 import (
 	"encoding/hex"
-	"math/big"
 
 	"gnark/aggregate/bls12377"
 )
@@ -12,28 +11,23 @@ var Hidden = []byte{
 	117, 66, 188, 87, 242, 178, 37, 130, 121, 159, 157, 101, 126, 236, 70, 153,
 }
 
-var GroupOrder = new(big.Int).SetBytes([]byte{
-	115, 237, 167, 83, 41, 157, 125, 72, 51, 57, 216, 8, 9, 161, 216, 5,
-	83, 189, 164, 2, 255, 254, 91, 254, 255, 255, 255, 255, 0, 0, 0, 1,
-})
+var GroupOrder = bls12377.NewG1().Q()
 
 const PrivateKeySize = 32
 
 type PrivateKey struct {
-	value *big.Int
+	value *bls12377.Fr
 }
 
 func (key PrivateKey) GetPublicKey() PublicKey {
 	g1 := bls12377.NewG1()
 	return PublicKey{
-		value: g1.MulScalar(g1.New(), G1Generator(), bls12377.NewFr().FromBytes(key.value.Bytes())),
+		value: g1.MulScalarCT(g1.New(), G1Generator(), key.value),
 	}
 }
 
 func (key PrivateKey) Bytes() []byte {
-	output := make([]byte, PrivateKeySize)
-	key.value.FillBytes(output)
-	return output
+	return key.value.ToBytes()
 }
 
 func (key PrivateKey) Hex() string {
@@ -57,15 +51,14 @@ func (key PrivateKey) LocalSk() PrivateKey {
 }
 
 func (key PrivateKey) SyntheticSk(hiddenPuzzleHash []byte) PrivateKey {
-	secretExponent := new(big.Int).SetBytes(key.Bytes())
 	pk := key.GetPublicKey()
 	syntheticOffset := calculateSyntheticOffset(pk.Bytes(), hiddenPuzzleHash)
-	syntheticSecretExponent := new(big.Int).Mod(new(big.Int).Add(secretExponent, syntheticOffset), GroupOrder)
-	return KeyFromBytes(syntheticSecretExponent.Bytes())
+	syntheticSecretExponent := bls12377.NewFr()
+	syntheticSecretExponent.Add(key.value, syntheticOffset)
+	return PrivateKey{syntheticSecretExponent}
 }
 
-func calculateSyntheticOffset(pk []byte, hiddenPuzzleHash []byte) *big.Int {
+func calculateSyntheticOffset(pk []byte, hiddenPuzzleHash []byte) *bls12377.Fr {
 	blob := Hash256(append(pk, hiddenPuzzleHash...))
-	offset := new(big.Int).SetBytes(blob)
-	return new(big.Int).Mod(offset, GroupOrder)
+	return bls12377.NewFr().FromBytes(blob)
 }
@@ -3,23 +3,26 @@ package bls_tools
 import (
 	"fmt"
 	"testing"
+
+	"gnark/aggregate/bls12377"
 )
 
 func Test0BytesTruncatingProblem(t *testing.T) {
 	testMnemonic := "blood floor grow axis carbon ladder hybrid clutch flight satoshi fork main"
 	privateKey := KeyGenWithMnemonic(testMnemonic, "")
 
+	// Keys of BLS12-377 whose first byte is zero, they must keep their 32 bytes.
 	testCases := []struct {
 		index       uint32
 		expectedHex string
 	}{
 		{
-			index:       26,
-			expectedHex: "0x00cbe82fbff4b214b54ca763f012bd4a74824ccf8dd64186c5651216ebfc711f",
+			index:       21,
+			expectedHex: "0x00ccde8c94fcec48efba26fcdc258bb975afea8eb3626267f90efcec0843992c",
 		},
 		{
-			index:       239,
-			expectedHex: "0x00f235cdd3c56397dda0e6d808be09e0121653ff74944a33f4cfc11174dfa600",
+			index:       120,
+			expectedHex: "0x000b9f2343cfb00b3490c2c0c1a6658a33d84b68bcf4e11a58f2287b0bf3a118",
 		},
 	}
 
@@ -33,3 +36,12 @@ func Test0BytesTruncatingProblem(t *testing.T) {
 		})
 	}
 }
+
+func TestPublicKeyMatchesVariableTimeMul(t *testing.T) {
+	sk := KeyGen(testSeed)
+	g1 := bls12377.NewG1()
+	expected := g1.MulScalar(g1.New(), G1Generator(), sk.value)
+	if !g1.Equal(expected, sk.GetPublicKey().G1()) {
+		t.Fatal("public key must not depend on multiplication method")
+	}
+}
@@ -0,0 +1,178 @@
+package bls_tools
+
+import (
+	"math/big"
+)
+
+// Scheme is one of the signature schemes of the BLS signature draft on a curve. Private keys
+// are integers, public keys and signatures are in the encodings of the curve.
+type Scheme interface {
+	Curve() Curve
+	Ciphersuite() Ciphersuite
+	// DST is the domain separation tag messages are hashed with.
+	DST() []byte
+	Sign(sk *big.Int, msg []byte) ([]byte, error)
+	Verify(pk, msg, sig []byte) bool
+	Aggregate(sigs ...[]byte) ([]byte, error)
+	AggregateVerify(pks, msgs [][]byte, sig []byte) bool
+}
+
+// BasicScheme requires the messages of an aggregate signature to be distinct.
+type BasicScheme struct {
+	curve Curve
+}
+
+// NewBasicScheme returns the basic scheme on curve c.
+func NewBasicScheme(c Curve) *BasicScheme {
+	return &BasicScheme{curve: c}
+}
+
+func (s *BasicScheme) Curve() Curve { return s.curve }
+
+func (s *BasicScheme) Ciphersuite() Ciphersuite { return s.curve.Ciphersuite().WithScheme(NUL) }
+
+func (s *BasicScheme) DST() []byte { return s.Ciphersuite().DST() }
+
+func (s *BasicScheme) Sign(sk *big.Int, msg []byte) ([]byte, error) {
+	return s.curve.Sign(sk, msg, s.DST())
+}
+
+func (s *BasicScheme) Verify(pk, msg, sig []byte) bool {
+	return coreVerify(s.curve, [][]byte{pk}, [][]byte{msg}, sig, s.DST())
+}
+
+func (s *BasicScheme) Aggregate(sigs ...[]byte) ([]byte, error) {
+	return s.curve.AddSignatures(sigs...)
+}
+
+func (s *BasicScheme) AggregateVerify(pks, msgs [][]byte, sig []byte) bool {
+	seen := make(map[string]bool, len(msgs))
+	for _, m := range msgs {
+		if seen[string(m)] {
+			return false
+		}
+		seen[string(m)] = true
+	}
+	return coreVerify(s.curve, pks, msgs, sig, s.DST())
+}
+
+// AugScheme prepends the public key of the signer to each message. On BLS12377G2 it signs as
+// AugSchemeMPL.
+type AugScheme struct {
+	curve Curve
+}
+
+// NewAugScheme returns the message augmentation scheme on curve c.
+func NewAugScheme(c Curve) *AugScheme {
+	return &AugScheme{curve: c}
+}
+
+func (s *AugScheme) Curve() Curve { return s.curve }
+
+func (s *AugScheme) Ciphersuite() Ciphersuite { return s.curve.Ciphersuite().WithScheme(AUG) }
+
+func (s *AugScheme) DST() []byte { return s.Ciphersuite().DST() }
+
+func (s *AugScheme) Sign(sk *big.Int, msg []byte) ([]byte, error) {
+	return s.curve.Sign(sk, augment(s.curve.PublicKey(sk), msg), s.DST())
+}
+
+func (s *AugScheme) Verify(pk, msg, sig []byte) bool {
+	return s.AggregateVerify([][]byte{pk}, [][]byte{msg}, sig)
+}
+
+func (s *AugScheme) Aggregate(sigs ...[]byte) ([]byte, error) {
+	return s.curve.AddSignatures(sigs...)
+}
+
+func (s *AugScheme) AggregateVerify(pks, msgs [][]byte, sig []byte) bool {
+	if len(pks) != len(msgs) {
+		return false
+	}
+	augmented := make([][]byte, len(msgs))
+	for i := range msgs {
+		augmented[i] = augment(pks[i], msgs[i])
+	}
+	return coreVerify(s.curve, pks, augmented, sig, s.DST())
+}
+
+func augment(pk, msg []byte) []byte {
+	return append(append(make([]byte, 0, len(pk)+len(msg)), pk...), msg...)
+}
+
+// PopScheme relies on proofs of possession of the keys, which allow aggregate signatures of a
+// single message to be checked against the sum of public keys.
+type PopScheme struct {
+	curve Curve
+}
+
+// NewPopScheme returns the proof of possession scheme on curve c.
+func NewPopScheme(c Curve) *PopScheme {
+	return &PopScheme{curve: c}
+}
+
+func (s *PopScheme) Curve() Curve { return s.curve }
+
+func (s *PopScheme) Ciphersuite() Ciphersuite { return s.curve.Ciphersuite().WithScheme(POP) }
+
+func (s *PopScheme) DST() []byte { return s.Ciphersuite().DST() }
+
+// PopDST is the domain separation tag public keys are hashed with in proofs of possession.
+func (s *PopScheme) PopDST() []byte {
+	return s.Ciphersuite().PopDST()
+}
+
+func (s *PopScheme) Sign(sk *big.Int, msg []byte) ([]byte, error) {
+	return s.curve.Sign(sk, msg, s.DST())
+}
+
+func (s *PopScheme) Verify(pk, msg, sig []byte) bool {
+	return coreVerify(s.curve, [][]byte{pk}, [][]byte{msg}, sig, s.DST())
+}
+
+func (s *PopScheme) Aggregate(sigs ...[]byte) ([]byte, error) {
+	return s.curve.AddSignatures(sigs...)
+}
+
+func (s *PopScheme) AggregateVerify(pks, msgs [][]byte, sig []byte) bool {
+	return coreVerify(s.curve, pks, msgs, sig, s.DST())
+}
+
+// PopProve returns the proof of possession of sk.
+func (s *PopScheme) PopProve(sk *big.Int) ([]byte, error) {
+	return s.curve.Sign(sk, s.curve.PublicKey(sk), s.PopDST())
+}
+
+// PopVerify checks the proof of possession of the key pk.
+func (s *PopScheme) PopVerify(pk, proof []byte) bool {
+	return coreVerify(s.curve, [][]byte{pk}, [][]byte{pk}, proof, s.PopDST())
+}
+
+// FastAggregateVerify checks an aggregate signature of msg by keys whose proofs of possession
+// have been verified.
+func (s *PopScheme) FastAggregateVerify(pks [][]byte, msg, sig []byte) bool {
+	for _, pk := range pks {
+		if s.curve.ValidatePublicKey(pk) != nil {
+			return false
+		}
+	}
+	sum, err := s.curve.AddPublicKeys(pks...)
+	if err != nil {
+		return false
+	}
+	return s.curve.Verify([][]byte{sum}, [][]byte{msg}, sig, s.DST())
+}
+
+// coreVerify validates the public keys, the identity key verifies the identity signature of
+// every message, and checks the pairing equation.
+func coreVerify(c Curve, pks, msgs [][]byte, sig, dst []byte) bool {
+	if len(pks) != len(msgs) || len(pks) < 1 {
+		return false
+	}
+	for _, pk := range pks {
+		if c.ValidatePublicKey(pk) != nil {
+			return false
+		}
+	}
+	return c.Verify(pks, msgs, sig, dst)
+}
@@ -0,0 +1,136 @@
+package bls_tools
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+)
+
+func testSchemes(c Curve) []Scheme {
+	return []Scheme{NewBasicScheme(c), NewAugScheme(c), NewPopScheme(c)}
+}
+
+func TestSchemes(t *testing.T) {
+	msgs := [][]byte{[]byte("chuwt1"), []byte("chuwt2")}
+	for _, c := range Curves {
+		sks := []*big.Int{GenerateKey(c, testSeed), GenerateKey(c, []byte("another seed of at least 32 bytes"))}
+		pks := [][]byte{c.PublicKey(sks[0]), c.PublicKey(sks[1])}
+		if len(pks[0]) != c.PublicKeySize() {
+			t.Fatalf("%s: public key of %d bytes", c.Name(), len(pks[0]))
+		}
+		for _, s := range testSchemes(c) {
+			sigs := make([][]byte, len(sks))
+			for i := range sks {
+				var err error
+				if sigs[i], err = s.Sign(sks[i], msgs[i]); err != nil {
+					t.Fatal(err)
+				}
+				if len(sigs[i]) != c.SignatureSize() {
+					t.Fatalf("%s %s: signature of %d bytes", c.Name(), s.DST(), len(sigs[i]))
+				}
+			}
+			if !s.Verify(pks[0], msgs[0], sigs[0]) {
+				t.Fatalf("%s: signature must verify", s.DST())
+			}
+			if s.Verify(pks[1], msgs[0], sigs[0]) || s.Verify(pks[0], msgs[1], sigs[0]) {
+				t.Fatalf("%s: signature verifies with another key or message", s.DST())
+			}
+			agg, err := s.Aggregate(sigs...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !s.AggregateVerify(pks, msgs, agg) {
+				t.Fatalf("%s: aggregate signature must verify", s.DST())
+			}
+			if s.AggregateVerify(pks, [][]byte{msgs[1], msgs[0]}, agg) || s.AggregateVerify(pks, msgs[:1], agg) {
+				t.Fatalf("%s: aggregate signature verifies with other messages", s.DST())
+			}
+			if _, err := s.Aggregate(); err == nil {
+				t.Fatalf("%s: empty aggregate must fail", s.DST())
+			}
+		}
+	}
+}
+
+func TestSchemesRejectIdentity(t *testing.T) {
+	for _, c := range Curves {
+		pk := c.PublicKey(big.NewInt(0))
+		if err := c.ValidatePublicKey(pk); err != ErrIdentityKey {
+			t.Fatalf("%s: identity public key validates: %v", c.Name(), err)
+		}
+		sig, err := c.Sign(big.NewInt(0), []byte("any message"), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, s := range testSchemes(c) {
+			if s.Verify(pk, []byte("any message"), sig) {
+				t.Fatalf("%s: the identity public key must not verify", s.DST())
+			}
+		}
+	}
+}
+
+func TestBasicSchemeDistinctMessages(t *testing.T) {
+	for _, c := range Curves {
+		s := NewBasicScheme(c)
+		sks := []*big.Int{big.NewInt(3), big.NewInt(5)}
+		msg := []byte("chuwt")
+		sig0, _ := s.Sign(sks[0], msg)
+		sig1, _ := s.Sign(sks[1], msg)
+		agg, err := s.Aggregate(sig0, sig1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s.AggregateVerify([][]byte{c.PublicKey(sks[0]), c.PublicKey(sks[1])}, [][]byte{msg, msg}, agg) {
+			t.Fatalf("%s: messages of the basic scheme must be distinct", c.Name())
+		}
+	}
+}
+
+func TestPopScheme(t *testing.T) {
+	for _, c := range Curves {
+		s := NewPopScheme(c)
+		sks := []*big.Int{GenerateKey(c, testSeed), big.NewInt(7)}
+		pks := [][]byte{c.PublicKey(sks[0]), c.PublicKey(sks[1])}
+		proof, err := s.PopProve(sks[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !s.PopVerify(pks[0], proof) || s.PopVerify(pks[1], proof) {
+			t.Fatalf("%s: proof of possession", c.Name())
+		}
+		// a proof of possession is not a signature of the public key
+		if s.Verify(pks[0], pks[0], proof) {
+			t.Fatalf("%s: proof of possession verifies as a signature", c.Name())
+		}
+		msg := []byte("chuwt")
+		sig0, _ := s.Sign(sks[0], msg)
+		sig1, _ := s.Sign(sks[1], msg)
+		agg, err := s.Aggregate(sig0, sig1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !s.FastAggregateVerify(pks, msg, agg) || s.FastAggregateVerify(pks[:1], msg, agg) {
+			t.Fatalf("%s: fast aggregate verify", c.Name())
+		}
+	}
+}
+
+func TestAugSchemeMatchesAugSchemeMPL(t *testing.T) {
+	sk := KeyGen(testSeed)
+	if GenerateKey(BLS12377G2, testSeed).Cmp(sk.value.ToBig()) != 0 {
+		t.Fatal("GenerateKey differs from KeyGen")
+	}
+	s := NewAugScheme(BLS12377G2)
+	if !bytes.Equal(s.DST(), AugSchemeDst) {
+		t.Fatalf("DST %s", s.DST())
+	}
+	msg := []byte("chuwt")
+	sig, err := s.Sign(sk.value.ToBig(), msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(sig, new(AugSchemeMPL).Sign(sk, msg)) {
+		t.Fatal("signature differs from AugSchemeMPL")
+	}
+}
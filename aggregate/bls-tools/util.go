@@ -3,39 +3,14 @@ package bls_tools
 import (
 	"crypto/sha256"
 	"encoding/binary"
-	"encoding/hex"
-	"log"
-	"math/big"
 
 	"gnark/aggregate/bls12377"
 	"golang.org/x/crypto/hkdf"
 )
 
-var g1One, _ = hex.DecodeString("" +
-	"17f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb" +
-	"08b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e1",
-)
-
-//var g1One, _ = hex.DecodeString("" +
-//	"81937999373150964239938255573465948239988671502647976594219695644855304257327692006745978603320413799295628339695" +
-//	"241266749859715473739788878240585681733927191168601896383759122102112907357779751001206799952863815012735208165030",
-//)
-
-//var g1One = []byte{
-//	0x17, 0xf1, 0xd3, 0xa7, 0x31, 0x97, 0xd7, 0x94, 0x26, 0x95, 0x63, 0x8c, 0x4f, 0xa9, 0xac, 0x0f,
-//	0xc3, 0x68, 0x8c, 0x4f, 0x97, 0x74, 0xb9, 0x05, 0xa1, 0x4e, 0x3a, 0x3f, 0x17, 0x1b, 0xac, 0x58,
-//	0x6c, 0x55, 0xe8, 0x3f, 0xf9, 0x7a, 0x1a, 0xef, 0xfb, 0x3a, 0xf0, 0x0a, 0xdb, 0x22, 0xc6, 0xbb,
-//	0x08, 0xb3, 0xf4, 0x81, 0xe3, 0xaa, 0x0f, 0x1a, 0x09, 0xe3, 0x0e, 0xd7, 0x41, 0xd8, 0xae, 0x4f,
-//	0xcf, 0x5e, 0x09, 0x5d, 0x5d, 0x00, 0xaf, 0x60, 0x0d, 0xb1, 0x8c, 0xb2, 0xc0, 0x4b, 0x3e, 0xdd,
-//	0x03, 0xcc, 0x74, 0x4a, 0x28, 0x88, 0xae, 0x40, 0xca, 0xa2, 0x32, 0x94, 0x6c, 0x5e, 0x7e, 0x1,
-//}
-
+// G1Generator returns the BLS12-377 generator of G1.
 func G1Generator() *bls12377.PointG1 {
-	one, err := bls12377.NewG1().FromBytes(g1One)
-	if err != nil {
-		log.Panicf(" generating point err: %s", err)
-	}
-	return one
+	return bls12377.NewG1().One()
 }
 
 func extractExpand(L int, key, salt, info []byte) (okm []byte) {
@@ -52,8 +27,7 @@ func ikmToLamportSk(ikm, salt []byte) []byte {
 func parentSkToLamportPk(parentSk PrivateKey, index int) []byte {
 	salt := make([]byte, 4)
 	binary.BigEndian.PutUint32(salt, uint32(index))
-	ikm := make([]byte, 32)
-	parentSk.value.FillBytes(ikm)
+	ikm := parentSk.Bytes()
 	notIkm := make([]byte, len(ikm))
 	for i, e := range ikm {
 		notIkm[i] = e ^ 0xFF
@@ -100,10 +74,10 @@ func DeriveChildSkUnhardened(parentSk PrivateKey, index uint32) PrivateKey {
 	hash := Hash256(append(parentSk.GetPublicKey().Bytes(), salt...))
 
 	// bls.PrivateKey.aggregate([PrivateKey.from_bytes(h), parent_sk])
-	sum := new(big.Int).Add(new(big.Int).SetBytes(hash), new(big.Int).SetBytes(parentSk.Bytes()))
-	bytes := new(big.Int).Mod(sum, bls12377.NewG1().Q()).Bytes()
+	sum := bls12377.NewFr()
+	sum.Add(bls12377.NewFr().FromBytes(hash), parentSk.value)
 
-	return KeyFromBytes(bytes)
+	return PrivateKey{sum}
 }
 
 // To make keys more secure, choose path len value of at least 4
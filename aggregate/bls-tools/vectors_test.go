@@ -0,0 +1,589 @@
+package bls_tools
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	bls12377_ecc "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	bls12377_fp "github.com/consensys/gnark-crypto/ecc/bls12-377/fp"
+	bls12381_ecc "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	bls12381_fp "github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+	"github.com/consensys/gnark-crypto/field/hash"
+
+	"gnark/aggregate/bls12377"
+)
+
+// Vectors of RFC 9380 are in the format of the hash-to-curve repository, signature vectors in
+// the input/output format of the Ethereum BLS test suite. Files with an RFC source are copied
+// from the RFC, the BLS12-377 ones are generated with gnark-crypto, see TestGenerateVectors.
+
+var updateVectors = flag.Bool("update", false, "regenerate the generated vectors in testdata")
+
+type expandMessageVectors struct {
+	DST    string `json:"DST"`
+	Hash   string `json:"hash"`
+	K      int    `json:"k"`
+	Name   string `json:"name"`
+	Source string `json:"source"`
+	Tests  []struct {
+		LenInBytes   string `json:"len_in_bytes"`
+		Msg          string `json:"msg"`
+		UniformBytes string `json:"uniform_bytes"`
+	} `json:"tests"`
+}
+
+type vectorPoint struct {
+	X string `json:"x"`
+	Y string `json:"y"`
+}
+
+type hashToCurveVector struct {
+	P   vectorPoint `json:"P"`
+	Q0  vectorPoint `json:"Q0"`
+	Q1  vectorPoint `json:"Q1"`
+	Msg string      `json:"msg"`
+	U   []string    `json:"u"`
+}
+
+type hashToCurveVectors struct {
+	Ciphersuite  string              `json:"ciphersuite"`
+	Curve        string              `json:"curve"`
+	DST          string              `json:"dst"`
+	RandomOracle bool                `json:"randomOracle"`
+	Source       string              `json:"source"`
+	Vectors      []hashToCurveVector `json:"vectors"`
+}
+
+type signCase struct {
+	Input struct {
+		Privkey string `json:"privkey"`
+		Message string `json:"message"`
+	} `json:"input"`
+	Output string `json:"output"`
+}
+
+type verifyCase struct {
+	Input struct {
+		Pubkey    string `json:"pubkey"`
+		Message   string `json:"message"`
+		Signature string `json:"signature"`
+	} `json:"input"`
+	Output bool `json:"output"`
+}
+
+type aggregateCase struct {
+	Input  []string `json:"input"`
+	Output *string  `json:"output"`
+}
+
+type aggregateVerifyCase struct {
+	Input struct {
+		Pubkeys   []string `json:"pubkeys"`
+		Messages  []string `json:"messages"`
+		Signature string   `json:"signature"`
+	} `json:"input"`
+	Output bool `json:"output"`
+}
+
+type signatureVectors struct {
+	Ciphersuite     string                `json:"ciphersuite"`
+	Source          string                `json:"source"`
+	Sign            []signCase            `json:"sign"`
+	Verify          []verifyCase          `json:"verify"`
+	Aggregate       []aggregateCase       `json:"aggregate"`
+	AggregateVerify []aggregateVerifyCase `json:"aggregate_verify"`
+}
+
+func readVectors(t *testing.T, name string, v interface{}) {
+	b, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		t.Fatal(name, err)
+	}
+}
+
+func writeVectors(t *testing.T, name string, v interface{}) {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join("testdata", name), append(b, '\n'), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func decodeHex(t testing.TB, s string) []byte {
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// fpHex formats field elements as the RFC does, elements of extension fields are their
+// coefficients separated by commas.
+func fpHex(coefficients ...[]byte) string {
+	s := make([]string, len(coefficients))
+	for i, c := range coefficients {
+		s[i] = "0x" + hex.EncodeToString(c)
+	}
+	return strings.Join(s, ",")
+}
+
+func vectorName(msg string) string {
+	if len(msg) > 16 {
+		return msg[:16] + "..."
+	}
+	return strconv.Quote(msg)
+}
+
+func TestExpandMessageVectors(t *testing.T) {
+	var v expandMessageVectors
+	readVectors(t, "expand_message_xmd_SHA256_38.json", &v)
+	for _, tc := range v.Tests {
+		n, err := strconv.ParseInt(tc.LenInBytes, 0, 32)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := decodeHex(t, tc.UniformBytes)
+		out, err := bls12377.ExpandMsgXMD([]byte(tc.Msg), []byte(v.DST), int(n))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(out, expected) {
+			t.Fatalf("expand_message_xmd(%s, %d) = %x", vectorName(tc.Msg), n, out)
+		}
+		// gnark-crypto's expander, used by the BLS12-381 vectors
+		out, err = hash.ExpandMsgXmd([]byte(tc.Msg), []byte(v.DST), int(n))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(out, expected) {
+			t.Fatalf("gnark-crypto expand_message_xmd(%s, %d) = %x", vectorName(tc.Msg), n, out)
+		}
+	}
+}
+
+// hashToCurveSuites checks a vector of each ciphersuite. BLS12-377 is the curve of this package,
+// BLS12-381 vectors are checked with gnark-crypto.
+var hashToCurveSuites = map[string]func(t *testing.T, dst []byte, v *hashToCurveVector){
+	"BLS12377G2_XMD:SHA-256_SSWU_RO_": checkBLS12377G2Vector,
+	"BLS12381G1_XMD:SHA-256_SSWU_RO_": checkBLS12381G1Vector,
+	"BLS12381G2_XMD:SHA-256_SSWU_RO_": checkBLS12381G2Vector,
+}
+
+func TestHashToCurveVectors(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "*_SSWU_RO_.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != len(hashToCurveSuites) {
+		t.Fatalf("expected a vector file for each of the %d suites, found %v", len(hashToCurveSuites), files)
+	}
+	for _, file := range files {
+		var v hashToCurveVectors
+		readVectors(t, filepath.Base(file), &v)
+		check, ok := hashToCurveSuites[v.Ciphersuite]
+		if !ok {
+			t.Fatalf("no implementation of %s", v.Ciphersuite)
+		}
+		t.Run(v.Ciphersuite, func(t *testing.T) {
+			for i := range v.Vectors {
+				vec := &v.Vectors[i]
+				t.Run(vectorName(vec.Msg), func(t *testing.T) {
+					check(t, []byte(v.DST), vec)
+				})
+			}
+		})
+	}
+}
+
+// bls12377G2Point returns the coordinates of p in the format of the vectors.
+func bls12377G2Point(p *bls12377.PointG2) vectorPoint {
+	b := bls12377.NewG2().ToBytes(p)
+	return vectorPoint{fpHex(b[:48], b[48:96]), fpHex(b[96:144], b[144:])}
+}
+
+func checkBLS12377G2Vector(t *testing.T, dst []byte, v *hashToCurveVector) {
+	g := bls12377.NewG2()
+	u, err := g.HashToField([]byte(v.Msg), dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range u {
+		if s := fpHex(u[i][:48], u[i][48:]); s != v.U[i] {
+			t.Fatalf("u%d = %s", i, s)
+		}
+		q, err := g.MapToCurve(u[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p := bls12377G2Point(q); p != []vectorPoint{v.Q0, v.Q1}[i] {
+			t.Fatalf("Q%d = %v", i, p)
+		}
+	}
+	p, err := g.HashToCurve([]byte(v.Msg), dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p := bls12377G2Point(p); p != v.P {
+		t.Fatalf("P = %v", p)
+	}
+}
+
+func bls12381Fp(t *testing.T, s string) bls12381_fp.Element {
+	var e bls12381_fp.Element
+	if _, err := e.SetString(s); err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+func bls12381E2(t *testing.T, s string) bls12381_ecc.E2 {
+	c := strings.Split(s, ",")
+	if len(c) != 2 {
+		t.Fatalf("%s is not an element of Fp2", s)
+	}
+	return bls12381_ecc.E2{A0: bls12381Fp(t, c[0]), A1: bls12381Fp(t, c[1])}
+}
+
+func checkBLS12381G1Vector(t *testing.T, dst []byte, v *hashToCurveVector) {
+	u, err := bls12381_fp.Hash([]byte(v.Msg), dst, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sum bls12381_ecc.G1Jac
+	for i, q := range []vectorPoint{v.Q0, v.Q1} {
+		if e := bls12381Fp(t, v.U[i]); !e.Equal(&u[i]) {
+			t.Fatalf("u%d = %s", i, u[i].String())
+		}
+		// gnark-crypto does not expose the isogeny, Q is checked through the cofactor clearing
+		Q := bls12381_ecc.G1Affine{X: bls12381Fp(t, q.X), Y: bls12381Fp(t, q.Y)}
+		var cleared bls12381_ecc.G1Affine
+		cleared.ClearCofactor(&Q)
+		if mapped := bls12381_ecc.MapToG1(u[i]); !Q.IsOnCurve() || !mapped.Equal(&cleared) {
+			t.Fatalf("Q%d differs", i)
+		}
+		sum.AddMixed(&Q)
+	}
+	P := bls12381_ecc.G1Affine{X: bls12381Fp(t, v.P.X), Y: bls12381Fp(t, v.P.Y)}
+	var cleared bls12381_ecc.G1Affine
+	cleared.FromJacobian(sum.ClearCofactor(&sum))
+	if !cleared.Equal(&P) {
+		t.Fatal("P != clear_cofactor(Q0 + Q1)")
+	}
+	p, err := bls12381_ecc.HashToG1([]byte(v.Msg), dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !p.Equal(&P) {
+		t.Fatal("P differs")
+	}
+}
+
+func checkBLS12381G2Vector(t *testing.T, dst []byte, v *hashToCurveVector) {
+	u, err := bls12381_fp.Hash([]byte(v.Msg), dst, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sum bls12381_ecc.G2Jac
+	for i, q := range []vectorPoint{v.Q0, v.Q1} {
+		ui := bls12381_ecc.E2{A0: u[2*i], A1: u[2*i+1]}
+		if e := bls12381E2(t, v.U[i]); !e.Equal(&ui) {
+			t.Fatalf("u%d = %s", i, ui.String())
+		}
+		Q := bls12381_ecc.G2Affine{X: bls12381E2(t, q.X), Y: bls12381E2(t, q.Y)}
+		var cleared bls12381_ecc.G2Affine
+		cleared.ClearCofactor(&Q)
+		if mapped := bls12381_ecc.MapToG2(ui); !Q.IsOnCurve() || !mapped.Equal(&cleared) {
+			t.Fatalf("Q%d differs", i)
+		}
+		sum.AddMixed(&Q)
+	}
+	P := bls12381_ecc.G2Affine{X: bls12381E2(t, v.P.X), Y: bls12381E2(t, v.P.Y)}
+	var cleared bls12381_ecc.G2Affine
+	cleared.FromJacobian(sum.ClearCofactor(&sum))
+	if !cleared.Equal(&P) {
+		t.Fatal("P != clear_cofactor(Q0 + Q1)")
+	}
+	p, err := bls12381_ecc.HashToG2([]byte(v.Msg), dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !p.Equal(&P) {
+		t.Fatal("P differs")
+	}
+}
+
+func TestAugSchemeVectors(t *testing.T) {
+	var v signatureVectors
+	readVectors(t, "aug_scheme_BLS12377.json", &v)
+	if v.Ciphersuite != string(AugSchemeDst) {
+		t.Fatalf("vectors of %s, scheme is %s", v.Ciphersuite, AugSchemeDst)
+	}
+	asm := new(AugSchemeMPL)
+	for i, tc := range v.Sign {
+		sk, err := KeyFromHexString(tc.Input.Privkey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sig := asm.Sign(sk, decodeHex(t, tc.Input.Message)); fpHex(sig) != tc.Output {
+			t.Fatalf("sign %d: %x", i, sig)
+		}
+	}
+	for i, tc := range v.Verify {
+		ok := false
+		if pk, err := NewPublicKey(decodeHex(t, tc.Input.Pubkey)); err == nil {
+			ok = asm.Verify(pk, decodeHex(t, tc.Input.Message), decodeHex(t, tc.Input.Signature))
+		}
+		if ok != tc.Output {
+			t.Fatalf("verify %d: %v", i, ok)
+		}
+	}
+	for i, tc := range v.Aggregate {
+		sigs := make([][]byte, len(tc.Input))
+		for k := range sigs {
+			sigs[k] = decodeHex(t, tc.Input[k])
+		}
+		agg, err := asm.Aggregate(sigs...)
+		switch {
+		case tc.Output == nil && err == nil:
+			t.Fatalf("aggregate %d: expected an error", i)
+		case tc.Output != nil && err != nil:
+			t.Fatalf("aggregate %d: %v", i, err)
+		case tc.Output != nil && fpHex(agg) != *tc.Output:
+			t.Fatalf("aggregate %d: %x", i, agg)
+		}
+	}
+	for i, tc := range v.AggregateVerify {
+		pks, msgs := make([][]byte, len(tc.Input.Pubkeys)), make([][]byte, len(tc.Input.Messages))
+		for k := range pks {
+			pks[k] = decodeHex(t, tc.Input.Pubkeys[k])
+		}
+		for k := range msgs {
+			msgs[k] = decodeHex(t, tc.Input.Messages[k])
+		}
+		if ok := asm.AggregateVerify(pks, msgs, decodeHex(t, tc.Input.Signature)); ok != tc.Output {
+			t.Fatalf("aggregate verify %d: %v", i, ok)
+		}
+	}
+}
+
+// TestGenerateVectors writes the BLS12-377 vectors, which the RFC does not have. Field elements,
+// final points and signatures are computed with gnark-crypto, the encodings of points and the
+// outputs of map_to_curve, which gnark-crypto does not expose, with this package.
+func TestGenerateVectors(t *testing.T) {
+	if !*updateVectors {
+		t.Skip("run with -update to regenerate vectors")
+	}
+	writeVectors(t, "BLS12377G2_XMD_SHA-256_SSWU_RO_.json", generateHashToCurveVectors(t))
+	writeVectors(t, "aug_scheme_BLS12377.json", generateAugSchemeVectors(t))
+}
+
+func bls12377E2Bytes(e *bls12377_ecc.E2) []byte {
+	a0, a1 := e.A0.Bytes(), e.A1.Bytes()
+	return append(a0[:], a1[:]...)
+}
+
+func bls12377E2Hex(e *bls12377_ecc.E2) string {
+	a0, a1 := e.A0.Bytes(), e.A1.Bytes()
+	return fpHex(a0[:], a1[:])
+}
+
+func generateHashToCurveVectors(t *testing.T) *hashToCurveVectors {
+	const suite = "BLS12377G2_XMD:SHA-256_SSWU_RO_"
+	v := &hashToCurveVectors{
+		Ciphersuite:  suite,
+		Curve:        "BLS12-377 G2",
+		DST:          "QUUX-V01-CS02-with-" + suite,
+		RandomOracle: true,
+		Source:       "gnark-crypto v0.12.1, Q0 and Q1 checked against its MapToG2",
+	}
+	g := bls12377.NewG2()
+	for _, msg := range []string{"", "abc", "abcdef0123456789", "q128_" + strings.Repeat("q", 128), "a512_" + strings.Repeat("a", 512)} {
+		u, err := bls12377_fp.Hash([]byte(msg), []byte(v.DST), 4)
+		if err != nil {
+			t.Fatal(err)
+		}
+		vec := hashToCurveVector{Msg: msg}
+		for i := 0; i < 2; i++ {
+			ui := bls12377_ecc.E2{A0: u[2*i], A1: u[2*i+1]}
+			b := bls12377E2Bytes(&ui)
+			vec.U = append(vec.U, fpHex(b[:48], b[48:]))
+			q, err := g.MapToCurve(b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			qb := g.ToBytes(q)
+			var Q bls12377_ecc.G2Affine
+			Q.X.A0.SetBytes(qb[:48])
+			Q.X.A1.SetBytes(qb[48:96])
+			Q.Y.A0.SetBytes(qb[96:144])
+			Q.Y.A1.SetBytes(qb[144:])
+			Q.ClearCofactor(&Q)
+			if mapped := bls12377_ecc.MapToG2(ui); !mapped.Equal(&Q) {
+				t.Fatalf("map_to_curve of %q differs from gnark-crypto", msg)
+			}
+			if i == 0 {
+				vec.Q0 = bls12377G2Point(q)
+			} else {
+				vec.Q1 = bls12377G2Point(q)
+			}
+		}
+		p, err := bls12377_ecc.HashToG2([]byte(msg), []byte(v.DST))
+		if err != nil {
+			t.Fatal(err)
+		}
+		vec.P = vectorPoint{bls12377E2Hex(&p.X), bls12377E2Hex(&p.Y)}
+		v.Vectors = append(v.Vectors, vec)
+	}
+	return v
+}
+
+// augSchemeKeys are the private keys of the signature vectors, derived from their index.
+func augSchemeKeys(n int) []*big.Int {
+	keys := make([]*big.Int, n)
+	for i := range keys {
+		h := sha256.Sum256([]byte(fmt.Sprintf("aug scheme vector key %d", i)))
+		keys[i] = new(big.Int).SetBytes(h[:])
+		keys[i].Mod(keys[i], GroupOrder)
+	}
+	return keys
+}
+
+func generateAugSchemeVectors(t *testing.T) *signatureVectors {
+	g1, g2 := bls12377.NewG1(), bls12377.NewG2()
+	// points are encoded as the scheme does, Fp2 elements of signatures are c0 || c1 unlike
+	// in gnark-crypto
+	compressG1 := func(p *bls12377_ecc.G1Affine) []byte {
+		q, err := g1.Decode(p.Marshal(), bls12377.FormatGnarkCryptoRaw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return g1.ToCompressed(q)
+	}
+	compressG2 := func(p *bls12377_ecc.G2Affine) string {
+		q, err := g2.Decode(p.Marshal(), bls12377.FormatGnarkCryptoRaw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return fpHex(g2.ToCompressed(q))
+	}
+	keys := augSchemeKeys(3)
+	_, _, g1Gen, _ := bls12377_ecc.Generators()
+	pks := make([][]byte, len(keys))
+	for i, sk := range keys {
+		var pk bls12377_ecc.G1Affine
+		pk.ScalarMultiplication(&g1Gen, sk)
+		pks[i] = compressG1(&pk)
+	}
+	// sign prepends the public key to the message as the augmented scheme does
+	sign := func(i int, msg []byte) bls12377_ecc.G2Affine {
+		q, err := bls12377_ecc.HashToG2(append(append([]byte(nil), pks[i]...), msg...), AugSchemeDst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var sig bls12377_ecc.G2Affine
+		sig.ScalarMultiplication(&q, keys[i])
+		return sig
+	}
+	v := &signatureVectors{
+		Ciphersuite: string(AugSchemeDst),
+		Source:      "gnark-crypto v0.12.1, points encoded with aggregate/bls12377",
+	}
+
+	messages := [][]byte{{}, []byte("abc"), bytes.Repeat([]byte{0}, 32), bytes.Repeat([]byte{0xff}, 32)}
+	sigs := make([][]bls12377_ecc.G2Affine, len(keys))
+	for i := range keys {
+		for _, msg := range messages {
+			sigs[i] = append(sigs[i], sign(i, msg))
+			var c signCase
+			c.Input.Privkey = fpHex(keys[i].FillBytes(make([]byte, PrivateKeySize)))
+			c.Input.Message = fpHex(msg)
+			c.Output = compressG2(&sigs[i][len(sigs[i])-1])
+			v.Sign = append(v.Sign, c)
+		}
+	}
+
+	abc := fpHex(messages[1])
+	sig := compressG2(&sigs[0][1])
+	var neg bls12377_ecc.G2Affine
+	neg.Neg(&sigs[0][1])
+	infinityG1, infinityG2 := compressG1(&bls12377_ecc.G1Affine{}), compressG2(&bls12377_ecc.G2Affine{})
+	// the first coordinate of the signature increased by the modulus
+	nonCanonical := decodeHex(t, sig)
+	flags := nonCanonical[0] & 0xe0
+	nonCanonical[0] &^= flags
+	x := new(big.Int).SetBytes(nonCanonical[:48])
+	x.Add(x, bls12377_fp.Modulus()).FillBytes(nonCanonical[:48])
+	nonCanonical[0] |= flags
+	invalidPk := append([]byte(nil), pks[0]...)
+	invalidPk[0] ^= 1 << 6
+	for _, c := range []struct {
+		pk       []byte
+		msg, sig string
+		valid    bool
+	}{
+		{pks[0], abc, sig, true},
+		{pks[0], fpHex(messages[0]), sig, false},
+		{pks[1], abc, sig, false},
+		{pks[0], abc, compressG2(&sigs[1][1]), false},
+		{pks[0], abc, compressG2(&neg), false},
+		{pks[0], abc, infinityG2, false},
+		{infinityG1, abc, infinityG2, false},
+		{pks[0], abc, fpHex(nonCanonical), false},
+		{pks[0], abc, sig[:len(sig)-2], false},
+		{invalidPk, abc, sig, false},
+	} {
+		var vc verifyCase
+		vc.Input.Pubkey, vc.Input.Message, vc.Input.Signature, vc.Output = fpHex(c.pk), c.msg, c.sig, c.valid
+		v.Verify = append(v.Verify, vc)
+	}
+
+	var agg bls12377_ecc.G2Affine
+	agg.Add(&sigs[0][1], &sigs[1][2])
+	aggHex := compressG2(&agg)
+	for _, c := range []struct {
+		sigs   []string
+		output *string
+	}{
+		{[]string{sig, compressG2(&sigs[1][2])}, &aggHex},
+		{[]string{sig}, &sig},
+		{[]string{sig, compressG2(&neg)}, &infinityG2},
+		{[]string{}, nil},
+		{[]string{sig, sig[:len(sig)-2]}, nil},
+	} {
+		v.Aggregate = append(v.Aggregate, aggregateCase{Input: c.sigs, Output: c.output})
+	}
+
+	for _, c := range []struct {
+		pks, msgs []string
+		sig       string
+		valid     bool
+	}{
+		{[]string{fpHex(pks[0]), fpHex(pks[1])}, []string{abc, fpHex(messages[2])}, aggHex, true},
+		{[]string{fpHex(pks[0]), fpHex(pks[1])}, []string{fpHex(messages[2]), abc}, aggHex, false},
+		{[]string{fpHex(pks[1]), fpHex(pks[0])}, []string{abc, fpHex(messages[2])}, aggHex, false},
+		{[]string{fpHex(pks[0]), fpHex(pks[1])}, []string{abc}, aggHex, false},
+		{[]string{fpHex(pks[0])}, []string{abc}, sig, true},
+		{[]string{fpHex(pks[0]), fpHex(infinityG1)}, []string{abc, abc}, sig, false},
+		{[]string{}, []string{}, infinityG2, false},
+	} {
+		var vc aggregateVerifyCase
+		vc.Input.Pubkeys, vc.Input.Messages, vc.Input.Signature, vc.Output = c.pks, c.msgs, c.sig, c.valid
+		v.AggregateVerify = append(v.AggregateVerify, vc)
+	}
+	return v
+}
@@ -0,0 +1,105 @@
+package bls_tools
+
+import (
+	"errors"
+
+	bls12377_ecc "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark/std/algebra/native/sw_bls12377"
+
+	"gnark/aggregate/bls12377"
+)
+
+// G1ToAffine converts p to gnark-crypto's affine point, the identity is (0, 0) in both.
+func G1ToAffine(p *bls12377.PointG1) bls12377_ecc.G1Affine {
+	b := bls12377.NewG1().ToBytes(bls12377.NewG1().New().Set(p))
+	var a bls12377_ecc.G1Affine
+	a.X.SetBytes(b[:48])
+	a.Y.SetBytes(b[48:])
+	return a
+}
+
+// G2ToAffine converts p to gnark-crypto's affine point, the identity is (0, 0) in both.
+func G2ToAffine(p *bls12377.PointG2) bls12377_ecc.G2Affine {
+	b := bls12377.NewG2().ToBytes(bls12377.NewG2().New().Set(p))
+	var a bls12377_ecc.G2Affine
+	a.X.A0.SetBytes(b[:48])
+	a.X.A1.SetBytes(b[48:96])
+	a.Y.A0.SetBytes(b[96:144])
+	a.Y.A1.SetBytes(b[144:])
+	return a
+}
+
+// G1FromAffine converts gnark-crypto's affine point to a point of gnark/aggregate/bls12377.
+// It fails if a is not on the curve.
+func G1FromAffine(a *bls12377_ecc.G1Affine) (*bls12377.PointG1, error) {
+	x, y := a.X.Bytes(), a.Y.Bytes()
+	return bls12377.NewG1().FromBytes(append(x[:], y[:]...))
+}
+
+// G2FromAffine converts gnark-crypto's affine point to a point of gnark/aggregate/bls12377.
+// It fails if a is not on the curve.
+func G2FromAffine(a *bls12377_ecc.G2Affine) (*bls12377.PointG2, error) {
+	var b []byte
+	for _, e := range [][48]byte{a.X.A0.Bytes(), a.X.A1.Bytes(), a.Y.A0.Bytes(), a.Y.A1.Bytes()} {
+		b = append(b, e[:]...)
+	}
+	return bls12377.NewG2().FromBytes(b)
+}
+
+// G1Assignment returns the assignment of p to a BW6-761 circuit.
+func G1Assignment(p *bls12377.PointG1) sw_bls12377.G1Affine {
+	a := G1ToAffine(p)
+	var v sw_bls12377.G1Affine
+	v.Assign(&a)
+	return v
+}
+
+// G2Assignment returns the assignment of p to a BW6-761 circuit.
+func G2Assignment(p *bls12377.PointG2) sw_bls12377.G2Affine {
+	a := G2ToAffine(p)
+	var v sw_bls12377.G2Affine
+	v.Assign(&a)
+	return v
+}
+
+// AugSchemeAssignment is the assignment of an AugSchemeMPL aggregate signature to a circuit
+// checking e(-g1, Sig) * ∏ e(Pk_i, Hm_i) == 1. Messages are hashed with their public keys
+// outside of the circuit.
+type AugSchemeAssignment struct {
+	Pk  []sw_bls12377.G1Affine
+	Hm  []sw_bls12377.G2Affine
+	Sig sw_bls12377.G2Affine
+}
+
+// NewAugSchemeAssignment decodes the public keys and the aggregate signature of messages as
+// AugSchemeMPL.AggregateVerify does. The signature itself is not verified.
+func NewAugSchemeAssignment(pks, messages [][]byte, sig []byte) (*AugSchemeAssignment, error) {
+	if len(pks) != len(messages) || len(pks) < 1 {
+		return nil, errors.New("public keys and messages must have the same non zero length")
+	}
+	g1, g2 := bls12377.NewG1(), bls12377.NewG2()
+	signature, err := g2.FromCompressed(sig)
+	if err != nil {
+		return nil, err
+	}
+	a := &AugSchemeAssignment{
+		Pk:  make([]sw_bls12377.G1Affine, len(pks)),
+		Hm:  make([]sw_bls12377.G2Affine, len(pks)),
+		Sig: G2Assignment(signature),
+	}
+	for i := range pks {
+		p, err := g1.FromCompressed(pks[i])
+		if err != nil {
+			return nil, err
+		}
+		if g1.IsZero(p) {
+			return nil, ErrIdentityKey
+		}
+		q, err := g2.HashToCurve(augment(pks[i], messages[i]), AugSchemeDst)
+		if err != nil {
+			return nil, err
+		}
+		a.Pk[i], a.Hm[i] = G1Assignment(p), G2Assignment(q)
+	}
+	return a, nil
+}
@@ -0,0 +1,97 @@
+package bls_tools
+
+import (
+	"math/big"
+	"testing"
+
+	bls12377_ecc "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark/std/algebra/native/sw_bls12377"
+
+	"gnark/aggregate/bls12377"
+)
+
+func TestAffineRoundTrip(t *testing.T) {
+	g1, g2 := bls12377.NewG1(), bls12377.NewG2()
+	_, _, g1Gen, g2Gen := bls12377_ecc.Generators()
+	for _, s := range []int64{0, 1, 2, 0xffff} {
+		k := big.NewInt(s)
+		p1 := g1.MulScalarBig(g1.New(), g1.One(), k)
+		var e1 bls12377_ecc.G1Affine
+		e1.ScalarMultiplication(&g1Gen, k)
+		if a := G1ToAffine(p1); !a.Equal(&e1) {
+			t.Fatalf("G1ToAffine of %d·g1", s)
+		}
+		back1, err := G1FromAffine(&e1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !g1.Equal(back1, p1) {
+			t.Fatalf("G1FromAffine of %d·g1", s)
+		}
+
+		p2 := g2.MulScalarBig(g2.New(), g2.One(), k)
+		var e2 bls12377_ecc.G2Affine
+		e2.ScalarMultiplication(&g2Gen, k)
+		if a := G2ToAffine(p2); !a.Equal(&e2) {
+			t.Fatalf("G2ToAffine of %d·g2", s)
+		}
+		back2, err := G2FromAffine(&e2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !g2.Equal(back2, p2) {
+			t.Fatalf("G2FromAffine of %d·g2", s)
+		}
+
+		var v1 sw_bls12377.G1Affine
+		v1.Assign(&e1)
+		if G1Assignment(p1) != v1 {
+			t.Fatalf("G1Assignment of %d·g1", s)
+		}
+		var v2 sw_bls12377.G2Affine
+		v2.Assign(&e2)
+		if G2Assignment(p2) != v2 {
+			t.Fatalf("G2Assignment of %d·g2", s)
+		}
+	}
+	var off bls12377_ecc.G1Affine
+	off.X.SetOne()
+	if _, err := G1FromAffine(&off); err == nil {
+		t.Fatal("point off the curve must be rejected")
+	}
+}
+
+func TestNewAugSchemeAssignment(t *testing.T) {
+	asm := new(AugSchemeMPL)
+	sk := KeyGen(testSeed)
+	pk := sk.GetPublicKey()
+	msg := []byte("chuwt")
+	sig := asm.Sign(sk, msg)
+	a, err := NewAugSchemeAssignment([][]byte{pk.Bytes()}, [][]byte{msg}, sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hm, err := bls12377_ecc.HashToG2(append(pk.Bytes(), msg...), AugSchemeDst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v sw_bls12377.G2Affine
+	v.Assign(&hm)
+	if a.Hm[0] != v || a.Pk[0] != G1Assignment(pk.G1()) {
+		t.Fatal("assignment differs from gnark-crypto")
+	}
+	identity := bls12377.NewG1().ToCompressed(bls12377.NewG1().Zero())
+	for _, tc := range []struct {
+		pks, msgs [][]byte
+		sig       []byte
+	}{
+		{[][]byte{pk.Bytes()}, [][]byte{msg, msg}, sig},
+		{nil, nil, sig},
+		{[][]byte{identity}, [][]byte{msg}, sig},
+		{[][]byte{pk.Bytes()}, [][]byte{msg}, sig[1:]},
+	} {
+		if _, err := NewAugSchemeAssignment(tc.pks, tc.msgs, tc.sig); err == nil {
+			t.Fatal("invalid input must be rejected")
+		}
+	}
+}
@@ -140,6 +140,18 @@ var qr1 = &Fr{0x7d1c7ffffffffff3, 0x7257f50f6ffffff2, 0x16d81575512c0fee, 0x0d4b
 // qr2 = qr^2 mod q
 var qr2 = &Fr{0x25d577bab861857b, 0xcc2c27b58860591f, 0xa7cc008fe5dc8593, 0x011fdae7eff1c939}
 
+// q - 1 = 2^s * t where s is the 2-adicity of the scalar field
+var frTwoAdicity = 47
+
+// (t - 1) / 2
+var frSqrtExp = bigFromHex("0x12ab655e9a2ca55660b44d1e5c37b00159aa76fed00000010a11")
+
+// frSqrtZ = 22^t in Montgomery form, 22 is the smallest quadratic non residue
+var frSqrtZ = &Fr{0xaf80da4dda3ad648, 0x5e223adbfc381dac, 0x03ba0666b2f92525, 0x0f906c5b3befb0ce}
+
+// q - 2
+var qMinus2Big = bigFromHex("0x12ab655e9a2ca55660b44d1e5c37b00159aa76fed00000010a117fffffffffff")
+
 // Psi values for faster cofactor clearing
 // z = u + 1
 
@@ -0,0 +1,129 @@
+package bls12377
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Format names a byte layout of G1 and G2 points.
+type Format int
+
+const (
+	// FormatZcashCompressed is the x coordinate with compression, infinity and sign flags
+	// in the three most significant bits. Fp2 elements are written as c1 || c0.
+	// It is the same layout as gnark-crypto's Bytes.
+	FormatZcashCompressed Format = iota
+	// FormatZcashUncompressed is x || y with only the infinity flag allowed in the
+	// three most significant bits. Fp2 elements are written as c1 || c0.
+	FormatZcashUncompressed
+	// FormatGnarkCryptoRaw is the layout of gnark-crypto's Marshal. Encoding is equal to
+	// FormatZcashUncompressed, decoding accepts both compressed and uncompressed inputs
+	// as Unmarshal does.
+	FormatGnarkCryptoRaw
+	// FormatEIP2537 is x || y where each base field element is left padded to 64 bytes.
+	// Fp2 elements are written as c0 || c1 and infinity is encoded as all zeros.
+	FormatEIP2537
+)
+
+var formatNames = map[Format]string{
+	FormatZcashCompressed:   "zcash-compressed",
+	FormatZcashUncompressed: "zcash-uncompressed",
+	FormatGnarkCryptoRaw:    "gnark-crypto-raw",
+	FormatEIP2537:           "eip2537",
+}
+
+// String returns name of the format.
+func (f Format) String() string {
+	if name, ok := formatNames[f]; ok {
+		return name
+	}
+	return fmt.Sprintf("Format(%d)", int(f))
+}
+
+// ParseFormat returns the format with given name.
+func ParseFormat(name string) (Format, error) {
+	for f, s := range formatNames {
+		if s == name {
+			return f, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown point format %q", name)
+}
+
+// G1Size returns the size of a G1 point encoded in the format.
+func (f Format) G1Size() int {
+	switch f {
+	case FormatZcashCompressed:
+		return fpByteSize
+	case FormatEIP2537:
+		return 2 * eip2537FpByteSize
+	}
+	return 2 * fpByteSize
+}
+
+// G2Size returns the size of a G2 point encoded in the format.
+func (f Format) G2Size() int {
+	return 2 * f.G1Size()
+}
+
+// eip2537FpByteSize is the size of a padded base field element in EIP-2537 encoding.
+const eip2537FpByteSize = 64
+
+const (
+	flagCompressed byte = 1 << 7
+	flagInfinity   byte = 1 << 6
+	flagSign       byte = 1 << 5
+	flagMask            = flagCompressed | flagInfinity | flagSign
+)
+
+// decodeUncompressedFlags checks the flags of zcash uncompressed input and returns
+// true if it encodes the point at infinity.
+func decodeUncompressedFlags(in []byte) (bool, error) {
+	switch in[0] & flagMask {
+	case 0:
+		return false, nil
+	case flagInfinity:
+		for i, v := range in {
+			if (i == 0 && v != flagInfinity) || (i != 0 && v != 0x00) {
+				return false, errors.New("input string must be zero when infinity flag is set")
+			}
+		}
+		return true, nil
+	}
+	return false, errors.New("invalid flags for uncompressed input")
+}
+
+// swapFp2Halves swaps c0 and c1 of each Fp2 element in place.
+func swapFp2Halves(in []byte) {
+	for i := 0; i < len(in); i += 2 * fpByteSize {
+		for j := 0; j < fpByteSize; j++ {
+			in[i+j], in[i+fpByteSize+j] = in[i+fpByteSize+j], in[i+j]
+		}
+	}
+}
+
+// eip2537Pad left pads each base field element to 64 bytes.
+func eip2537Pad(in []byte) []byte {
+	n := len(in) / fpByteSize
+	out := make([]byte, n*eip2537FpByteSize)
+	for i := 0; i < n; i++ {
+		copy(out[(i+1)*eip2537FpByteSize-fpByteSize:(i+1)*eip2537FpByteSize], in[i*fpByteSize:(i+1)*fpByteSize])
+	}
+	return out
+}
+
+// eip2537Unpad removes padding of each base field element and checks that padding bytes are zero.
+func eip2537Unpad(in []byte) ([]byte, error) {
+	n := len(in) / eip2537FpByteSize
+	out := make([]byte, n*fpByteSize)
+	for i := 0; i < n; i++ {
+		chunk := in[i*eip2537FpByteSize : (i+1)*eip2537FpByteSize]
+		for _, v := range chunk[:eip2537FpByteSize-fpByteSize] {
+			if v != 0 {
+				return nil, errors.New("padding bytes must be zero")
+			}
+		}
+		copy(out[i*fpByteSize:], chunk[eip2537FpByteSize-fpByteSize:])
+	}
+	return out, nil
+}
@@ -0,0 +1,272 @@
+package bls12377
+
+import (
+	"bytes"
+	"testing"
+
+	gnark "github.com/consensys/gnark-crypto/ecc/bls12-377"
+)
+
+var testFormats = []Format{FormatZcashCompressed, FormatZcashUncompressed, FormatGnarkCryptoRaw, FormatEIP2537}
+
+func TestFormatNames(t *testing.T) {
+	for _, f := range testFormats {
+		f2, err := ParseFormat(f.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f != f2 {
+			t.Fatal("bad format name round trip")
+		}
+	}
+	if _, err := ParseFormat("zcash"); err == nil {
+		t.Fatal("unknown format name must be rejected")
+	}
+}
+
+func TestG1Codec(t *testing.T) {
+	g := NewG1()
+	points := []*PointG1{g.Zero(), g.One()}
+	for i := 0; i < 10; i++ {
+		points = append(points, g.randCorrect())
+	}
+	for _, f := range testFormats {
+		for _, p := range points {
+			enc, err := g.Encode(p, f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(enc) != f.G1Size() {
+				t.Fatalf("%s: bad encoding size", f)
+			}
+			p2, err := g.Decode(enc, f)
+			if err != nil {
+				t.Fatalf("%s: %v", f, err)
+			}
+			if !g.Equal(p, p2) {
+				t.Fatalf("%s: round trip failed", f)
+			}
+			for _, to := range testFormats {
+				converted, err := g.Convert(enc, f, to)
+				if err != nil {
+					t.Fatal(err)
+				}
+				expected, _ := g.Encode(p, to)
+				if !bytes.Equal(converted, expected) {
+					t.Fatalf("%s -> %s: conversion failed", f, to)
+				}
+			}
+		}
+	}
+}
+
+func TestG2Codec(t *testing.T) {
+	g := NewG2()
+	points := []*PointG2{g.Zero(), g.One()}
+	for i := 0; i < 4; i++ {
+		points = append(points, g.randCorrect())
+	}
+	for _, f := range testFormats {
+		for _, p := range points {
+			enc, err := g.Encode(p, f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(enc) != f.G2Size() {
+				t.Fatalf("%s: bad encoding size", f)
+			}
+			p2, err := g.Decode(enc, f)
+			if err != nil {
+				t.Fatalf("%s: %v", f, err)
+			}
+			if !g.Equal(p, p2) {
+				t.Fatalf("%s: round trip failed", f)
+			}
+			for _, to := range testFormats {
+				converted, err := g.Convert(enc, f, to)
+				if err != nil {
+					t.Fatal(err)
+				}
+				expected, _ := g.Encode(p, to)
+				if !bytes.Equal(converted, expected) {
+					t.Fatalf("%s -> %s: conversion failed", f, to)
+				}
+			}
+		}
+	}
+}
+
+func TestG1CodecGnarkCrypto(t *testing.T) {
+	g := NewG1()
+	_, _, gen, _ := gnark.Generators()
+	for i := 0; i < 10; i++ {
+		var expected gnark.G1Affine
+		k := randScalar(qBig)
+		if i == 0 {
+			k.SetUint64(0)
+		}
+		expected.ScalarMultiplication(&gen, k)
+		p := g.MulScalarBig(g.New(), g.One(), k)
+
+		raw, err := g.Encode(p, FormatGnarkCryptoRaw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(raw, expected.Marshal()) {
+			t.Fatal("raw encoding does not match gnark-crypto")
+		}
+		var q gnark.G1Affine
+		if err := q.Unmarshal(raw); err != nil {
+			t.Fatal(err)
+		}
+		if !q.Equal(&expected) {
+			t.Fatal("gnark-crypto could not decode raw encoding")
+		}
+		compressed, err := g.Encode(p, FormatZcashCompressed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gnarkCompressed := expected.Bytes()
+		if !bytes.Equal(compressed, gnarkCompressed[:]) {
+			t.Fatal("compressed encoding does not match gnark-crypto")
+		}
+		if err := q.Unmarshal(compressed); err != nil || !q.Equal(&expected) {
+			t.Fatal("gnark-crypto could not decode compressed encoding")
+		}
+		for _, in := range [][]byte{expected.Marshal(), gnarkCompressed[:]} {
+			p2, err := g.Decode(in, FormatGnarkCryptoRaw)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !g.Equal(p, p2) {
+				t.Fatal("could not decode gnark-crypto encoding")
+			}
+		}
+	}
+}
+
+func TestG2CodecGnarkCrypto(t *testing.T) {
+	g := NewG2()
+	_, _, _, gen := gnark.Generators()
+	for i := 0; i < 4; i++ {
+		var expected gnark.G2Affine
+		k := randScalar(qBig)
+		if i == 0 {
+			k.SetUint64(0)
+		}
+		expected.ScalarMultiplication(&gen, k)
+		p := g.MulScalarBig(g.New(), g.One(), k)
+
+		raw, err := g.Encode(p, FormatGnarkCryptoRaw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(raw, expected.Marshal()) {
+			t.Fatal("raw encoding does not match gnark-crypto")
+		}
+		var q gnark.G2Affine
+		if err := q.Unmarshal(raw); err != nil || !q.Equal(&expected) {
+			t.Fatal("gnark-crypto could not decode raw encoding")
+		}
+		compressed, err := g.Encode(p, FormatZcashCompressed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gnarkCompressed := expected.Bytes()
+		if !bytes.Equal(compressed, gnarkCompressed[:]) {
+			t.Fatal("compressed encoding does not match gnark-crypto")
+		}
+		for _, in := range [][]byte{expected.Marshal(), gnarkCompressed[:]} {
+			p2, err := g.Decode(in, FormatGnarkCryptoRaw)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !g.Equal(p, p2) {
+				t.Fatal("could not decode gnark-crypto encoding")
+			}
+		}
+	}
+}
+
+func TestG1CodecInvalidInputs(t *testing.T) {
+	g := NewG1()
+	p := g.randCorrect()
+	uncompressed, _ := g.Encode(p, FormatZcashUncompressed)
+	eip, _ := g.Encode(p, FormatEIP2537)
+
+	compressedFlag := append([]byte{}, uncompressed...)
+	compressedFlag[0] |= flagCompressed
+	signFlag := append([]byte{}, uncompressed...)
+	signFlag[0] |= flagSign
+	infinityNonZero := append([]byte{}, uncompressed...)
+	infinityNonZero[0] |= flagInfinity
+	zeroNoFlag := make([]byte, 2*fpByteSize)
+	offCurve := append([]byte{}, uncompressed...)
+	offCurve[2*fpByteSize-1] ^= 1
+	badPadding := append([]byte{}, eip...)
+	badPadding[0] = 1
+	notInSubgroup := g.ToBytes(g.rand())
+	if g.InCorrectSubgroup(g.rand()) {
+		t.Fatal("random point is expected to be out of subgroup")
+	}
+
+	for _, c := range []struct {
+		name string
+		in   []byte
+		f    Format
+	}{
+		{"short", uncompressed[1:], FormatZcashUncompressed},
+		{"compression flag", compressedFlag, FormatZcashUncompressed},
+		{"sign flag", signFlag, FormatZcashUncompressed},
+		{"infinity flag", infinityNonZero, FormatZcashUncompressed},
+		{"zero without flag", zeroNoFlag, FormatZcashUncompressed},
+		{"off curve", offCurve, FormatZcashUncompressed},
+		{"not in subgroup", notInSubgroup, FormatZcashUncompressed},
+		{"eip2537 padding", badPadding, FormatEIP2537},
+		{"eip2537 short", eip[1:], FormatEIP2537},
+		{"unknown", uncompressed, Format(-1)},
+	} {
+		if _, err := g.Decode(c.in, c.f); err == nil {
+			t.Fatalf("%s: input must be rejected", c.name)
+		}
+	}
+	if _, err := g.Encode(p, Format(-1)); err == nil {
+		t.Fatal("unknown format must be rejected")
+	}
+}
+
+func TestG2CodecInvalidInputs(t *testing.T) {
+	g := NewG2()
+	p := g.randCorrect()
+	uncompressed, _ := g.Encode(p, FormatZcashUncompressed)
+	eip, _ := g.Encode(p, FormatEIP2537)
+
+	signFlag := append([]byte{}, uncompressed...)
+	signFlag[0] |= flagSign
+	offCurve := append([]byte{}, uncompressed...)
+	offCurve[4*fpByteSize-1] ^= 1
+	badPadding := append([]byte{}, eip...)
+	badPadding[eip2537FpByteSize] = 1
+	q := g.rand()
+	if g.InCorrectSubgroup(q) {
+		t.Fatal("random point is expected to be out of subgroup")
+	}
+	notInSubgroup := g.ToBytes(q)
+	swapFp2Halves(notInSubgroup)
+
+	for _, c := range []struct {
+		name string
+		in   []byte
+		f    Format
+	}{
+		{"short", uncompressed[1:], FormatZcashUncompressed},
+		{"sign flag", signFlag, FormatZcashUncompressed},
+		{"off curve", offCurve, FormatZcashUncompressed},
+		{"not in subgroup", notInSubgroup, FormatZcashUncompressed},
+		{"eip2537 padding", badPadding, FormatEIP2537},
+	} {
+		if _, err := g.Decode(c.in, c.f); err == nil {
+			t.Fatalf("%s: input must be rejected", c.name)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package bls12377
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// ctMulWindow is the window size of constant time scalar multiplication.
+// Tables keep 2^(ctMulWindow-1) odd multiples of the base point.
+const ctMulWindow = 4
+
+// ctMulDigits is the number of signed digits of a 256 bit scalar excluding the top digit.
+const ctMulDigits = (fourWordBitSize + ctMulWindow - 1) / ctMulWindow
+
+// CTTableSize is the number of odd multiples P, 3P, ..., (2^w - 1)P of the base point in the
+// table of MulCT.
+const CTTableSize = 1 << (ctMulWindow - 1)
+
+// ctEq returns 1 if a == b and 0 otherwise without branching.
+func ctEq(a, b uint64) uint64 {
+	x := a ^ b
+	return 1 ^ ((x | -x) >> 63)
+}
+
+// cmov sets the element to a if cond is 1 and leaves it untouched if cond is 0.
+func (e *fe) cmov(a *fe, cond uint64) {
+	mask := -cond
+	for i := 0; i < fpNumberOfLimbs; i++ {
+		e[i] ^= mask & (e[i] ^ a[i])
+	}
+}
+
+func (e *fe2) cmov(a *fe2, cond uint64) {
+	e[0].cmov(&a[0], cond)
+	e[1].cmov(&a[1], cond)
+}
+
+func (p *PointG1) cmov(p2 *PointG1, cond uint64) {
+	p[0].cmov(&p2[0], cond)
+	p[1].cmov(&p2[1], cond)
+	p[2].cmov(&p2[2], cond)
+}
+
+func (p *PointG2) cmov(p2 *PointG2, cond uint64) {
+	p[0].cmov(&p2[0], cond)
+	p[1].cmov(&p2[1], cond)
+	p[2].cmov(&p2[2], cond)
+}
+
+// ctRecode writes a scalar in regular signed window form. The scalar is first made odd by adding one
+// if it is even, the returned flag is 1 in that case so that the caller subtracts the base point at the end.
+// Every digit d_i is odd and |d_i| < 2^w, the top digit is positive, and e + even = Σ d_i * 2^(w*i).
+// Operations do not depend on the value of the scalar.
+//
+// Joye, Tunstall, "Exponent Recoding and Regular Exponentiation Algorithms"
+// https://doi.org/10.1007/978-3-642-02384-2_21
+func ctRecode(e *[4]uint64) (digits [ctMulDigits + 1]int64, even uint64) {
+	var k [5]uint64
+	even = 1 ^ (e[0] & 1)
+	var c uint64
+	k[0], c = bits.Add64(e[0], even, 0)
+	k[1], c = bits.Add64(e[1], 0, c)
+	k[2], c = bits.Add64(e[2], 0, c)
+	k[3], c = bits.Add64(e[3], 0, c)
+	k[4] = c
+
+	const mask = 1<<(ctMulWindow+1) - 1
+	for i := 0; i < ctMulDigits; i++ {
+		// d = (k mod 2^(w+1)) - 2^w
+		d := int64(k[0]&mask) - 1<<ctMulWindow
+		digits[i] = d
+		// k = (k - d) / 2^w
+		sign := uint64(d >> 63)
+		var b uint64
+		k[0], b = bits.Sub64(k[0], uint64(d), 0)
+		k[1], b = bits.Sub64(k[1], sign, b)
+		k[2], b = bits.Sub64(k[2], sign, b)
+		k[3], b = bits.Sub64(k[3], sign, b)
+		k[4], _ = bits.Sub64(k[4], sign, b)
+		k[0] = k[0]>>ctMulWindow | k[1]<<(64-ctMulWindow)
+		k[1] = k[1]>>ctMulWindow | k[2]<<(64-ctMulWindow)
+		k[2] = k[2]>>ctMulWindow | k[3]<<(64-ctMulWindow)
+		k[3] = k[3]>>ctMulWindow | k[4]<<(64-ctMulWindow)
+		k[4] = k[4] >> ctMulWindow
+	}
+	digits[ctMulDigits] = int64(k[0])
+	return digits, even
+}
+
+// CTGroup are the point operations MulCT is built on. Select sets r to x0 if c is 0 and to x1 if
+// c is 1 without branching, Add is only given entries of the table of MulCT or their negation as q.
+type CTGroup[P any] interface {
+	Set(r, p *P)
+	Double(r, p *P)
+	Add(r, p, q *P)
+	Neg(r, p *P)
+	Select(c uint64, r, x0, x1 *P)
+}
+
+// MulCT returns [e]P for a secret scalar e < 2^256, where table holds P, 3P, ..., (2^w - 1)P.
+// The scalar is processed in regular signed windows of fixed size and table entries are selected
+// by scanning the whole table, so that neither the sequence of group operations nor memory
+// accesses depend on the scalar. Exceptional cases of point addition are not handled in constant
+// time. It is the ladder of MulScalarCT, shared with the curves of gnark-crypto in bls-tools.
+func MulCT[P any](g CTGroup[P], table []P, e *[4]uint64) P {
+	digits, even := ctRecode(e)
+
+	var acc, t, neg P
+	ctLookup(g, &acc, table, digits[ctMulDigits])
+	for i := ctMulDigits - 1; i >= 0; i-- {
+		for j := 0; j < ctMulWindow; j++ {
+			g.Double(&acc, &acc)
+		}
+		ctLookup(g, &t, table, digits[i])
+		g.Add(&acc, &acc, &t)
+	}
+
+	// subtract the base point if it was added to make the scalar odd
+	g.Neg(&neg, &table[0])
+	g.Add(&t, &acc, &neg)
+	g.Select(even, &acc, &acc, &t)
+	return acc
+}
+
+// ctLookup sets r to d * P where table holds odd multiples of P and d is an odd digit.
+func ctLookup[P any](g CTGroup[P], r *P, table []P, d int64) {
+	mask := d >> 63
+	idx := uint64((d^mask)-mask) >> 1
+	g.Set(r, &table[0])
+	for i := 1; i < len(table); i++ {
+		g.Select(ctEq(uint64(i), idx), r, r, &table[i])
+	}
+	var neg P
+	g.Neg(&neg, r)
+	g.Select(uint64(mask)&1, r, r, &neg)
+}
+
+// ReduceCT returns in modulo q < 2^256 in four little endian limbs. Words of in are folded from
+// the top, each by conditional subtractions of q shifted by 64 down to 0 bits, so that only the
+// number of words and the sign of in, which big.Int does not hide, change the operations.
+func ReduceCT(in, q *big.Int) [4]uint64 {
+	var m [4]uint64
+	for i, w := range q.Bits() {
+		m[i] = uint64(w)
+	}
+	var r [4]uint64
+	words := in.Bits()
+	for i := len(words) - 1; i >= 0; i-- {
+		// t = r * 2^64 + w < q * 2^64
+		t := [5]uint64{uint64(words[i]), r[0], r[1], r[2], r[3]}
+		for s := 64; s >= 0; s-- {
+			// d = t - q * 2^s, kept if there is no borrow
+			qs := shiftLeft(&m, s)
+			var d [5]uint64
+			var b uint64
+			for j := range d {
+				d[j], b = bits.Sub64(t[j], qs[j], b)
+			}
+			keep := -(1 ^ b)
+			for j := range t {
+				t[j] ^= keep & (t[j] ^ d[j])
+			}
+		}
+		copy(r[:], t[:4])
+	}
+	if in.Sign() < 0 {
+		// r = q - r, and 0 for r = 0
+		var d [4]uint64
+		var b, nonZero uint64
+		for j := range d {
+			d[j], b = bits.Sub64(m[j], r[j], b)
+			nonZero |= r[j]
+		}
+		move := -((nonZero | -nonZero) >> 63)
+		for j := range r {
+			r[j] ^= move & (r[j] ^ d[j])
+		}
+	}
+	return r
+}
+
+// shiftLeft returns m * 2^s for s <= 64.
+func shiftLeft(m *[4]uint64, s int) (r [5]uint64) {
+	if s == 64 {
+		copy(r[1:], m[:])
+		return r
+	}
+	r[0] = m[0] << s
+	for j := 1; j < 4; j++ {
+		r[j] = m[j]<<s | m[j-1]>>(64-s)
+	}
+	r[4] = m[3] >> (64 - s)
+	return r
+}
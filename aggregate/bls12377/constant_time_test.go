@@ -0,0 +1,182 @@
+package bls12377
+
+import (
+	"crypto/rand"
+	"flag"
+	"math"
+	"math/big"
+	"sort"
+	"testing"
+	"time"
+)
+
+var dudect = flag.Bool("dudect", false, "run statistical timing tests")
+var dudectSamples = flag.Int("dudect.samples", 20000, "# of measurements of statistical timing tests")
+
+// dudectThreshold is the t statistic above which timing of the two classes is considered different.
+const dudectThreshold = 10
+
+func TestCtRecode(t *testing.T) {
+	max := &Fr{^uint64(0), ^uint64(0), ^uint64(0), ^uint64(0)}
+	scalars := []*Fr{{0}, {1}, {2}, {15}, {16}, max}
+	for i := 0; i < fuz; i++ {
+		e, _ := new(Fr).Rand(rand.Reader)
+		scalars = append(scalars, e)
+	}
+	for _, e := range scalars {
+		digits, even := ctRecode((*[4]uint64)(e))
+		acc := new(big.Int)
+		for i := len(digits) - 1; i >= 0; i-- {
+			d := digits[i]
+			if d%2 == 0 || d >= 1<<ctMulWindow || d <= -(1<<ctMulWindow) {
+				t.Fatal("digits must be odd and in window", d)
+			}
+			acc.Lsh(acc, ctMulWindow)
+			acc.Add(acc, big.NewInt(d))
+		}
+		if digits[len(digits)-1] < 0 {
+			t.Fatal("top digit must be positive")
+		}
+		expected := new(big.Int).Add(e.ToBig(), new(big.Int).SetUint64(even))
+		if acc.Cmp(expected) != 0 {
+			t.Fatal("regular recoding failed", e.ToBig())
+		}
+		if even != uint64(1^e.ToBig().Bit(0)) {
+			t.Fatal("bad parity flag")
+		}
+	}
+}
+
+func TestCtSelect(t *testing.T) {
+	for a := uint64(0); a < 20; a++ {
+		for b := uint64(0); b < 20; b++ {
+			if (ctEq(a, b) == 1) != (a == b) {
+				t.Fatal("ctEq failed", a, b)
+			}
+		}
+	}
+	a, _ := new(fe).rand(rand.Reader)
+	b, _ := new(fe).rand(rand.Reader)
+	c := new(fe).set(a)
+	c.cmov(b, 0)
+	if !c.equal(a) {
+		t.Fatal("cmov must not move when condition is zero")
+	}
+	c.cmov(b, 1)
+	if !c.equal(b) {
+		t.Fatal("cmov must move when condition is one")
+	}
+}
+
+func TestReduceCT(t *testing.T) {
+	// the order of BN254 leaves more than two multiples below 2^256
+	bn254R := bigFromHex("0x30644e72e131a029b85045b68181585d2833e84879b9709143e1f593f0000001")
+	for _, q := range []*big.Int{qBig, bn254R} {
+		max := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+		inputs := []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(-1), max, new(big.Int).Neg(max)}
+		inputs = append(inputs, new(big.Int).Sub(q, big.NewInt(1)), new(big.Int).Set(q), new(big.Int).Neg(q))
+		for i := 0; i < fuz; i++ {
+			e, _ := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 512))
+			inputs = append(inputs, e, new(big.Int).Neg(e))
+		}
+		for _, in := range inputs {
+			r := ReduceCT(in, q)
+			expected := new(big.Int).Mod(in, q)
+			if got := (*Fr)(&r).ToBig(); got.Cmp(expected) != 0 {
+				t.Fatalf("%s mod %s: got %s, expected %s", in, q, got, expected)
+			}
+		}
+	}
+}
+
+// welch keeps running mean and variance of measurements for two classes.
+type welch struct {
+	n, mean, m2 [2]float64
+}
+
+func (w *welch) push(class int, x float64) {
+	w.n[class]++
+	delta := x - w.mean[class]
+	w.mean[class] += delta / w.n[class]
+	w.m2[class] += delta * (x - w.mean[class])
+}
+
+// t returns Welch's t statistic of the two classes.
+func (w *welch) t() float64 {
+	v0 := w.m2[0] / (w.n[0] - 1)
+	v1 := w.m2[1] / (w.n[1] - 1)
+	return (w.mean[0] - w.mean[1]) / math.Sqrt(v0/w.n[0]+v1/w.n[1])
+}
+
+// measureLeakage is a dudect style test. Inputs of two classes, typically a fixed and a random one,
+// are prepared beforehand, the operation is timed on them in random order and measurements above the
+// given percentile are dropped. Returned value is the absolute Welch's t statistic of the two classes.
+//
+// Reparaz, Balasch, Verbauwhede, "Dude, is my code constant time?"
+// https://eprint.iacr.org/2016/1123.pdf
+func measureLeakage(samples int, percentile float64, input func(class int) func()) float64 {
+	classes := make([]int, samples)
+	ops := make([]func(), samples)
+	coins := make([]byte, samples)
+	_, _ = rand.Read(coins)
+	for i := range ops {
+		classes[i] = int(coins[i] & 1)
+		ops[i] = input(classes[i])
+	}
+	timings := make([]float64, samples)
+	for i, op := range ops {
+		start := time.Now()
+		op()
+		timings[i] = float64(time.Since(start))
+	}
+	sorted := append([]float64{}, timings...)
+	sort.Float64s(sorted)
+	cutoff := sorted[int(percentile*float64(samples-1))]
+	w := new(welch)
+	for i, x := range timings {
+		if x <= cutoff {
+			w.push(classes[i], x)
+		}
+	}
+	return math.Abs(w.t())
+}
+
+func TestMulScalarCTTiming(t *testing.T) {
+	if !*dudect {
+		t.Skip("statistical timing tests are enabled with -dudect")
+	}
+	// low weight fixed scalar against random scalars
+	fixed := &Fr{0x10001}
+	random := func() *Fr {
+		e, _ := new(Fr).Rand(rand.Reader)
+		return e
+	}
+	g1, g2 := NewG1(), NewG2()
+	p1, p2 := g1.randAffine(), g2.randAffine()
+	r1, r2 := g1.New(), g2.New()
+	cases := []struct {
+		name string
+		op   func(e *Fr)
+		ct   bool
+	}{
+		{"G1 MulScalarCT", func(e *Fr) { g1.MulScalarCT(r1, p1, e) }, true},
+		{"G2 MulScalarCT", func(e *Fr) { g2.MulScalarCT(r2, p2, e) }, true},
+		{"G1 MulScalar", func(e *Fr) { g1.MulScalar(r1, p1, e) }, false},
+	}
+	for _, c := range cases {
+		tt := measureLeakage(*dudectSamples, 0.9, func(class int) func() {
+			e := fixed
+			if class == 1 {
+				e = random()
+			}
+			return func() { c.op(e) }
+		})
+		t.Logf("%s: |t| = %.2f", c.name, tt)
+		if c.ct && tt > dudectThreshold {
+			t.Errorf("%s: timing depends on scalar, |t| = %.2f", c.name, tt)
+		}
+		if !c.ct && tt <= dudectThreshold {
+			t.Logf("%s: variable time reference is not detected, measurements may be too noisy", c.name)
+		}
+	}
+}
@@ -0,0 +1,438 @@
+package bls12377
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	gnark "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fp"
+)
+
+// The tests of this file compare the package to gnark-crypto's BLS12-377 implementation.
+// Points are converted coordinate by coordinate rather than through encodings, so points
+// out of the correct subgroup can be compared too.
+
+func feToGnark(e *fe) fp.Element {
+	var r fp.Element
+	r.SetBytes(toBytes(e))
+	return r
+}
+
+func feFromGnark(t testing.TB, e *fp.Element) fe {
+	b := e.Bytes()
+	r, err := fromBytes(b[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return *r
+}
+
+func fe2ToGnark(e *fe2) gnark.E2 {
+	return gnark.E2{A0: feToGnark(&e[0]), A1: feToGnark(&e[1])}
+}
+
+func fe2FromGnark(t testing.TB, e *gnark.E2) fe2 {
+	return fe2{feFromGnark(t, &e.A0), feFromGnark(t, &e.A1)}
+}
+
+func g1ToGnark(g *G1, p *PointG1) gnark.G1Affine {
+	var r gnark.G1Affine
+	if g.IsZero(p) {
+		return r
+	}
+	a := g.Affine(g.New().Set(p))
+	r.X, r.Y = feToGnark(&a[0]), feToGnark(&a[1])
+	return r
+}
+
+func g1FromGnark(t testing.TB, g *G1, p *gnark.G1Affine) *PointG1 {
+	if p.IsInfinity() {
+		return g.Zero()
+	}
+	r := &PointG1{feFromGnark(t, &p.X), feFromGnark(t, &p.Y)}
+	r[2].one()
+	return r
+}
+
+func g2ToGnark(g *G2, p *PointG2) gnark.G2Affine {
+	var r gnark.G2Affine
+	if g.IsZero(p) {
+		return r
+	}
+	a := g.Affine(g.New().Set(p))
+	r.X, r.Y = fe2ToGnark(&a[0]), fe2ToGnark(&a[1])
+	return r
+}
+
+func g2FromGnark(t testing.TB, g *G2, p *gnark.G2Affine) *PointG2 {
+	if p.IsInfinity() {
+		return g.Zero()
+	}
+	r := &PointG2{fe2FromGnark(t, &p.X), fe2FromGnark(t, &p.Y)}
+	r[2].one()
+	return r
+}
+
+func gtToGnark(e *E) gnark.GT {
+	var r gnark.GT
+	for i, c := range []*gnark.E6{&r.C0, &r.C1} {
+		for j, b := range []*gnark.E2{&c.B0, &c.B1, &c.B2} {
+			*b = fe2ToGnark(&e[i][j])
+		}
+	}
+	return r
+}
+
+// diffScalars are the edge-case scalars of the multiplication tests, next to random ones.
+func diffScalars() []*big.Int {
+	return []*big.Int{
+		big.NewInt(0),
+		big.NewInt(1),
+		big.NewInt(2),
+		new(big.Int).Sub(qBig, big.NewInt(1)),
+		new(big.Int).Set(qBig),
+		new(big.Int).Add(qBig, big.NewInt(1)),
+		new(big.Int).Set(x),
+		randScalar(qBig),
+	}
+}
+
+func TestDifferentialGenerators(t *testing.T) {
+	g1, g2 := NewG1(), NewG2()
+	_, _, g1Gen, g2Gen := gnark.Generators()
+	if p := g1ToGnark(g1, g1.One()); !p.Equal(&g1Gen) {
+		t.Fatal("G1 generators differ")
+	}
+	if p := g2ToGnark(g2, g2.One()); !p.Equal(&g2Gen) {
+		t.Fatal("G2 generators differ")
+	}
+}
+
+func TestDifferentialG1Add(t *testing.T) {
+	g := NewG1()
+	for i := 0; i < fuz; i++ {
+		// points out of the subgroup too, addition does not depend on it
+		for _, p := range [][2]*PointG1{
+			{g.rand(), g.rand()},
+			{g.randCorrect(), g.randCorrect()},
+			{g.Zero(), g.rand()},
+			{g.Zero(), g.Zero()},
+		} {
+			a, b := p[0], p[1]
+			ga, gb := g1ToGnark(g, a), g1ToGnark(g, b)
+			for _, c := range []struct {
+				name string
+				r    *PointG1
+				x, y *gnark.G1Affine
+			}{
+				{"a + b", g.Add(g.New(), a, b), &ga, &gb},
+				{"a + a", g.Add(g.New(), a, a), &ga, &ga},
+				{"a + -a", g.Add(g.New(), a, g.Neg(g.New(), a)), &ga, new(gnark.G1Affine).Neg(&ga)},
+				{"b - a", g.Sub(g.New(), b, a), &gb, new(gnark.G1Affine).Neg(&ga)},
+				{"2a", g.Double(g.New(), a), &ga, &ga},
+			} {
+				var expected gnark.G1Affine
+				expected.Add(c.x, c.y)
+				if r := g1ToGnark(g, c.r); !r.Equal(&expected) {
+					t.Fatalf("G1 %s differs", c.name)
+				}
+			}
+		}
+	}
+}
+
+func TestDifferentialG2Add(t *testing.T) {
+	g := NewG2()
+	for i := 0; i < fuz; i++ {
+		for _, p := range [][2]*PointG2{
+			{g.rand(), g.rand()},
+			{g.randCorrect(), g.randCorrect()},
+			{g.Zero(), g.rand()},
+			{g.Zero(), g.Zero()},
+		} {
+			a, b := p[0], p[1]
+			ga, gb := g2ToGnark(g, a), g2ToGnark(g, b)
+			for _, c := range []struct {
+				name string
+				r    *PointG2
+				x, y *gnark.G2Affine
+			}{
+				{"a + b", g.Add(g.New(), a, b), &ga, &gb},
+				{"a + a", g.Add(g.New(), a, a), &ga, &ga},
+				{"a + -a", g.Add(g.New(), a, g.Neg(g.New(), a)), &ga, new(gnark.G2Affine).Neg(&ga)},
+				{"b - a", g.Sub(g.New(), b, a), &gb, new(gnark.G2Affine).Neg(&ga)},
+				{"2a", g.Double(g.New(), a), &ga, &ga},
+			} {
+				var expected gnark.G2Affine
+				expected.Add(c.x, c.y)
+				if r := g2ToGnark(g, c.r); !r.Equal(&expected) {
+					t.Fatalf("G2 %s differs", c.name)
+				}
+			}
+		}
+	}
+}
+
+func TestDifferentialG1MulScalar(t *testing.T) {
+	g := NewG1()
+	for i := 0; i < fuz; i++ {
+		// gnark-crypto multiplies with the GLV endomorphism, which requires a point of the subgroup
+		for _, p := range []*PointG1{g.randCorrect(), g.One(), g.Zero()} {
+			gp := g1ToGnark(g, p)
+			for _, s := range diffScalars() {
+				var expected gnark.G1Affine
+				expected.ScalarMultiplication(&gp, s)
+				if r := g1ToGnark(g, g.MulScalar(g.New(), p, new(Fr).SetBig(s))); !r.Equal(&expected) {
+					t.Fatalf("G1 MulScalar differs for %x", s)
+				}
+				if r := g1ToGnark(g, g.MulScalarBig(g.New(), p, s)); !r.Equal(&expected) {
+					t.Fatalf("G1 MulScalarBig differs for %x", s)
+				}
+			}
+		}
+	}
+}
+
+func TestDifferentialG2MulScalar(t *testing.T) {
+	g := NewG2()
+	for i := 0; i < fuz; i++ {
+		for _, p := range []*PointG2{g.randCorrect(), g.One(), g.Zero()} {
+			gp := g2ToGnark(g, p)
+			for _, s := range diffScalars() {
+				var expected gnark.G2Affine
+				expected.ScalarMultiplication(&gp, s)
+				if r := g2ToGnark(g, g.MulScalar(g.New(), p, new(Fr).SetBig(s))); !r.Equal(&expected) {
+					t.Fatalf("G2 MulScalar differs for %x", s)
+				}
+				if r := g2ToGnark(g, g.MulScalarBig(g.New(), p, s)); !r.Equal(&expected) {
+					t.Fatalf("G2 MulScalarBig differs for %x", s)
+				}
+			}
+		}
+	}
+}
+
+func TestDifferentialClearCofactor(t *testing.T) {
+	g1, g2 := NewG1(), NewG2()
+	// G1 multiplies by the cofactor h = (x - 1)² / 3 and gnark-crypto by 1 - x, so the results
+	// differ by a factor -(x - 1) / 3
+	k := new(big.Int).Sub(x, big.NewInt(1))
+	k.Div(k, big.NewInt(3))
+	k.Neg(k).Mod(k, qBig)
+	for i := 0; i < fuz; i++ {
+		for _, p := range []*PointG1{g1.rand(), g1.randCorrect(), g1.Zero()} {
+			var expected gnark.G1Affine
+			gp := g1ToGnark(g1, p)
+			expected.ClearCofactor(&gp)
+			expected.ScalarMultiplication(&expected, k)
+			r := g1.ClearCofactor(g1.New().Set(p))
+			if gr := g1ToGnark(g1, r); !gr.Equal(&expected) {
+				t.Fatal("G1 ClearCofactor differs")
+			}
+			if !g1.InCorrectSubgroup(r) {
+				t.Fatal("G1 ClearCofactor must map to the subgroup")
+			}
+		}
+		for _, p := range []*PointG2{g2.rand(), g2.randCorrect(), g2.Zero()} {
+			var expected gnark.G2Affine
+			gp := g2ToGnark(g2, p)
+			expected.ClearCofactor(&gp)
+			r := g2.ClearCofactor(g2.New().Set(p))
+			if gr := g2ToGnark(g2, r); !gr.Equal(&expected) {
+				t.Fatal("G2 ClearCofactor differs")
+			}
+		}
+	}
+}
+
+func TestDifferentialInCorrectSubgroup(t *testing.T) {
+	g1, g2 := NewG1(), NewG2()
+	for i := 0; i < fuz; i++ {
+		for _, p := range []*PointG1{g1.rand(), g1.randCorrect(), g1.Zero()} {
+			gp := g1ToGnark(g1, p)
+			if g1.InCorrectSubgroup(p) != gp.IsInSubGroup() {
+				t.Fatal("G1 subgroup checks differ")
+			}
+		}
+		for _, p := range []*PointG2{g2.rand(), g2.randCorrect(), g2.Zero()} {
+			gp := g2ToGnark(g2, p)
+			if g2.InCorrectSubgroup(p) != gp.IsInSubGroup() {
+				t.Fatal("G2 subgroup checks differ")
+			}
+		}
+	}
+	// the rand points are out of the subgroup, the comparison must not only see valid points
+	if p := g1.rand(); g1.InCorrectSubgroup(p) {
+		t.Fatal("G1 point expected out of the subgroup")
+	}
+	if p := g2.rand(); g2.InCorrectSubgroup(p) {
+		t.Fatal("G2 point expected out of the subgroup")
+	}
+}
+
+func testDifferentialHashToCurve(t testing.TB, msg, dst []byte) {
+	g := NewG2()
+	expected, gErr := gnark.HashToG2(msg, dst)
+	p, err := g.HashToCurve(msg, dst)
+	if (err == nil) != (gErr == nil) {
+		t.Fatalf("HashToCurve errors differ for %x, %x: %v, %v", msg, dst, err, gErr)
+	}
+	if err != nil {
+		return
+	}
+	if r := g2ToGnark(g, p); !r.Equal(&expected) {
+		t.Fatalf("HashToCurve differs for %x, %x", msg, dst)
+	}
+}
+
+func TestDifferentialHashToCurve(t *testing.T) {
+	dst := []byte("BLS_SIG_BLS12377G2_XMD:SHA-256_SSWU_RO_NUL_")
+	for _, msg := range [][]byte{
+		{},
+		[]byte("abc"),
+		[]byte("abcdef0123456789"),
+		bytes.Repeat([]byte("q128_"), 26),
+		bytes.Repeat([]byte{'a'}, 512),
+	} {
+		testDifferentialHashToCurve(t, msg, dst)
+	}
+	testDifferentialHashToCurve(t, []byte("abc"), []byte{})
+	testDifferentialHashToCurve(t, []byte("abc"), bytes.Repeat([]byte{'d'}, 255))
+	for i := 0; i < fuz; i++ {
+		msg := make([]byte, 64)
+		_, _ = rand.Read(msg)
+		testDifferentialHashToCurve(t, msg, dst)
+	}
+}
+
+func TestDifferentialSWUMapG2(t *testing.T) {
+	g := NewG2()
+	var zero, one fe2
+	one.one()
+	// u = 0 takes the exceptional case of the map
+	for _, u := range []*fe2{&zero, &one, {one[0], zero[0]}, {zero[0], one[0]}} {
+		x, y := swuMapG2(g.f, u)
+		expected := gnark.MapToCurve2(&gnark.E2{A0: feToGnark(&u[0]), A1: feToGnark(&u[1])})
+		if rx, ry := fe2ToGnark(x), fe2ToGnark(y); !rx.Equal(&expected.X) || !ry.Equal(&expected.Y) {
+			t.Fatalf("SSWU map differs for %v", u)
+		}
+	}
+}
+
+func TestDifferentialPairing(t *testing.T) {
+	g1, g2 := NewG1(), NewG2()
+	for i := 0; i < fuz; i++ {
+		var (
+			p = []*PointG1{g1.randAffine(), g1.randAffine(), g1.Zero(), g1.One()}
+			q = []*PointG2{g2.randAffine(), g2.randAffine(), g2.randAffine(), g2.Zero()}
+		)
+		gp, gq := make([]gnark.G1Affine, len(p)), make([]gnark.G2Affine, len(q))
+		for k := range p {
+			gp[k], gq[k] = g1ToGnark(g1, p[k]), g2ToGnark(g2, q[k])
+		}
+		for n := 1; n <= len(p); n++ {
+			e := NewEngine()
+			for k := 0; k < n; k++ {
+				e.AddPair(p[k], q[k])
+			}
+			expected, err := gnark.Pair(gp[:n], gq[:n])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if r := gtToGnark(e.Result()); !r.Equal(&expected) {
+				t.Fatalf("pairing of %d pairs differs", n)
+			}
+		}
+		// e(a, b) * e(-a, b) == 1
+		e := NewEngine().AddPair(p[0], q[0]).AddPairInv(p[0], q[0])
+		ok, err := gnark.PairingCheck(
+			[]gnark.G1Affine{gp[0], *new(gnark.G1Affine).Neg(&gp[0])},
+			[]gnark.G2Affine{gq[0], gq[0]},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e.Check() != ok || !ok {
+			t.Fatal("pairing checks differ")
+		}
+	}
+}
+
+// scalarFromBytes reads b as a big endian scalar of at most 64 bytes.
+func scalarFromBytes(b []byte) *big.Int {
+	if len(b) > 64 {
+		b = b[:64]
+	}
+	return new(big.Int).SetBytes(b)
+}
+
+func FuzzDifferentialG1(f *testing.F) {
+	f.Add([]byte{}, []byte{})
+	f.Add([]byte{1}, []byte{2})
+	f.Add(qBig.Bytes(), new(big.Int).Sub(qBig, big.NewInt(1)).Bytes())
+	g := NewG1()
+	f.Fuzz(func(t *testing.T, a, b []byte) {
+		sa, sb := scalarFromBytes(a), scalarFromBytes(b)
+		p, q := g.MulScalarBig(g.New(), g.One(), sa), g.MulScalarBig(g.New(), g.One(), sb)
+		gp, gq := g1ToGnark(g, p), g1ToGnark(g, q)
+		var expected gnark.G1Affine
+		expected.Add(&gp, &gq)
+		if r := g1ToGnark(g, g.Add(g.New(), p, q)); !r.Equal(&expected) {
+			t.Fatal("G1 Add differs")
+		}
+		expected.ScalarMultiplication(&gp, sb)
+		if r := g1ToGnark(g, g.MulScalar(g.New(), p, new(Fr).SetBig(sb))); !r.Equal(&expected) {
+			t.Fatal("G1 MulScalar differs")
+		}
+	})
+}
+
+func FuzzDifferentialG2(f *testing.F) {
+	f.Add([]byte{}, []byte{})
+	f.Add([]byte{1}, []byte{2})
+	f.Add(qBig.Bytes(), new(big.Int).Sub(qBig, big.NewInt(1)).Bytes())
+	g := NewG2()
+	f.Fuzz(func(t *testing.T, a, b []byte) {
+		sa, sb := scalarFromBytes(a), scalarFromBytes(b)
+		p, q := g.MulScalarBig(g.New(), g.One(), sa), g.MulScalarBig(g.New(), g.One(), sb)
+		gp, gq := g2ToGnark(g, p), g2ToGnark(g, q)
+		var expected gnark.G2Affine
+		expected.Add(&gp, &gq)
+		if r := g2ToGnark(g, g.Add(g.New(), p, q)); !r.Equal(&expected) {
+			t.Fatal("G2 Add differs")
+		}
+		expected.ScalarMultiplication(&gp, sb)
+		if r := g2ToGnark(g, g.MulScalar(g.New(), p, new(Fr).SetBig(sb))); !r.Equal(&expected) {
+			t.Fatal("G2 MulScalar differs")
+		}
+	})
+}
+
+func FuzzDifferentialHashToCurve(f *testing.F) {
+	f.Add([]byte{}, []byte("BLS_SIG_BLS12377G2_XMD:SHA-256_SSWU_RO_NUL_"))
+	f.Add([]byte("abc"), []byte{})
+	f.Add(bytes.Repeat([]byte{'a'}, 512), bytes.Repeat([]byte{'d'}, 256))
+	f.Fuzz(func(t *testing.T, msg, dst []byte) {
+		testDifferentialHashToCurve(t, msg, dst)
+	})
+}
+
+func FuzzDifferentialPairing(f *testing.F) {
+	f.Add([]byte{}, []byte{1})
+	f.Add([]byte{1}, []byte{2})
+	f.Add(new(big.Int).Sub(qBig, big.NewInt(1)).Bytes(), []byte{1})
+	g1, g2 := NewG1(), NewG2()
+	f.Fuzz(func(t *testing.T, a, b []byte) {
+		p := g1.MulScalarBig(g1.New(), g1.One(), scalarFromBytes(a))
+		q := g2.MulScalarBig(g2.New(), g2.One(), scalarFromBytes(b))
+		expected, err := gnark.Pair([]gnark.G1Affine{g1ToGnark(g1, p)}, []gnark.G2Affine{g2ToGnark(g2, q)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r := gtToGnark(NewEngine().AddPair(p, q).Result()); !r.Equal(&expected) {
+			t.Fatal("pairing differs")
+		}
+	})
+}
@@ -139,7 +139,6 @@ func (fe *fe) isOne() bool {
 }
 
 func (fe *fe) cmp(fe2 *fe) int {
-	fmt.Printf("fe2: %+v\n", fe2)
 	for i := fpNumberOfLimbs - 1; i >= 0; i-- {
 		if fe[i] > fe2[i] {
 			return 1
@@ -2,7 +2,6 @@ package bls12377
 
 import (
 	"errors"
-	"fmt"
 	"math/big"
 )
 
@@ -12,7 +11,6 @@ func fromBytes(in []byte) (*fe, error) {
 		return nil, errors.New("input string length must be equal 48 bytes")
 	}
 	fe.setBytes(in)
-	fmt.Printf("fromBytes: %+v\n", fe)
 
 	if !fe.isValid() {
 		return nil, errors.New("must be less than modulus")
@@ -39,12 +37,12 @@ func from64Bytes(in []byte) (*fe, error) {
 	}
 	// F = 2 ^ 256 * R
 	F := fe{
-		0x75b3cd7c5ce820f,
-		0x3ec6ba621c3edb0b,
-		0x168a13d82bff6bce,
-		0x87663c4bf8c449d2,
-		0x15f34c83ddc8d830,
-		0xf9628b49caa2e85,
+		0x7ad989e964bf4bc5,
+		0x506bfea62ba9792a,
+		0x9bcbd51fe77a6419,
+		0xf3686e651b6a0c02,
+		0xa530f99c54c84d81,
+		0x9772b5cbb75e07,
 	}
 
 	mul(e0, e0, &F)
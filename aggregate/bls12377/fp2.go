@@ -260,23 +260,49 @@ func (e *fp2) frobeniusMap(a *fe2, power int) {
 	mul(&a[1], &a[1], &frobeniusCoeffs2[power%2])
 }
 
-func (e *fp2) sqrt(c, a *fe2) bool {
-	u, x0, a1, alpha := &fe2{}, &fe2{}, &fe2{}, &fe2{}
-	u.set(a)
-	e.exp(a1, a, pMinus3Over4)
-	e.square(alpha, a1)
-	e.mul(alpha, alpha, a)
-	e.mul(x0, a1, a)
-	if alpha.equal(negativeOne2) {
-		neg(&c[0], &x0[1])
-		c[1].set(&x0[0])
-		return true
+// sqrt computes square root for p = 1 mod 4 following
+// "Square root computation over even extension fields", Adj and Rodríguez-Henríquez, Algorithm 9.
+func (e *fp2) sqrt(out, in *fe2) bool {
+	C := new(fe2)
+	_, _ = C[0].setString("0x0014e085301446626974096803f243c11f6e5040e25acc8ed051435f0adff697ee7ac7a67918f9e626cd8b128e5d886d")
+	_, _ = C[1].setString("0x01a180ecebac7cc8a95500b90ae1ebb19eec113708742dee1b779facc5b909c9a9ffffc2476646664005a6b9a7abc345")
+
+	negOne := new(fe2)
+	e.neg(negOne, new(fe2).one())
+
+	D, E, F, DC := new(fe2), new(fe2), new(fe2), new(fe2)
+	e.exp(D, C, pMinus1Over2)
+	e.mul(DC, D, C)
+	e.square(F, DC)
+	e.inverse(E, DC)
+
+	B := new(fe2)
+	e.exp(B, in, pMinus1Over4)
+
+	BQB, BQ, a0 := new(fe2), new(fe2), new(fe2)
+
+	e.frobeniusMap1(BQ.set(B)) // b ^ q
+	e.mul(BQB, BQ, B)          // b ^ (q + 1)
+	e.square(a0, BQB)          // b ^ (2 * (q + 1))
+
+	if a0.equal(negOne) {
+		return false
+	}
+
+	v, u := new(fe2), new(fe)
+	e.square(v, B)  // b^2
+	e.mul(v, v, in) // b^2 * a
+
+	if BQB.equal(new(fe2).one()) {
+		sqrt(u, &v[0])
+		e.mul0(out, BQ, u)
+	} else {
+		e.mul(v, v, F) // b^2 * a * f
+		sqrt(u, &v[0])
+		e.mul(v, BQ, E)
+		e.mul0(out, v, u)
 	}
-	e.add(alpha, alpha, e.one())
-	e.exp(alpha, alpha, pMinus1Over2)
-	e.mul(c, alpha, x0)
-	e.square(alpha, c)
-	return alpha.equal(u)
+	return true
 }
 
 func (e *fp2) isQuadraticNonResidue(a *fe2) bool {
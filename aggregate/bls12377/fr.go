@@ -2,6 +2,7 @@ package bls12377
 
 import (
 	"crypto/rand"
+	"errors"
 	"io"
 	"math/big"
 )
@@ -80,7 +81,7 @@ func (e *Fr) fromBig(in *big.Int) *Fr {
 	zero := new(big.Int)
 	c0 := _in.Cmp(zero)
 	c1 := _in.Cmp(qBig)
-	if c0 == -1 || c1 == 1 {
+	if c0 == -1 || c1 != -1 {
 		_in.Mod(_in, qBig)
 	}
 	words := _in.Bits()
@@ -96,6 +97,28 @@ func (e *Fr) setUint64(n uint64) *Fr {
 	return e
 }
 
+// SetUint64 sets the element to given integer value.
+func (e *Fr) SetUint64(n uint64) *Fr {
+	return e.setUint64(n)
+}
+
+// SetBig sets the element to given big.Int value. Input is reduced modulo group order.
+func (e *Fr) SetBig(in *big.Int) *Fr {
+	return e.fromBig(in)
+}
+
+// FromCanonicalBytes constructs the element from its 32 byte big endian encoding.
+// Unlike FromBytes input is not reduced, an error is returned if it is not less than group order.
+func (e *Fr) FromCanonicalBytes(in []byte) (*Fr, error) {
+	if len(in) != frByteSize {
+		return nil, errors.New("input string length must be equal to 32 bytes")
+	}
+	if new(big.Int).SetBytes(in).Cmp(qBig) != -1 {
+		return nil, errors.New("must be less than group order")
+	}
+	return e.fromBytes(in), nil
+}
+
 func (e *Fr) ToBytes() []byte {
 	return NewFr().Set(e).bytes()
 }
@@ -317,6 +340,99 @@ func (e *Fr) Inverse(ei *Fr) {
 	e.Set(u)
 }
 
+// InverseCT inverses an element in Montgomery form as a^(q-2).
+// Unlike Inverse the sequence of operations does not depend on the input,
+// so it should be preferred for secret values. Inverse of zero is zero.
+func (e *Fr) InverseCT(a *Fr) {
+	z := new(Fr).RedOne()
+	for i := frBitSize - 1; i >= 0; i-- {
+		z.RedSquare(z)
+		if qMinus2Big.Bit(i) == 1 {
+			z.RedMul(z, a)
+		}
+	}
+	e.Set(z)
+}
+
+// Sqrt calculates square root of an element in Montgomery form with Tonelli-Shanks algorithm.
+// Returns false and leaves the receiver untouched if the element is not a quadratic residue.
+func (e *Fr) Sqrt(a *Fr) bool {
+	if a.IsZero() {
+		e.Zero()
+		return true
+	}
+	w := new(Fr)
+	w.Exp(a, frSqrtExp) // a^((t-1)/2)
+	x := new(Fr)
+	x.RedMul(a, w) // a^((t+1)/2)
+	b := new(Fr)
+	b.RedMul(x, w) // a^t
+	z := new(Fr).Set(frSqrtZ)
+	v := frTwoAdicity
+	t := new(Fr)
+	for !b.IsRedOne() {
+		// find least m such that b^(2^m) = 1
+		m := 0
+		for t.Set(b); !t.IsRedOne(); m++ {
+			if m == v-1 {
+				return false
+			}
+			t.RedSquare(t)
+		}
+		w.Set(z)
+		for i := 0; i < v-m-1; i++ {
+			w.RedSquare(w)
+		}
+		z.RedSquare(w)
+		x.RedMul(x, w)
+		b.RedMul(b, z)
+		v = m
+	}
+	e.Set(x)
+	return true
+}
+
+// BatchInverse inverses given elements in Montgomery form in place
+// with a single inversion. Zero elements are left as zero.
+func BatchInverse(in []Fr) {
+	n := 0
+	for i := 0; i < len(in); i++ {
+		if !in[i].IsZero() {
+			n++
+		}
+	}
+	if n == 0 {
+		return
+	}
+
+	// tA[j] = product of first j+1 non zero elements
+	tA := make([]Fr, n)
+	acc := new(Fr).RedOne()
+	for i, j := 0, 0; i < len(in); i++ {
+		if !in[i].IsZero() {
+			acc.RedMul(acc, &in[i])
+			tA[j].Set(acc)
+			j++
+		}
+	}
+
+	inv := new(Fr)
+	inv.Inverse(&tA[n-1])
+	for i, j := len(in)-1, n-1; i >= 0; i-- {
+		if in[i].IsZero() {
+			continue
+		}
+		t := new(Fr).Set(&in[i])
+		if j == 0 {
+			in[i].Set(inv)
+		} else {
+			in[i].RedMul(inv, &tA[j-1])
+		}
+		inv.RedMul(inv, t)
+		j--
+	}
+}
+
 func (ew *wideFr) mul(a, b *Fr) {
 	lmulFR(ew, a, b)
 }
@@ -5,6 +5,8 @@ import (
 	"crypto/rand"
 	"math/big"
 	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
 )
 
 func TestScalarField(t *testing.T) {
@@ -394,3 +396,150 @@ func TestFrInversion(t *testing.T) {
 		}
 	}
 }
+
+func TestFrSetters(t *testing.T) {
+	a := new(Fr).SetUint64(0x1234)
+	if a.ToBig().Cmp(big.NewInt(0x1234)) != 0 {
+		t.Fatal("set uint64 failed")
+	}
+	for i := 0; i < fuz; i++ {
+		b, _ := new(Fr).Rand(rand.Reader)
+		// b + q and b - q must be reduced to b
+		c := new(Fr).SetBig(new(big.Int).Add(b.ToBig(), qBig))
+		if !c.Equal(b) {
+			t.Fatal("set big failed, b + q")
+		}
+		c.SetBig(new(big.Int).Sub(b.ToBig(), qBig))
+		if !c.Equal(b) {
+			t.Fatal("set big failed, b - q")
+		}
+	}
+	if !new(Fr).SetBig(qBig).IsZero() {
+		t.Fatal("set big failed, q")
+	}
+}
+
+func TestFrCanonicalSerialization(t *testing.T) {
+	for i := 0; i < fuz; i++ {
+		a, _ := new(Fr).Rand(rand.Reader)
+		b, err := new(Fr).FromCanonicalBytes(a.ToBytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !a.Equal(b) {
+			t.Fatal("canonical serialization failed")
+		}
+	}
+	qMinus1 := padBytes(new(big.Int).Sub(qBig, bigOne).Bytes(), frByteSize)
+	if _, err := new(Fr).FromCanonicalBytes(qMinus1); err != nil {
+		t.Fatal("q - 1 must be accepted")
+	}
+	if _, err := new(Fr).FromCanonicalBytes(padBytes(qBig.Bytes(), frByteSize)); err == nil {
+		t.Fatal("q must be rejected")
+	}
+	max := bytes.Repeat([]byte{0xff}, frByteSize)
+	if _, err := new(Fr).FromCanonicalBytes(max); err == nil {
+		t.Fatal("2^256 - 1 must be rejected")
+	}
+	if _, err := new(Fr).FromCanonicalBytes(qMinus1[1:]); err == nil {
+		t.Fatal("short input must be rejected")
+	}
+}
+
+func TestFrSquareRoot(t *testing.T) {
+	r := new(Fr)
+	if !r.Sqrt(new(Fr).Zero()) || !r.IsZero() {
+		t.Fatal("sqrt(0) == 0")
+	}
+	if !r.Sqrt(new(Fr).RedOne()) {
+		t.Fatal("one is a quadratic residue")
+	}
+	r.RedSquare(r)
+	if !r.IsRedOne() {
+		t.Fatal("sqrt(1)^2 == 1")
+	}
+	for i := 0; i < fuz; i++ {
+		a, _ := new(Fr).Rand(rand.Reader)
+		aa, rr := new(Fr), new(Fr)
+		aa.RedSquare(a)
+		if !r.Sqrt(aa) {
+			t.Fatal("a^2 must have a square root")
+		}
+		rr.RedSquare(r)
+		if !rr.Equal(aa) {
+			t.Fatal("sqrt(a^2)^2 == a^2")
+		}
+		// a^2 * z is not a residue where z is a non residue
+		nonResidue := new(Fr).SetUint64(22)
+		nonResidue.toMont()
+		aa.RedMul(aa, nonResidue)
+		r.Set(a)
+		if r.Sqrt(aa) {
+			t.Fatal("a^2 * 22 must not have a square root")
+		}
+		if !r.Equal(a) {
+			t.Fatal("receiver must be untouched when there is no square root")
+		}
+	}
+}
+
+func TestFrInversionConstantTime(t *testing.T) {
+	u := new(Fr)
+	u.InverseCT(new(Fr).Zero())
+	if !u.IsZero() {
+		t.Fatal("(0^-1) == 0)")
+	}
+	for i := 0; i < fuz; i++ {
+		a, _ := new(Fr).Rand(rand.Reader)
+		v := new(Fr)
+		u.InverseCT(a)
+		v.Inverse(a)
+		if !u.Equal(v) {
+			t.Fatal("constant time inversion must match inversion")
+		}
+	}
+}
+
+func TestFrBatchInversion(t *testing.T) {
+	n := 20
+	a, u := make([]Fr, n), make([]Fr, n)
+	for i := 0; i < n; i++ {
+		if i%7 == 3 {
+			// leave some zeros
+			continue
+		}
+		e, _ := new(Fr).Rand(rand.Reader)
+		a[i].Set(e)
+		u[i].Set(e)
+	}
+	BatchInverse(u)
+	v := new(Fr)
+	for i := 0; i < n; i++ {
+		v.Inverse(&a[i])
+		if !u[i].Equal(v) {
+			t.Fatal("batch inversion failed", i)
+		}
+	}
+	BatchInverse(nil)
+}
+
+func TestHashToScalar(t *testing.T) {
+	dst := []byte("BLS12377_XMD:SHA-256_SSWU_RO_TEST_")
+	for _, msg := range []string{"", "abc", "abcdef0123456789", string(make([]byte, 1000))} {
+		e, err := HashToScalar([]byte(msg), dst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected, err := fr.Hash([]byte(msg), dst, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b := expected[0].Bytes()
+		if !bytes.Equal(e.ToBytes(), b[:]) {
+			t.Fatalf("hash to scalar failed for %q", msg)
+		}
+	}
+	if _, err := HashToScalar([]byte("abc"), make([]byte, 256)); err == nil {
+		t.Fatal("domain longer than 255 bytes must be rejected")
+	}
+}
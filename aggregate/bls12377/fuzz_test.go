@@ -0,0 +1,161 @@
+package bls12377
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+)
+
+// addModulus returns a copy of in with the field element of 48 bytes at offset off increased by
+// the modulus. The three flag bits of a compressed point are kept.
+func addModulus(in []byte, off int) []byte {
+	out := append([]byte(nil), in...)
+	flags := out[off] & 0xe0
+	if off != 0 {
+		flags = 0
+	}
+	out[off] &^= flags
+	v := new(big.Int).SetBytes(out[off : off+fpByteSize])
+	v.Add(v, modulus.big())
+	v.FillBytes(out[off : off+fpByteSize])
+	out[off] |= flags
+	return out
+}
+
+// flagSeeds returns encodings derived from the valid compressed point in: the flags set or
+// cleared one at a time, and the x coordinate out of range.
+func flagSeeds(in []byte) [][]byte {
+	seeds := [][]byte{in, addModulus(in, 0), in[:len(in)-1], {}}
+	for _, flag := range []byte{1 << 7, 1 << 6, 1 << 5, 1 << 4} {
+		s := append([]byte(nil), in...)
+		s[0] ^= flag
+		seeds = append(seeds, s)
+	}
+	infinity := make([]byte, len(in))
+	infinity[0] = 0xc0
+	seeds = append(seeds, infinity)
+	for _, flag := range []byte{1 << 5, 1 << 7} {
+		s := append([]byte(nil), infinity...)
+		s[0] ^= flag
+		seeds = append(seeds, s)
+	}
+	s := append([]byte(nil), infinity...)
+	s[len(s)-1] = 1
+	return append(seeds, s)
+}
+
+func FuzzG1FromCompressed(f *testing.F) {
+	g := NewG1()
+	for _, s := range []int64{1, 2, 0xff} {
+		p := g.MulScalarBig(g.New(), g.One(), big.NewInt(s))
+		for _, seed := range flagSeeds(g.ToCompressed(p)) {
+			f.Add(seed)
+		}
+	}
+	f.Fuzz(func(t *testing.T, in []byte) {
+		p, err := g.FromCompressed(in)
+		if err != nil {
+			return
+		}
+		if !g.IsOnCurve(p) || !g.InCorrectSubgroup(p) {
+			t.Fatal("decoded point must be in G1")
+		}
+		out := g.ToCompressed(g.New().Set(p))
+		if !bytes.Equal(out, in) {
+			t.Fatalf("non canonical encoding %x of %x accepted", in, out)
+		}
+		q, err := g.FromCompressed(out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !g.Equal(p, q) {
+			t.Fatal("decode(encode(p)) != p")
+		}
+	})
+}
+
+func FuzzG2FromCompressed(f *testing.F) {
+	g := NewG2()
+	for _, s := range []int64{1, 2, 0xff} {
+		p := g.MulScalarBig(g.New(), g.One(), big.NewInt(s))
+		compressed := g.ToCompressed(p)
+		for _, seed := range flagSeeds(compressed) {
+			f.Add(seed)
+		}
+		f.Add(addModulus(compressed, fpByteSize))
+	}
+	f.Fuzz(func(t *testing.T, in []byte) {
+		p, err := g.FromCompressed(in)
+		if err != nil {
+			return
+		}
+		if !g.IsOnCurve(p) || !g.InCorrectSubgroup(p) {
+			t.Fatal("decoded point must be in G2")
+		}
+		out := g.ToCompressed(g.New().Set(p))
+		if !bytes.Equal(out, in) {
+			t.Fatalf("non canonical encoding %x of %x accepted", in, out)
+		}
+		q, err := g.FromCompressed(out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !g.Equal(p, q) {
+			t.Fatal("decode(encode(p)) != p")
+		}
+	})
+}
+
+func FuzzGTFromBytes(f *testing.F) {
+	gt := NewGT()
+	e := NewEngine()
+	valid := gt.ToBytes(e.AddPair(e.G1.One(), e.G2.One()).Result())
+	f.Add(valid)
+	f.Add(gt.ToBytes(gt.New()))
+	f.Add(addModulus(valid, 0))
+	f.Add(addModulus(valid, 11*fpByteSize))
+	f.Add(valid[:len(valid)-1])
+	f.Add(make([]byte, len(valid)))
+	f.Fuzz(func(t *testing.T, in []byte) {
+		e, err := gt.FromBytes(in)
+		if err != nil {
+			return
+		}
+		if !gt.IsValid(e) {
+			t.Fatal("decoded element must be in GT")
+		}
+		out := gt.ToBytes(e)
+		if !bytes.Equal(out, in) {
+			t.Fatalf("non canonical encoding %x of %x accepted", in, out)
+		}
+		e2, err := gt.FromBytes(out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !e.Equal(e2) {
+			t.Fatal("decode(encode(e)) != e")
+		}
+	})
+}
+
+func TestDecodingRejectsNonCanonical(t *testing.T) {
+	g1, g2, gt := NewG1(), NewG2(), NewGT()
+	for i := 0; i < fuz; i++ {
+		p1 := g1.ToCompressed(g1.randCorrect())
+		if _, err := g1.FromCompressed(addModulus(p1, 0)); err == nil {
+			t.Fatal("G1 x >= p must be rejected")
+		}
+		p2 := g2.ToCompressed(g2.randCorrect())
+		for _, off := range []int{0, fpByteSize} {
+			if _, err := g2.FromCompressed(addModulus(p2, off)); err == nil {
+				t.Fatal("G2 x >= p must be rejected")
+			}
+		}
+		e := gt.ToBytes(gt.randCorrect(t))
+		for off := 0; off < len(e); off += fpByteSize {
+			if _, err := gt.FromBytes(addModulus(e, off)); err == nil {
+				t.Fatal("GT coefficient >= p must be rejected")
+			}
+		}
+	}
+}
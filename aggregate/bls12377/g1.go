@@ -83,12 +83,10 @@ func (g *G1) FromBytes(in []byte) (*PointG1, error) {
 
 	p0, err := fromBytes(in[:fpByteSize])
 	if err != nil {
-		fmt.Printf("fromBytes err: %s\n", err)
 		return nil, err
 	}
 	p1, err := fromBytes(in[fpByteSize:])
 	if err != nil {
-		fmt.Printf("fromBytes1 err: %s\n", err)
 		return nil, err
 	}
 
@@ -380,6 +378,50 @@ func (g *G1) MulScalarBig(r, p *PointG1, e *big.Int) *PointG1 {
 	return g.glvMulBig(r, p, e)
 }
 
+// MulScalarCT multiplies a point by given secret scalar value and assigns the result to point at first argument.
+// Unlike MulScalar the scalar is processed in regular signed windows of fixed size and table entries are
+// selected by scanning the whole table, so that neither the sequence of group operations nor memory
+// accesses depend on the scalar. Exceptional cases of point addition are not handled in constant time.
+func (g *G1) MulScalarCT(r, p *PointG1, e *Fr) *PointG1 {
+	if g.IsZero(p) {
+		return r.Zero()
+	}
+
+	// table = {P, 3P, 5P, ..., (2^w - 1)P}
+	table := make([]PointG1, CTTableSize)
+	refs := make([]*PointG1, CTTableSize)
+	double := g.New()
+	g.Double(double, p)
+	g.Affine(double)
+	table[0].Set(p)
+	refs[0] = &table[0]
+	for i := 1; i < CTTableSize; i++ {
+		g.AddMixed(&table[i], &table[i-1], double)
+		refs[i] = &table[i]
+	}
+	g.AffineBatch(refs)
+
+	acc := MulCT[PointG1](ctG1{g}, table, (*[4]uint64)(e))
+	return r.Set(&acc)
+}
+
+// ctG1 adds the affine table entries of MulCT in mixed coordinates.
+type ctG1 struct{ g *G1 }
+
+func (c ctG1) Set(r, p *PointG1) { r.Set(p) }
+
+func (c ctG1) Double(r, p *PointG1) { c.g.Double(r, p) }
+
+func (c ctG1) Add(r, p, q *PointG1) { c.g.AddMixed(r, p, q) }
+
+func (c ctG1) Neg(r, p *PointG1) { c.g.Neg(r, p) }
+
+func (c ctG1) Select(cond uint64, r, x0, x1 *PointG1) {
+	t := *x1
+	r.Set(x0)
+	r.cmov(&t, cond)
+}
+
 func (g *G1) mulScalar(c, p *PointG1, e *Fr) *PointG1 {
 	q, n := &PointG1{}, &PointG1{}
 	n.Set(p)
@@ -578,48 +620,38 @@ func (g *G1) MultiExpBig(r *PointG1, points []*PointG1, scalars []*big.Int) (*Po
 // MultiExp calculates multi exponentiation. Given pairs of G1 point and scalar values `(P_0, e_0), (P_1, e_1), ... (P_n, e_n)`,
 // calculates `r = e_0 * P_0 + e_1 * P_1 + ... + e_n * P_n`. Length of points and scalars are expected to be equal,
 // otherwise an error is returned. Result is assigned to point at first argument.
+// Pippenger's bucket method is used with signed digits and a window size chosen from the number of points.
 func (g *G1) MultiExp(r *PointG1, points []*PointG1, scalars []*Fr) (*PointG1, error) {
+	return g.MultiExpParallel(r, points, scalars, 1)
+}
+
+// MultiExpParallel calculates multi exponentiation as MultiExp does
+// while bucket windows are processed concurrently by given number of workers.
+func (g *G1) MultiExpParallel(r *PointG1, points []*PointG1, scalars []*Fr, workers int) (*PointG1, error) {
 	if len(points) != len(scalars) {
 		return nil, errors.New("point and scalar vectors should be in same length")
 	}
 
 	g.AffineBatch(points)
 
-	c := 3
-	if len(scalars) >= 32 {
-		c = int(math.Ceil(math.Log(float64(len(scalars)))))
-	}
-
-	bucketSize := (1 << c) - 1
-	windows := make([]*PointG1, 255/c+1)
-	bucket := make([]PointG1, bucketSize)
-
-	for j := 0; j < len(windows); j++ {
-
-		for i := 0; i < bucketSize; i++ {
-			bucket[i].Zero()
-		}
-
-		for i := 0; i < len(scalars); i++ {
-			index := bucketSize & int(scalars[i].sliceUint64(c*j))
-			if index != 0 {
-				g.AddMixed(&bucket[index-1], &bucket[index-1], points[i])
-			}
-		}
+	c := multiExpWindowSize(len(scalars))
+	digits := signedDigits(scalars, c)
+	windows := make([]*PointG1, len(digits))
 
-		acc, sum := g.New(), g.New()
-		for i := bucketSize - 1; i >= 0; i-- {
-			g.Add(sum, sum, &bucket[i])
-			g.Add(acc, acc, sum)
+	parallelize(len(windows), workers, func(start, end int) {
+		// group instances hold temporary values and can not be shared among workers
+		g := NewG1()
+		for j := start; j < end; j++ {
+			windows[j] = g.New()
+			g.bucketSum(windows[j], points, digits[j], c)
 		}
-		windows[j] = g.New().Set(acc)
-	}
+	})
 
 	g.AffineBatch(windows)
 
 	acc := g.New()
 	for i := len(windows) - 1; i >= 0; i-- {
-		for j := 0; j < c; j++ {
+		for j := uint(0); j < c; j++ {
 			g.Double(acc, acc)
 		}
 		g.AddMixed(acc, acc, windows[i])
@@ -627,6 +659,79 @@ func (g *G1) MultiExp(r *PointG1, points []*PointG1, scalars []*Fr) (*PointG1, e
 	return r.Set(acc), nil
 }
 
+// MultiExpTableG1 keeps multiples of fixed bases for multi exponentiation.
+// Row j holds `2^(c*j) * P_i` in affine form so that no doubling is needed across windows.
+type MultiExpTableG1 struct {
+	c      uint
+	points [][]*PointG1
+}
+
+// NewMultiExpTable precomputes a table for fixed bases such as generators
+// to be used with MultiExpTable. Table size is about 253/c times the number of bases.
+func (g *G1) NewMultiExpTable(points []*PointG1) *MultiExpTableG1 {
+	c := multiExpWindowSize(len(points))
+	windows := frBitSize/int(c) + 1
+	table := &MultiExpTableG1{c, make([][]*PointG1, windows)}
+	for j := 0; j < windows; j++ {
+		row := make([]*PointG1, len(points))
+		for i := range points {
+			if j == 0 {
+				row[i] = g.New().Set(points[i])
+				continue
+			}
+			row[i] = g.New().Set(table.points[j-1][i])
+			for k := uint(0); k < c; k++ {
+				g.Double(row[i], row[i])
+			}
+		}
+		g.AffineBatch(row)
+		table.points[j] = row
+	}
+	return table
+}
+
+// MultiExpTable calculates multi exponentiation as MultiExp does
+// for the bases of a table created with NewMultiExpTable.
+func (g *G1) MultiExpTable(r *PointG1, table *MultiExpTableG1, scalars []*Fr) (*PointG1, error) {
+	if len(table.points) == 0 || len(table.points[0]) != len(scalars) {
+		return nil, errors.New("point and scalar vectors should be in same length")
+	}
+	digits := signedDigits(scalars, table.c)
+	// all windows share the same buckets
+	points := make([]*PointG1, 0, len(digits)*len(scalars))
+	flat := make([]int32, 0, len(digits)*len(scalars))
+	for j := range digits {
+		points = append(points, table.points[j]...)
+		flat = append(flat, digits[j]...)
+	}
+	return g.bucketSum(r, points, flat, table.c), nil
+}
+
+// bucketSum calculates `Σ d_i * P_i` for affine points and signed digits with |d_i| <= 2^(c-1).
+func (g *G1) bucketSum(r *PointG1, points []*PointG1, digits []int32, c uint) *PointG1 {
+	bucket := make([]PointG1, 1<<(c-1))
+	for i := range bucket {
+		bucket[i].Zero()
+	}
+	neg := new(PointG1)
+	for i, d := range digits {
+		if d > 0 {
+			g.AddMixed(&bucket[d-1], &bucket[d-1], points[i])
+		} else if d < 0 {
+			g.Neg(neg, points[i])
+			g.AddMixed(&bucket[-d-1], &bucket[-d-1], neg)
+		}
+	}
+
+	// Σ k * B_k = B_n + (B_n + B_n-1) + ... + (B_n + ... + B_1)
+	acc, sum := g.New(), g.New()
+	for i := len(bucket) - 1; i >= 0; i-- {
+		g.Add(sum, sum, &bucket[i])
+		g.Add(acc, acc, sum)
+	}
+	return r.Set(acc)
+}
+
 // ClearCofactor maps given a G1 point to correct subgroup
 func (g *G1) ClearCofactor(p *PointG1) *PointG1 {
 	return g.wnafMulBig(p, p, cofactorG1)
@@ -724,3 +829,76 @@ func (g *G1) ToCompressed(p *PointG1) []byte {
 	out[0] |= 1 << 7
 	return out
 }
+
+// Encode serializes a point in given format.
+func (g *G1) Encode(p *PointG1, f Format) ([]byte, error) {
+	switch f {
+	case FormatZcashCompressed:
+		return g.ToCompressed(p), nil
+	case FormatZcashUncompressed, FormatGnarkCryptoRaw:
+		out := g.ToBytes(p)
+		if g.IsZero(p) {
+			out[0] |= flagInfinity
+		}
+		return out, nil
+	case FormatEIP2537:
+		return eip2537Pad(g.ToBytes(p)), nil
+	}
+	return nil, fmt.Errorf("unknown point format %s", f)
+}
+
+// Decode constructs a point given input in the format.
+// Decode returns error if given point is not on correct subgroup.
+func (g *G1) Decode(in []byte, f Format) (*PointG1, error) {
+	if f == FormatGnarkCryptoRaw {
+		f = FormatZcashUncompressed
+		if len(in) > 0 && in[0]&flagCompressed != 0 {
+			f = FormatZcashCompressed
+		}
+	}
+	if len(in) != f.G1Size() {
+		return nil, fmt.Errorf("input string length must be equal to %d bytes", f.G1Size())
+	}
+	var p *PointG1
+	switch f {
+	case FormatZcashCompressed:
+		return g.FromCompressed(in)
+	case FormatZcashUncompressed:
+		infinity, err := decodeUncompressedFlags(in)
+		if err != nil {
+			return nil, err
+		}
+		if infinity {
+			return g.Zero(), nil
+		}
+		if p, err = g.fromBytesUnchecked(in); err != nil {
+			return nil, err
+		}
+		if !g.IsOnCurve(p) {
+			return nil, errors.New("point is not on curve")
+		}
+	case FormatEIP2537:
+		raw, err := eip2537Unpad(in)
+		if err != nil {
+			return nil, err
+		}
+		if p, err = g.FromBytes(raw); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown point format %s", f)
+	}
+	if !g.InCorrectSubgroup(p) {
+		return nil, errors.New("point is not on correct subgroup")
+	}
+	return p, nil
+}
+
+// Convert decodes a point in one format and encodes it in another.
+func (g *G1) Convert(in []byte, from, to Format) ([]byte, error) {
+	p, err := g.Decode(in, from)
+	if err != nil {
+		return nil, err
+	}
+	return g.Encode(p, to)
+}
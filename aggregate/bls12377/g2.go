@@ -2,6 +2,7 @@ package bls12377
 
 import (
 	"errors"
+	"fmt"
 	"math"
 	"math/big"
 )
@@ -384,6 +385,50 @@ func (g *G2) MulScalarBig(r, p *PointG2, e *big.Int) *PointG2 {
 	return g.glvMulBig(r, p, e)
 }
 
+// MulScalarCT multiplies a point by given secret scalar value and assigns the result to point at first argument.
+// Unlike MulScalar the scalar is processed in regular signed windows of fixed size and table entries are
+// selected by scanning the whole table, so that neither the sequence of group operations nor memory
+// accesses depend on the scalar. Exceptional cases of point addition are not handled in constant time.
+func (g *G2) MulScalarCT(r, p *PointG2, e *Fr) *PointG2 {
+	if g.IsZero(p) {
+		return r.Zero()
+	}
+
+	// table = {P, 3P, 5P, ..., (2^w - 1)P}
+	table := make([]PointG2, CTTableSize)
+	refs := make([]*PointG2, CTTableSize)
+	double := g.New()
+	g.Double(double, p)
+	g.Affine(double)
+	table[0].Set(p)
+	refs[0] = &table[0]
+	for i := 1; i < CTTableSize; i++ {
+		g.AddMixed(&table[i], &table[i-1], double)
+		refs[i] = &table[i]
+	}
+	g.AffineBatch(refs)
+
+	acc := MulCT[PointG2](ctG2{g}, table, (*[4]uint64)(e))
+	return r.Set(&acc)
+}
+
+// ctG2 adds the affine table entries of MulCT in mixed coordinates.
+type ctG2 struct{ g *G2 }
+
+func (c ctG2) Set(r, p *PointG2) { r.Set(p) }
+
+func (c ctG2) Double(r, p *PointG2) { c.g.Double(r, p) }
+
+func (c ctG2) Add(r, p, q *PointG2) { c.g.AddMixed(r, p, q) }
+
+func (c ctG2) Neg(r, p *PointG2) { c.g.Neg(r, p) }
+
+func (c ctG2) Select(cond uint64, r, x0, x1 *PointG2) {
+	t := *x1
+	r.Set(x0)
+	r.cmov(&t, cond)
+}
+
 func (g *G2) mulScalar(c, p *PointG2, e *Fr) *PointG2 {
 	q, n := &PointG2{}, &PointG2{}
 	n.Set(p)
@@ -581,48 +626,38 @@ func (g *G2) MultiExpBig(r *PointG2, points []*PointG2, scalars []*big.Int) (*Po
 // MultiExp calculates multi exponentiation. Given pairs of G2 point and scalar values `(P_0, e_0), (P_1, e_1), ... (P_n, e_n)`,
 // calculates `r = e_0 * P_0 + e_1 * P_1 + ... + e_n * P_n`. Length of points and scalars are expected to be equal,
 // otherwise an error is returned. Result is assigned to point at first argument.
+// Pippenger's bucket method is used with signed digits and a window size chosen from the number of points.
 func (g *G2) MultiExp(r *PointG2, points []*PointG2, scalars []*Fr) (*PointG2, error) {
+	return g.MultiExpParallel(r, points, scalars, 1)
+}
+
+// MultiExpParallel calculates multi exponentiation as MultiExp does
+// while bucket windows are processed concurrently by given number of workers.
+func (g *G2) MultiExpParallel(r *PointG2, points []*PointG2, scalars []*Fr, workers int) (*PointG2, error) {
 	if len(points) != len(scalars) {
 		return nil, errors.New("point and scalar vectors should be in same length")
 	}
 
 	g.AffineBatch(points)
 
-	c := 3
-	if len(scalars) >= 32 {
-		c = int(math.Ceil(math.Log(float64(len(scalars)))))
-	}
-
-	bucketSize := (1 << c) - 1
-	windows := make([]*PointG2, 255/c+1)
-	bucket := make([]PointG2, bucketSize)
-
-	for j := 0; j < len(windows); j++ {
-
-		for i := 0; i < bucketSize; i++ {
-			bucket[i].Zero()
-		}
-
-		for i := 0; i < len(scalars); i++ {
-			index := bucketSize & int(scalars[i].sliceUint64(c*j))
-			if index != 0 {
-				g.AddMixed(&bucket[index-1], &bucket[index-1], points[i])
-			}
-		}
+	c := multiExpWindowSize(len(scalars))
+	digits := signedDigits(scalars, c)
+	windows := make([]*PointG2, len(digits))
 
-		acc, sum := g.New(), g.New()
-		for i := bucketSize - 1; i >= 0; i-- {
-			g.Add(sum, sum, &bucket[i])
-			g.Add(acc, acc, sum)
+	parallelize(len(windows), workers, func(start, end int) {
+		// group instances hold temporary values and can not be shared among workers
+		g := NewG2()
+		for j := start; j < end; j++ {
+			windows[j] = g.New()
+			g.bucketSum(windows[j], points, digits[j], c)
 		}
-		windows[j] = g.New().Set(acc)
-	}
+	})
 
 	g.AffineBatch(windows)
 
 	acc := g.New()
 	for i := len(windows) - 1; i >= 0; i-- {
-		for j := 0; j < c; j++ {
+		for j := uint(0); j < c; j++ {
 			g.Double(acc, acc)
 		}
 		g.AddMixed(acc, acc, windows[i])
@@ -630,6 +665,79 @@ func (g *G2) MultiExp(r *PointG2, points []*PointG2, scalars []*Fr) (*PointG2, e
 	return r.Set(acc), nil
 }
 
+// MultiExpTableG2 keeps multiples of fixed bases for multi exponentiation.
+// Row j holds `2^(c*j) * P_i` in affine form so that no doubling is needed across windows.
+type MultiExpTableG2 struct {
+	c      uint
+	points [][]*PointG2
+}
+
+// NewMultiExpTable precomputes a table for fixed bases such as generators
+// to be used with MultiExpTable. Table size is about 253/c times the number of bases.
+func (g *G2) NewMultiExpTable(points []*PointG2) *MultiExpTableG2 {
+	c := multiExpWindowSize(len(points))
+	windows := frBitSize/int(c) + 1
+	table := &MultiExpTableG2{c, make([][]*PointG2, windows)}
+	for j := 0; j < windows; j++ {
+		row := make([]*PointG2, len(points))
+		for i := range points {
+			if j == 0 {
+				row[i] = g.New().Set(points[i])
+				continue
+			}
+			row[i] = g.New().Set(table.points[j-1][i])
+			for k := uint(0); k < c; k++ {
+				g.Double(row[i], row[i])
+			}
+		}
+		g.AffineBatch(row)
+		table.points[j] = row
+	}
+	return table
+}
+
+// MultiExpTable calculates multi exponentiation as MultiExp does
+// for the bases of a table created with NewMultiExpTable.
+func (g *G2) MultiExpTable(r *PointG2, table *MultiExpTableG2, scalars []*Fr) (*PointG2, error) {
+	if len(table.points) == 0 || len(table.points[0]) != len(scalars) {
+		return nil, errors.New("point and scalar vectors should be in same length")
+	}
+	digits := signedDigits(scalars, table.c)
+	// all windows share the same buckets
+	points := make([]*PointG2, 0, len(digits)*len(scalars))
+	flat := make([]int32, 0, len(digits)*len(scalars))
+	for j := range digits {
+		points = append(points, table.points[j]...)
+		flat = append(flat, digits[j]...)
+	}
+	return g.bucketSum(r, points, flat, table.c), nil
+}
+
+// bucketSum calculates `Σ d_i * P_i` for affine points and signed digits with |d_i| <= 2^(c-1).
+func (g *G2) bucketSum(r *PointG2, points []*PointG2, digits []int32, c uint) *PointG2 {
+	bucket := make([]PointG2, 1<<(c-1))
+	for i := range bucket {
+		bucket[i].Zero()
+	}
+	neg := new(PointG2)
+	for i, d := range digits {
+		if d > 0 {
+			g.AddMixed(&bucket[d-1], &bucket[d-1], points[i])
+		} else if d < 0 {
+			g.Neg(neg, points[i])
+			g.AddMixed(&bucket[-d-1], &bucket[-d-1], neg)
+		}
+	}
+
+	// Σ k * B_k = B_n + (B_n + B_n-1) + ... + (B_n + ... + B_1)
+	acc, sum := g.New(), g.New()
+	for i := len(bucket) - 1; i >= 0; i-- {
+		g.Add(sum, sum, &bucket[i])
+		g.Add(acc, acc, sum)
+	}
+	return r.Set(acc)
+}
+
 // InCorrectSubgroup checks whether given point is in correct subgroup.
 func (g *G2) InCorrectSubgroup(p *PointG2) bool {
 
@@ -771,21 +879,132 @@ func (g *G2) ToCompressed(p *PointG2) []byte {
 	return out
 }
 
+// Encode serializes a point in given format.
+func (g *G2) Encode(p *PointG2, f Format) ([]byte, error) {
+	switch f {
+	case FormatZcashCompressed:
+		out := g.ToCompressed(p)
+		flags := out[0] & flagMask
+		out[0] &^= flagMask
+		swapFp2Halves(out)
+		out[0] |= flags
+		return out, nil
+	case FormatZcashUncompressed, FormatGnarkCryptoRaw:
+		out := g.ToBytes(p)
+		swapFp2Halves(out)
+		if g.IsZero(p) {
+			out[0] |= flagInfinity
+		}
+		return out, nil
+	case FormatEIP2537:
+		return eip2537Pad(g.ToBytes(p)), nil
+	}
+	return nil, fmt.Errorf("unknown point format %s", f)
+}
+
+// Decode constructs a point given input in the format.
+// Decode returns error if given point is not on correct subgroup.
+func (g *G2) Decode(in []byte, f Format) (*PointG2, error) {
+	if f == FormatGnarkCryptoRaw {
+		f = FormatZcashUncompressed
+		if len(in) > 0 && in[0]&flagCompressed != 0 {
+			f = FormatZcashCompressed
+		}
+	}
+	if len(in) != f.G2Size() {
+		return nil, fmt.Errorf("input string length must be equal to %d bytes", f.G2Size())
+	}
+	var p *PointG2
+	switch f {
+	case FormatZcashCompressed:
+		buf := make([]byte, len(in))
+		copy(buf, in)
+		flags := buf[0] & flagMask
+		buf[0] &^= flagMask
+		swapFp2Halves(buf)
+		buf[0] |= flags
+		return g.FromCompressed(buf)
+	case FormatZcashUncompressed:
+		infinity, err := decodeUncompressedFlags(in)
+		if err != nil {
+			return nil, err
+		}
+		if infinity {
+			return g.Zero(), nil
+		}
+		buf := make([]byte, len(in))
+		copy(buf, in)
+		swapFp2Halves(buf)
+		if p, err = g.fromBytesUnchecked(buf); err != nil {
+			return nil, err
+		}
+		if !g.IsOnCurve(p) {
+			return nil, errors.New("point is not on curve")
+		}
+	case FormatEIP2537:
+		raw, err := eip2537Unpad(in)
+		if err != nil {
+			return nil, err
+		}
+		if p, err = g.FromBytes(raw); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown point format %s", f)
+	}
+	if !g.InCorrectSubgroup(p) {
+		return nil, errors.New("point is not on correct subgroup")
+	}
+	return p, nil
+}
+
+// Convert decodes a point in one format and encodes it in another.
+func (g *G2) Convert(in []byte, from, to Format) ([]byte, error) {
+	p, err := g.Decode(in, from)
+	if err != nil {
+		return nil, err
+	}
+	return g.Encode(p, to)
+}
+
+// HashToField hashes msg with the domain separation tag domain to the two elements of Fp2 of
+// hash_to_field of RFC 9380, each encoded as a coordinate of ToBytes.
+func (g *G2) HashToField(msg, domain []byte) ([][]byte, error) {
+	hashRes, err := hashToFpXMDSHA256(msg, domain, 4)
+	if err != nil {
+		return nil, err
+	}
+	u0, u1 := &fe2{*hashRes[0], *hashRes[1]}, &fe2{*hashRes[2], *hashRes[3]}
+	return [][]byte{g.f.toBytes(u0), g.f.toBytes(u1)}, nil
+}
+
+// MapToCurve maps an element of Fp2 encoded as a coordinate of ToBytes to the curve with the
+// SSWU map and the isogeny. The cofactor of the result is not cleared.
+func (g *G2) MapToCurve(in []byte) (*PointG2, error) {
+	u, err := g.f.fromBytes(in)
+	if err != nil {
+		return nil, err
+	}
+	return g.mapToCurve(u), nil
+}
+
+func (g *G2) mapToCurve(u *fe2) *PointG2 {
+	x, y := swuMapG2(g.f, u)
+	// the isogenous curve has a != 0, so the points are mapped to G2 before being added
+	isogenyMapG2(g.f, x, y)
+	return &PointG2{*x, *y, *new(fe2).one()}
+}
+
+// HashToCurve hashes msg with the domain separation tag domain to a point of G2 with
+// expand_message_xmd over SHA-256 and the SSWU map, as gnark-crypto's HashToG2 does.
 func (g *G2) HashToCurve(msg, domain []byte) (*PointG2, error) {
 	hashRes, err := hashToFpXMDSHA256(msg, domain, 4)
 	if err != nil {
 		return nil, err
 	}
-	fp2 := g.f
 	u0, u1 := &fe2{*hashRes[0], *hashRes[1]}, &fe2{*hashRes[2], *hashRes[3]}
-	x0, y0 := swuMapG2(fp2, u0)
-	x1, y1 := swuMapG2(fp2, u1)
-	z0 := new(fe2).one()
-	z1 := new(fe2).one()
-	p0, p1 := &PointG2{*x0, *y0, *z0}, &PointG2{*x1, *y1, *z1}
+	p0, p1 := g.mapToCurve(u0), g.mapToCurve(u1)
 	g.Add(p0, p0, p1)
-	g.Affine(p0)
-	isogenyMapG2(fp2, &p0[0], &p0[1])
 	g.ClearCofactor(p0)
 	return g.Affine(p0), nil
 }
@@ -4,6 +4,7 @@ import (
 	"crypto/rand"
 	"fmt"
 	"math/big"
+	"runtime"
 	"testing"
 )
 
@@ -296,6 +297,35 @@ func TestG2MultiplicativeProperties(t *testing.T) {
 	}
 }
 
+func TestG2MulScalarCT(t *testing.T) {
+	g := NewG2()
+	max := &Fr{^uint64(0), ^uint64(0), ^uint64(0), ^uint64(0)}
+	qMinus1 := new(Fr).Set(&q)
+	lsubAssignFR(qMinus1, &Fr{1})
+	scalars := []*Fr{{0}, {1}, {2}, {3}, {16}, {17}, qMinus1, max}
+	for i := 0; i < fuz; i++ {
+		e, _ := new(Fr).Rand(rand.Reader)
+		scalars = append(scalars, e)
+	}
+	for _, p := range []*PointG2{g.one(), g.rand(), g.randCorrect()} {
+		for _, e := range scalars {
+			expected, result := g.New(), g.New()
+			g.mulScalar(expected, p, e)
+			if e == max {
+				// mulScalar skips bits above the scalar field size
+				g.mulScalarBig(expected, p, e.ToBig())
+			}
+			g.MulScalarCT(result, p, e)
+			if !g.Equal(expected, result) {
+				t.Fatal("constant time scalar multiplication failed", e.ToBig())
+			}
+		}
+	}
+	if !g.IsZero(g.MulScalarCT(g.New(), g.Zero(), &Fr{5})) {
+		t.Fatal("scalar multiplication of zero must be zero")
+	}
+}
+
 func TestG2MultiExpExpected(t *testing.T) {
 	g := NewG2()
 	one := g.one()
@@ -380,6 +410,64 @@ func TestG2MultiExpBig(t *testing.T) {
 	}
 }
 
+func TestG2MultiExpParallel(t *testing.T) {
+	g := NewG2()
+	for _, n := range []int{1, 7, 64, 300} {
+		bases := make([]*PointG2, n)
+		scalars := make([]*Fr, n)
+		for i := 0; i < n; i++ {
+			scalars[i], _ = new(Fr).Rand(rand.Reader)
+			bases[i] = g.rand()
+		}
+		// extreme scalars exercise the last carry of signed digits
+		scalars[0] = new(Fr).Set(&q)
+		lsubAssignFR(scalars[0], &Fr{1})
+		if n > 1 {
+			scalars[1] = new(Fr).Zero()
+		}
+		expected, tmp := g.New(), g.New()
+		for i := 0; i < n; i++ {
+			g.mulScalar(tmp, bases[i], scalars[i])
+			g.Add(expected, expected, tmp)
+		}
+		for _, workers := range []int{0, 1, 3, 100} {
+			result := g.New()
+			_, _ = g.MultiExpParallel(result, bases, scalars, workers)
+			if !g.Equal(expected, result) {
+				t.Fatal("parallel multi-exponentiation failed", n, workers)
+			}
+		}
+	}
+}
+
+func TestG2MultiExpTable(t *testing.T) {
+	g := NewG2()
+	for _, n := range []int{1, 2, 40} {
+		bases := make([]*PointG2, n)
+		for i := 0; i < n; i++ {
+			bases[i] = g.rand()
+		}
+		table := g.NewMultiExpTable(bases)
+		for k := 0; k < 3; k++ {
+			scalars := make([]*Fr, n)
+			for i := 0; i < n; i++ {
+				scalars[i], _ = new(Fr).Rand(rand.Reader)
+			}
+			expected, result := g.New(), g.New()
+			_, _ = g.MultiExp(expected, bases, scalars)
+			if _, err := g.MultiExpTable(result, table, scalars); err != nil {
+				t.Fatal(err)
+			}
+			if !g.Equal(expected, result) {
+				t.Fatal("multi-exponentiation with table failed", n)
+			}
+		}
+		if _, err := g.MultiExpTable(g.New(), table, make([]*Fr, n+1)); err == nil {
+			t.Fatal("length mismatch must be rejected")
+		}
+	}
+}
+
 func TestG2ClearCofactor(t *testing.T) {
 	g := NewG2()
 	for i := 0; i < fuz; i++ {
@@ -477,15 +565,26 @@ func BenchmarkG2MultiExp(t *testing.B) {
 		}
 		return bases, scalars
 	}
-	for _, i := range []int{2, 10, 100, 1000} {
-		t.Run(fmt.Sprint(i), func(t *testing.B) {
-			bases, scalars := v(i)
-			result := g.New()
-			t.ResetTimer()
+	for logN := 4; logN <= 16; logN += 2 {
+		n := 1 << logN
+		bases, scalars := v(n)
+		result := g.New()
+		t.Run(fmt.Sprintf("%d", n), func(t *testing.B) {
 			for i := 0; i < t.N; i++ {
 				_, _ = g.MultiExp(result, bases, scalars)
 			}
 		})
+		t.Run(fmt.Sprintf("%d, parallel", n), func(t *testing.B) {
+			for i := 0; i < t.N; i++ {
+				_, _ = g.MultiExpParallel(result, bases, scalars, runtime.NumCPU())
+			}
+		})
+		table := g.NewMultiExpTable(bases)
+		t.Run(fmt.Sprintf("%d, table", n), func(t *testing.B) {
+			for i := 0; i < t.N; i++ {
+				_, _ = g.MultiExpTable(result, table, scalars)
+			}
+		})
 	}
 }
 
@@ -1,6 +1,7 @@
 package bls12377
 
 import (
+	"bytes"
 	"errors"
 	"math/big"
 )
@@ -104,3 +105,141 @@ func (g *GT) Exp(c, a *E, s *big.Int) {
 func (g *GT) Inverse(c, a *E) {
 	g.fp12.inverse(c, a)
 }
+
+// IsInSubgroup checks whether given element is in target group. It is much cheaper than IsValid
+// which exponentiates by group order. Element is first checked to be in cyclotomic subgroup with
+// a^(p^4 - p^2 + 1) = 1 using Frobenius maps, then a^p = a^x is checked where gcd(p - x, Φ12(p)) = r.
+//
+// Scott, "Unbalancing Pairing-Based Key Exchange Protocols"
+// https://eprint.iacr.org/2013/688.pdf
+func (g *GT) IsInSubgroup(e *E) bool {
+	if e.isZero() {
+		return false
+	}
+	t0, t1, t2 := new(fe12).set(e), new(fe12), new(fe12)
+	// t0 = a^(p^2), t1 = a^(p^4) * a
+	g.fp12.frobeniusMap2(t0)
+	t1.set(t0)
+	g.fp12.frobeniusMap2(t1)
+	g.fp12.mul(t1, t1, e)
+	if !t1.equal(t0) {
+		return false
+	}
+	// a^p == a^x
+	t1.set(e)
+	g.fp12.frobeniusMap1(t1)
+	g.fp12.cyclotomicExp(t2, e, x)
+	return t1.equal(t2)
+}
+
+// gtCompressedSize is the size of a target group element in compressed form.
+const gtCompressedSize = 6 * fpByteSize
+
+// ToCompressed serializes target group element in half size using torus based compression.
+// Element a0 + a1*w is mapped to c = (1 + a0) / a1 in Fp6. Identity element is encoded with
+// compression and infinity flags set in the first byte, and with all remaining bytes zero.
+// ToCompressed returns error if given element is not in cyclotomic subgroup and so can not be compressed.
+//
+// Rubin, Silverberg, "Torus-Based Cryptography"
+// https://doi.org/10.1007/978-3-540-45146-4_21
+func (g *GT) ToCompressed(e *E) ([]byte, error) {
+	fp6 := g.fp12.fp6
+	out := make([]byte, gtCompressedSize)
+	// norm a0^2 - v*a1^2 must be equal to one
+	t0, t1 := new(fe6), new(fe6)
+	fp6.square(t0, &e[0])
+	fp6.square(t1, &e[1])
+	fp6.mulByNonResidue(t1, t1)
+	fp6.sub(t0, t0, t1)
+	if !t0.isOne() {
+		return nil, errors.New("element is not in cyclotomic subgroup")
+	}
+	if e[1].isZero() {
+		if !e[0].isOne() {
+			// minus one has order two and is not in target group
+			return nil, errors.New("element is not in target group")
+		}
+		out[0] |= 1 << 6
+	} else {
+		// c = (1 + a0) / a1
+		fp6.inverse(t1, &e[1])
+		fp6.add(t0, &e[0], fp6.one())
+		fp6.mul(t0, t0, t1)
+		copy(out, fp6.toBytes(t0))
+	}
+	out[0] |= 1 << 7
+	return out, nil
+}
+
+// FromCompressed expects 288 byte input in torus compressed form and returns target group element.
+// FromCompressed returns error if given element is not in target group.
+func (g *GT) FromCompressed(compressed []byte) (*E, error) {
+	c, identity, err := g.decodeCompressed(compressed)
+	if err != nil {
+		return nil, err
+	}
+	if identity {
+		return g.New(), nil
+	}
+	fp6 := g.fp12.fp6
+	// a0 = (c^2 + v) / (c^2 - v)
+	// a1 = 2c / (c^2 - v)
+	// c^2 - v is never zero since v is not a square in Fp6
+	t0, t1, v := new(fe6), new(fe6), new(fe6).zero()
+	v[1].one()
+	e := new(E)
+	fp6.square(t0, c)
+	fp6.sub(t1, t0, v)
+	fp6.inverse(t1, t1)
+	fp6.add(t0, t0, v)
+	fp6.mul(&e[0], t0, t1)
+	fp6.double(t0, c)
+	fp6.mul(&e[1], t0, t1)
+	if !g.IsInSubgroup(e) {
+		return nil, errors.New("element is not in target group")
+	}
+	return e, nil
+}
+
+// EqualCompressed returns true if given two compressed target group elements are both
+// well formed and represent the same element. Compressed form is canonical so elements
+// are compared without decompression, subgroup membership is not checked.
+func (g *GT) EqualCompressed(a, b []byte) bool {
+	if _, _, err := g.decodeCompressed(a); err != nil {
+		return false
+	}
+	if _, _, err := g.decodeCompressed(b); err != nil {
+		return false
+	}
+	return bytes.Equal(a, b)
+}
+
+// decodeCompressed checks flags and returns the Fp6 element of compressed form.
+// Returned flag is true if input encodes the identity element.
+func (g *GT) decodeCompressed(compressed []byte) (*fe6, bool, error) {
+	if len(compressed) != gtCompressedSize {
+		return nil, false, errors.New("input string length must be equal to 288 bytes")
+	}
+	in := make([]byte, gtCompressedSize)
+	copy(in, compressed)
+	if in[0]&(1<<7) == 0 {
+		return nil, false, errors.New("compression flag must be set")
+	}
+	if in[0]&(1<<6) != 0 {
+		for i, v := range in {
+			if (i == 0 && v != 0xc0) || (i != 0 && v != 0x00) {
+				return nil, false, errors.New("input string must be zero when infinity flag is set")
+			}
+		}
+		return nil, true, nil
+	}
+	if in[0]&(1<<5) != 0 {
+		return nil, false, errors.New("sign flag must not be set")
+	}
+	in[0] &= 0x1f
+	c, err := g.fp12.fp6.fromBytes(in)
+	if err != nil {
+		return nil, false, err
+	}
+	return c, false, nil
+}
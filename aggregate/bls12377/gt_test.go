@@ -0,0 +1,180 @@
+package bls12377
+
+import (
+	"crypto/rand"
+	"testing"
+)
+
+func (g *GT) randCorrect(t *testing.T) *E {
+	bls := NewEngine()
+	e := bls.AddPair(bls.G1.One(), bls.G2.One()).Result()
+	g.Exp(e, e, randScalar(qBig))
+	return e
+}
+
+// randCyclotomic returns an element of cyclotomic subgroup which is not in target group
+// f ^ ((p^6 - 1) * (p^2 + 1))
+func (g *GT) randCyclotomic(t *testing.T) *E {
+	f, err := new(fe12).rand(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, c := new(fe12), new(fe12)
+	g.fp12.conjugate(c, f)
+	g.fp12.inverse(e, f)
+	g.fp12.mul(e, e, c)
+	c.set(e)
+	g.fp12.frobeniusMap2(c)
+	g.fp12.mul(e, e, c)
+	return e
+}
+
+func TestGTSubgroupCheck(t *testing.T) {
+	gt := NewGT()
+	if !gt.IsInSubgroup(gt.New()) {
+		t.Fatal("identity must be in subgroup")
+	}
+	if gt.IsInSubgroup(new(fe12).zero()) {
+		t.Fatal("zero must not be in subgroup")
+	}
+	for i := 0; i < 10; i++ {
+		e := gt.randCorrect(t)
+		if !gt.IsInSubgroup(e) || !gt.IsValid(e) {
+			t.Fatal("element is expected to be in subgroup")
+		}
+		e = gt.randCyclotomic(t)
+		if gt.IsInSubgroup(e) || gt.IsValid(e) {
+			t.Fatal("element is not expected to be in subgroup")
+		}
+		f, err := new(fe12).rand(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if gt.IsInSubgroup(f) != gt.IsValid(f) {
+			t.Fatal("subgroup checks must agree")
+		}
+	}
+}
+
+func TestGTCompression(t *testing.T) {
+	gt := NewGT()
+	for i := 0; i < 10; i++ {
+		e := gt.randCorrect(t)
+		compressed, err := gt.ToCompressed(e)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(compressed) != 288 {
+			t.Fatal("bad compressed size")
+		}
+		e2, err := gt.FromCompressed(compressed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !e.Equal(e2) {
+			t.Fatal("compression round trip failed")
+		}
+	}
+	compressed, err := gt.ToCompressed(gt.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if compressed[0] != 0xc0 {
+		t.Fatal("identity must be encoded with infinity flag")
+	}
+	e, err := gt.FromCompressed(compressed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !e.IsOne() {
+		t.Fatal("identity round trip failed")
+	}
+}
+
+func TestGTCompressionInvalidInputs(t *testing.T) {
+	gt := NewGT()
+	valid, err := gt.ToCompressed(gt.randCorrect(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := new(fe12).rand(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := gt.ToCompressed(f); err == nil {
+		t.Fatal("element not in cyclotomic subgroup must not be compressed")
+	}
+	minusOne := new(fe12).one()
+	gt.fp12.neg(minusOne, minusOne)
+	if _, err := gt.ToCompressed(minusOne); err == nil {
+		t.Fatal("minus one must not be compressed")
+	}
+	notInSubgroup, err := gt.ToCompressed(gt.randCyclotomic(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	identityNonZero := make([]byte, 288)
+	identityNonZero[0], identityNonZero[287] = 0xc0, 1
+	noFlag := append([]byte{}, valid...)
+	noFlag[0] &= 0x7f
+	signFlag := append([]byte{}, valid...)
+	signFlag[0] |= 1 << 5
+	nonCanonical := append([]byte{}, valid...)
+	copy(nonCanonical[:fpByteSize], modulus.bytes())
+	nonCanonical[0] |= 1 << 7
+	for name, in := range map[string][]byte{
+		"short":           valid[1:],
+		"long":            append(append([]byte{}, valid...), 0),
+		"no flag":         noFlag,
+		"sign flag":       signFlag,
+		"non canonical":   nonCanonical,
+		"identity":        identityNonZero,
+		"not in subgroup": notInSubgroup,
+	} {
+		if _, err := gt.FromCompressed(in); err == nil {
+			t.Fatalf("%s: input must be rejected", name)
+		}
+	}
+	for name, in := range map[string][]byte{
+		"short":         valid[1:],
+		"no flag":       noFlag,
+		"non canonical": nonCanonical,
+		"identity":      identityNonZero,
+	} {
+		if gt.EqualCompressed(in, in) {
+			t.Fatalf("%s: malformed input must not be equal", name)
+		}
+	}
+}
+
+func TestGTEqualCompressed(t *testing.T) {
+	gt := NewGT()
+	a := gt.randCorrect(t)
+	b := gt.randCorrect(t)
+	ac, _ := gt.ToCompressed(a)
+	bc, _ := gt.ToCompressed(b)
+	a2 := new(E)
+	gt.Mul(a2, a, gt.New())
+	ac2, _ := gt.ToCompressed(a2)
+	if !gt.EqualCompressed(ac, ac2) {
+		t.Fatal("compressed forms must be equal")
+	}
+	if gt.EqualCompressed(ac, bc) {
+		t.Fatal("compressed forms must not be equal")
+	}
+}
+
+func BenchmarkGTSubgroupCheck(t *testing.B) {
+	gt := NewGT()
+	e := gt.New()
+	t.Run("IsInSubgroup", func(t *testing.B) {
+		for i := 0; i < t.N; i++ {
+			gt.IsInSubgroup(e)
+		}
+	})
+	t.Run("IsValid", func(t *testing.B) {
+		for i := 0; i < t.N; i++ {
+			gt.IsValid(e)
+		}
+	})
+}
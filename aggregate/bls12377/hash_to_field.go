@@ -3,6 +3,7 @@ package bls12377
 import (
 	"crypto/sha256"
 	"errors"
+	"math/big"
 )
 
 func hashToFpXMDSHA256(msg []byte, domain []byte, count int) ([]*fe, error) {
@@ -20,12 +21,44 @@ func hashToFpXMDSHA256(msg []byte, domain []byte, count int) ([]*fe, error) {
 	return els, nil
 }
 
+// HashToScalar hashes a message to a scalar field element following hash_to_field of RFC 9380
+// with expand_message_xmd and SHA-256. Security parameter k = 128 so that each element is
+// derived from L = ceil((253 + 128) / 8) = 48 bytes. Result is not in Montgomery form.
+func HashToScalar(msg, dst []byte) (*Fr, error) {
+	els, err := hashToFrXMDSHA256(msg, dst, 1)
+	if err != nil {
+		return nil, err
+	}
+	return els[0], nil
+}
+
+func hashToFrXMDSHA256(msg []byte, domain []byte, count int) ([]*Fr, error) {
+	randBytes, err := expandMsgSHA256XMD(msg, domain, count*48)
+	if err != nil {
+		return nil, err
+	}
+	els := make([]*Fr, count)
+	for i := 0; i < count; i++ {
+		els[i] = new(Fr).fromBig(new(big.Int).SetBytes(randBytes[i*48 : (i+1)*48]))
+	}
+	return els, nil
+}
+
+// ExpandMsgXMD is expand_message_xmd of RFC 9380 with SHA-256, it returns outLen uniform bytes
+// derived from msg and the domain separation tag dst.
+func ExpandMsgXMD(msg, dst []byte, outLen int) ([]byte, error) {
+	return expandMsgSHA256XMD(msg, dst, outLen)
+}
+
 func expandMsgSHA256XMD(msg []byte, domain []byte, outLen int) ([]byte, error) {
 	h := sha256.New()
-	domainLen := uint8(len(domain))
-	if domainLen > 255 {
+	if len(domain) > 255 {
 		return nil, errors.New("invalid domain length")
 	}
+	if outLen < 0 || outLen > 255*h.Size() {
+		return nil, errors.New("invalid output length")
+	}
+	domainLen := uint8(len(domain))
 	// DST_prime = DST || I2OSP(len(DST), 1)
 	// b_0 = H(Z_pad || msg || l_i_b_str || I2OSP(0, 1) || DST_prime)
 	_, _ = h.Write(make([]byte, h.BlockSize()))
@@ -29,28 +29,23 @@ func isogenyMapG1(x, y *fe) {
 	y.set(yNum)
 }
 
-// isogenyMapG2 applies 11-isogeny map for BLS12-381 G1 defined at draft-irtf-cfrg-hash-to-curve-06.
+// isogenyMapG2 applies the 23-isogeny from the curve of the SSWU map to BLS12-377 G2, with the
+// constants of gnark-crypto's hash to G2.
 func isogenyMapG2(e *fp2, x, y *fe2) {
 	if e == nil {
 		e = newFp2()
 	}
-	// https://tools.ietf.org/html/draft-irtf-cfrg-hash-to-curve-06#appendix-C.2
 	params := isogenyConstantsG2
-	degree := 3
-	xNum := new(fe2).set(params[0][degree])
-	xDen := new(fe2).set(params[1][degree])
-	yNum := new(fe2).set(params[2][degree])
-	yDen := new(fe2).set(params[3][degree])
-	for i := degree - 1; i >= 0; i-- {
-		e.mul(xNum, xNum, x)
-		e.mul(xDen, xDen, x)
-		e.mul(yNum, yNum, x)
-		e.mul(yDen, yDen, x)
-		e.add(xNum, xNum, params[0][i])
-		e.add(xDen, xDen, params[1][i])
-		e.add(yNum, yNum, params[2][i])
-		e.add(yDen, yDen, params[3][i])
+	var r [4]*fe2
+	for i := range params {
+		degree := len(params[i]) - 1
+		r[i] = new(fe2).set(params[i][degree])
+		for j := degree - 1; j >= 0; j-- {
+			e.mul(r[i], r[i], x)
+			e.add(r[i], r[i], params[i][j])
+		}
 	}
+	xNum, xDen, yNum, yDen := r[0], r[1], r[2], r[3]
 	e.inverse(xDen, xDen)
 	e.inverse(yDen, yDen)
 	e.mul(xNum, xNum, xDen)
@@ -135,76 +130,472 @@ var isogenyConstansG1 = [4][16]*fe{
 	},
 }
 
-var isogenyConstantsG2 = [4][4]*fe2{
-	[4]*fe2{
+var isogenyConstantsG2 = [4][]*fe2{
+	[]*fe2{
 		&fe2{
-			fe{0x47f671c71ce05e62, 0x06dd57071206393e, 0x7c80cd2af3fd71a2, 0x048103ea9e6cd062, 0xc54516acc8d037f6, 0x13808f550920ea41},
-			fe{0x47f671c71ce05e62, 0x06dd57071206393e, 0x7c80cd2af3fd71a2, 0x048103ea9e6cd062, 0xc54516acc8d037f6, 0x13808f550920ea41},
+			fe{0x314a78149be8f41b, 0xd9809c1c948632ef, 0x63f1a1340987cc87, 0x86688316ffa8c99d, 0x9f095b950631ff96, 0x0079c8c2f826107e},
+			fe{0x5d7334fadd62c272, 0x6f66ee7b250ac89e, 0x885e5e6d79820d7a, 0xf690ed725bfce276, 0xe8051340fe726399, 0x001f5c644ccaea1c},
 		},
 		&fe2{
-			fe{0, 0, 0, 0, 0, 0},
-			fe{0x5fe55555554c71d0, 0x873fffdd236aaaa3, 0x6a6b4619b26ef918, 0x21c2888408874945, 0x2836cda7028cabc5, 0x0ac73310a7fd5abd},
+			fe{0x8b52d9d9a86ce715, 0x6f609bb895337b50, 0xd9c7e02700e3b1c0, 0xc36ab690181d8cd6, 0xad3df2d3c9dd735a, 0x009371d86da5410d},
+			fe{0x2d96d7ae4811d57b, 0xe3f13ca89480e35b, 0xbbb5ddb757ed1116, 0x723838a217e152b4, 0x049c9c8b8c4e7bfc, 0x00d0857caa64befe},
+		},
+		&fe2{
+			fe{0xf3d4d259e5ad34b4, 0x85bf73a6051c6b05, 0xa06bf62b046783e8, 0x07120366cbeb1baf, 0xe9bff5630f31adcb, 0x0029fe9a7cab53fa},
+			fe{0xfaf0adf22bb2f1cd, 0x6603ea733fa8e73f, 0x78fe635e1bad0c26, 0x9e8c7af50da309f9, 0xaf0253caa24e5621, 0x01868de0742f078c},
+		},
+		&fe2{
+			fe{0xc7107733d06de3e8, 0xccfa4e577d8f71b0, 0xc07e6d33e649e108, 0xc6d4cae8d4fc9d6e, 0x1d2579427bc97d4d, 0x018dda50cfc7d05e},
+			fe{0xfc1dd7137a451dd6, 0x6dae4fecb2313f6b, 0x9beceb3c602e628d, 0x53f0e1fe4a991d01, 0x88eaec54957c4263, 0x0100b5684bcb07fd},
+		},
+		&fe2{
+			fe{0x80eb9ef6bc85495e, 0x087bae2d67e90ba6, 0x2bde334b0209a25b, 0x1fbc8e04c8bd4c5d, 0xcb0c08b72f4134e2, 0x01697e572d87356c},
+			fe{0x0307d003baefd680, 0x2cf538352413f0a3, 0x6e69d49ad79fb253, 0x3d85dc98df87d2cf, 0x69808c8372418443, 0x00808caf7a66e662},
+		},
+		&fe2{
+			fe{0x686ab68dec533b1b, 0xdca6665f36ec7c09, 0x80a4c9347e445b72, 0xadd79adc49e062d8, 0x342000a141255c15, 0x016768cd5176a597},
+			fe{0x5655068fdc9b649b, 0x0edb753961d77b1f, 0x7d4c34f7291b5c49, 0x035925b8f55a38d0, 0xf5f189a6387476c1, 0x00d31febc6c821bb},
+		},
+		&fe2{
+			fe{0x0fec298ba066497e, 0xa678ed0616ae0e37, 0x57e73729545793cc, 0x3e51e3cbae5d02de, 0xe4c4a96cbcbf5bfc, 0x0071e3009a7d823c},
+			fe{0x1692817f1566b315, 0xdbe1148c29049baf, 0x2cba15e63510ffd3, 0x0f28fa6d32749943, 0xd8acefb7cca7cfff, 0x007f0b058126a7cf},
+		},
+		&fe2{
+			fe{0x833d5e8344a7ff48, 0x7945a88d2c0d159b, 0xf29bc720c9a8bf36, 0x8e48c9fec628996c, 0xb48243d1b67e76c3, 0x00c01143ab69ee00},
+			fe{0x6d0c01ad1768a91e, 0xfc02a8891d0c0923, 0x608c61f397a62928, 0x0169fa0a99dc6f35, 0x66dad4645001bf26, 0x003e0a12023c8736},
+		},
+		&fe2{
+			fe{0x525ee893c6a3327e, 0x43932149d4d9516b, 0xcead3a2ff4458cc5, 0x5711de54da174076, 0x02a49de432206ea0, 0x01646ed2b41159ba},
+			fe{0x389f92b96d35a774, 0xc7b232e069671f65, 0xd81b215b05eca96f, 0x4d58347a795a7e22, 0x4f4cfa07472b804a, 0x0026c3726df22bdb},
+		},
+		&fe2{
+			fe{0xa73b8fe037a987c4, 0x6a914a26120ecff9, 0x3a0913c657e5deba, 0x3e4ba64cf1ca6cb9, 0x185c11b36a8b2c39, 0x007802fd326ce99b},
+			fe{0x9e1cdaac9442c1b4, 0x9660ead34d24b3b2, 0x41de3335ecdb7199, 0x559eee504509e342, 0x9c41fa419e643611, 0x00bb7b9cd8264b73},
+		},
+		&fe2{
+			fe{0x3f86f71b38e113a9, 0x07e3bb604efdbafd, 0x41c5f87ad911bb68, 0x845c7fb9ac423f49, 0xa1f127ad34dd963d, 0x01a71ec1c8c93638},
+			fe{0x4efb545ca46126e3, 0xbcc175400bc021f0, 0x6c83b521beeb6f4f, 0xa1b535b993fe2ebe, 0x1ef898c01c90b86a, 0x018a3b2a4f48f26e},
+		},
+		&fe2{
+			fe{0x7c4e38075d337cb1, 0x4eace315c83a0303, 0xf08e5f5b521a588c, 0x43e4bb1ebb4d50e8, 0x75dc9768d12f865e, 0x00fb6a36c63fc3fa},
+			fe{0x73f401c9a44299a9, 0xe820242daab2dd55, 0x5ea50a289bb90020, 0x8118c1fe8ac07546, 0x2ec917e86e1a94d1, 0x0074e53f64a69334},
+		},
+		&fe2{
+			fe{0x317158bfee76ee0a, 0xd913d1bbff8235c4, 0x351fd26547e6a87e, 0x4c8405bb3ff80184, 0x10af44e053a1b00a, 0x00443f5d40023ada},
+			fe{0x21c4f8437c111db7, 0xc843f4af9d110b7b, 0x6d60b97b8bedf730, 0x8bfbe98288dffdc9, 0xc6bef7287614ad25, 0x012b39660bdefb86},
+		},
+		&fe2{
+			fe{0x68b11f7a1c5e7f1a, 0xc3b0e545836f3c1c, 0x5915e47b1c2219ab, 0x9d30c3eb44d50ecc, 0xffcbb6d861b1e79c, 0x012f4254174e6251},
+			fe{0xede52dbb72480a6c, 0x479beb875dcf4c71, 0x58babfa8d74b2c01, 0x785af14dd4823f76, 0x6d124f46e1ad72ca, 0x00dcd93927bae02d},
+		},
+		&fe2{
+			fe{0x2901d976b5d99576, 0x9740bb145274bebe, 0x977cd49646b0b4fb, 0x09c34dd307b5ace8, 0xca79f75a3740118f, 0x013fed8699fbdd57},
+			fe{0xff99e03f89feb29a, 0x99329958179238a2, 0x8a66d3c2a33d17d6, 0xb8119ad72f8e308a, 0x3ec3bf51f2165f1c, 0x00e2f7f99192884d},
+		},
+		&fe2{
+			fe{0x88e471ae57ce2073, 0xadd3c1148b142580, 0x483d1a8a24f0267c, 0xaf8ab757f10bbd0f, 0xcb1d88020afaa6fb, 0x01552665324d9cda},
+			fe{0x8f2b39ed8d348533, 0x953232da29c94408, 0x3ea3cc08d50d5c34, 0x2493cacef0e8e2dd, 0xe80cc0bc147d429d, 0x006f854f54711f5c},
+		},
+		&fe2{
+			fe{0x29b02ea537324ff4, 0x2d1d4f40240a3964, 0x6200ad467f20eb72, 0xe1a50c3ab69bf824, 0x169400b63916c162, 0x01123b10bb427050},
+			fe{0xc888e55b992fac90, 0xa414da9b2244c854, 0x6029d62ea45a86cc, 0xd4f4023d51e22ea1, 0xdb196bd11997e4da, 0x00f8069b025d78d3},
+		},
+		&fe2{
+			fe{0x3aad44001036b818, 0xf9f27070198d95cf, 0xbe1dda8332020d29, 0xf5b3e32bd09524f2, 0x4c28fbeb5158f9be, 0x018ea1f2da65eba7},
+			fe{0xcdbb81f47be2e455, 0x69fc84084ca40937, 0x0e72bd26d060158d, 0x3a65a45c1fa46231, 0x85c62b311dfb949e, 0x017b8bd352d48ff7},
+		},
+		&fe2{
+			fe{0x951adfc25293a4df, 0x7951bfc856f5bd1f, 0x3ef8f1f23bdf53aa, 0x85daca28240f750a, 0xe6faa96b8c384d1e, 0x00cc622da7f2ec24},
+			fe{0x504bc59c65928962, 0x0bc4a67fa82ba2a1, 0x9f4a8d5c208580b0, 0xb0d1544672ad9d7d, 0x8cd70a406738937b, 0x017b6be9be258af5},
+		},
+		&fe2{
+			fe{0x59942c4571f4b89c, 0x8a77dc1e9bb882cc, 0x05bbab1e83cf34a7, 0xbcaaf9ad8f555dee, 0xf720e7cba0d67846, 0x018330cb01f98b44},
+			fe{0xec97f0f93cb79670, 0x27ae1b0079e06091, 0x962378effc170491, 0x68c8fe8bd1feec29, 0x065fe124fbf174ca, 0x0085cebbefce9a44},
 		},
 		&fe2{
-			fe{0x0a0c5555555971c3, 0xdb0c00101f9eaaae, 0xb1fb2f941d797997, 0xd3960742ef416e1c, 0xb70040e2c20556f4, 0x149d7861e581393b},
-			fe{0xaff2aaaaaaa638e8, 0x439fffee91b55551, 0xb535a30cd9377c8c, 0x90e144420443a4a2, 0x941b66d3814655e2, 0x0563998853fead5e},
+			fe{0x782a2dd791f4f457, 0xbbcba82b1364ebb0, 0xb2aef04447706b2a, 0x5e817881f52cd9fa, 0x279b0e3eeff0abfa, 0x013535b9f654246d},
+			fe{0xe239e0fdd93b4f9b, 0x7e5a3af338b62807, 0xad22e4b109842724, 0x37d9b8ca5d9c4f63, 0x4e16cbd70b111230, 0x00c964cd75bcb538},
 		},
 		&fe2{
-			fe{0x40aac71c71c725ed, 0x190955557a84e38e, 0xd817050a8f41abc3, 0xd86485d4c87f6fb1, 0x696eb479f885d059, 0x198e1a74328002d2},
+			fe{0x8705cd1de5248651, 0xc78d19ed68d52cf2, 0xd13c748236fb9b08, 0x120905a28107cb98, 0xc22853c6d2cd27f4, 0x0138e365142e6d7a},
+			fe{0x50067fb0981866e8, 0x0f2c29fa2027d348, 0x0f850e30517690ee, 0xe15b3714a2179c7d, 0x4bd2e5e350d635ce, 0x019deb6ee7a34526},
+		},
+		&fe2{
+			fe{0xe627173be192acff, 0x738ce221e042eb10, 0x2b76ce443f84321e, 0x4202f4de3f251660, 0x6a1018fdb04384a9, 0x001742a303b2dc5f},
+			fe{0x95ec20976846bdd0, 0x551fb5dded503658, 0x6bbaabcedc689a15, 0xd0a9e3d2ef614cdf, 0x8d9283a358061d5c, 0x0110781b5441fc9b},
+		},
+		&fe2{
+			fe{0x3242df55a7eca48a, 0x105e7a39c4e65a03, 0x868c02141c568769, 0x4378c64523ddc744, 0xd92a719398a84931, 0x016e3ed4bb9b0cea},
 			fe{0, 0, 0, 0, 0, 0},
 		},
 	},
-	[4]*fe2{
+	[]*fe2{
 		&fe2{
-			fe{0, 0, 0, 0, 0, 0},
-			fe{0x1f3affffff13ab97, 0xf25bfc611da3ff3e, 0xca3757cb3819b208, 0x3e6427366f8cec18, 0x03977bc86095b089, 0x04f69db13f39a952},
+			fe{0x0ec0b53ac73afbd6, 0xba3a59fef638b34b, 0xf538f34e61416278, 0xec059b40cede5a4e, 0x25aa431285ffd38b, 0x003500c9dcb9da9d},
+			fe{0xe74c9cbfacd7b395, 0x90827e1c3c0921a7, 0x22e0f8f8f9e42d37, 0x4743624084226278, 0xfad6047644836c49, 0x00eb6798cdaef07b},
 		},
 		&fe2{
-			fe{0x447600000027552e, 0xdcb8009a43480020, 0x6f7ee9ce4a6e8b59, 0xb10330b7c0a95bc6, 0x6140b1fcfb1e54b7, 0x0381be097f0bb4e1},
-			fe{0x7588ffffffd8557d, 0x41f3ff646e0bffdf, 0xf7b1e8d2ac426aca, 0xb3741acd32dbb6f8, 0xe9daf5b9482d581f, 0x167f53e0ba7431b8},
+			fe{0x97d42a06b6ef98ce, 0x277c180020c0807e, 0xeb85756dbfe9c549, 0x3eb1896134f28e77, 0xa6be7eba6e1b40ec, 0x016a3e54ab2c9fcf},
+			fe{0x192620e67396ddd7, 0x7d75de89ea320721, 0x157ce9be6c8beba0, 0x49e1a10fa5a8b64f, 0xa157b057ff6b41a6, 0x000efe46ee9028f3},
 		},
 		&fe2{
-			fe{0x760900000002fffd, 0xebf4000bc40c0002, 0x5f48985753c758ba, 0x77ce585370525745, 0x5c071a97a256ec6d, 0x15f65ec3fa80e493},
-			fe{0, 0, 0, 0, 0, 0},
+			fe{0x7a16276f3aaab210, 0xf70f02d71c2486d1, 0x8cf4ecac0bd89642, 0x083b8fe2e5899901, 0xc4d8f262c939a87f, 0x01832bdb12d9b08d},
+			fe{0xd7dde1f7458a4e87, 0x14fd24ea585cf53a, 0x2d46236ecde820ca, 0x405e920b4bd1dfb1, 0x01efc11400ad05fb, 0x00bfef5e04e17872},
 		},
 		&fe2{
-			fe{0, 0, 0, 0, 0, 0},
+			fe{0x9e8cbf8c42496d76, 0xbabe7ca303cf7f31, 0x1ac7a9e7fa0d6cc5, 0x57858505a5a61beb, 0xc0554357de6d8f32, 0x009473546a7ab9c3},
+			fe{0x6981a7342be8e03c, 0x43f6dcd7b9fb9b8e, 0xec016e092188f8e4, 0xeeee21e974575ce2, 0x34200f537e62bda8, 0x001331b4137beb19},
+		},
+		&fe2{
+			fe{0x2189426fc37cdc36, 0x430bfd8d55da53fa, 0xf00e0a1071b86b35, 0x5dfca89d0c45abb7, 0xfc3a6fcce996e7b9, 0x00d197a28170f3c8},
+			fe{0xd8a351ea2d22a09a, 0x8472015350e69b25, 0x7c7cdc10b06a7553, 0xb071fb08b640d36a, 0x22000fd63648d465, 0x0023f50cda31aa08},
+		},
+		&fe2{
+			fe{0xd58f524eea969db5, 0xd2a4ee4257a203a2, 0x40b850f9290ec979, 0x3781f87e27561c8d, 0x66200ce2f358e686, 0x00756628f17f0715},
+			fe{0x20b4377f24ce7931, 0x404fb53b17195518, 0xb880b6f189376699, 0xff128b4ccb8112a0, 0xc1958abebb9355d7, 0x0084b238b9612f0b},
+		},
+		&fe2{
+			fe{0x44fab7d981b34f49, 0x754b569060630a9b, 0x951b03d4329c0e2e, 0x042264516da3e655, 0x4e973efe7d39da3e, 0x00c678acb6188a8a},
+			fe{0xbdcd1d4d660bdb5d, 0x872c118351fc4df4, 0x14166f06ed06582c, 0x64c90a3f2c2a1f74, 0x3de6f15097742e58, 0x00ad016280467eba},
+		},
+		&fe2{
+			fe{0x3a8bba784f380720, 0x120d806a7156cb0a, 0x57bd4842785fc031, 0x22f0744929f451b2, 0xdd20ee73be179f68, 0x007efbc18b669adc},
+			fe{0x7857003704cf6976, 0x94dffd09cf982f6c, 0xfab8caa82a671270, 0x1cd3d9fca64c370f, 0xff64425733b7ce18, 0x0027452892db1869},
+		},
+		&fe2{
+			fe{0xbc08c295dc5bead1, 0xbab945b16822be45, 0xaf378df962fc54e6, 0xe20ef2c21663bcea, 0x09d9ab3ded2e792b, 0x0119fe00d9f7fb13},
+			fe{0x8761a69bdac3a38e, 0x6fe50f9b6430e6de, 0xa1ea922e3957fb6f, 0xb168cdee5f103ae2, 0x4a0c389bf4b2e34b, 0x01a6819bab871b30},
+		},
+		&fe2{
+			fe{0x45ba0241d880599d, 0x7f269f33f5f9f863, 0xcb22f8966b1f00a0, 0x45fc3540031fc77b, 0x987aae6570fbcc92, 0x00a49ee863078235},
+			fe{0xf230e2e6f3cd7126, 0x8e1599ec653a70ff, 0x8f08de4dad988bc6, 0xe8eb9619fdf2f02f, 0x20d5cec1622b8d04, 0x006049fd2047f2e1},
+		},
+		&fe2{
+			fe{0x18b2d5a20f9808cf, 0xbde1cea7e9740326, 0x04702dc003e2b189, 0x96f6ee220b43a981, 0x49014cde8dc51d73, 0x018bb808fd425ac1},
+			fe{0x8fd73894caceaa4f, 0x52a6d507dfe98bd3, 0xe1a6550312e05cae, 0x09b1498bf1fd67cc, 0xc8a86516d656fba9, 0x00235a99e2280121},
+		},
+		&fe2{
+			fe{0x88d65b94f51cd3e2, 0x415dc0e1f0c8601b, 0xe82bc6333052c9c9, 0x102712e0f5762855, 0xebea79ea77952d86, 0x009fe60405e2412e},
+			fe{0x1591795533df82f1, 0xb46fa155f337f4d9, 0xb59059d549083754, 0xd6837ccf9afe25b2, 0x80085510c3b8b60a, 0x008e6f55e7674922},
+		},
+		&fe2{
+			fe{0xd288115f7f1d1a4a, 0x072817bcde287934, 0x68b8f80f8e94acb3, 0x1c50f6e84322d480, 0xceed368a144fcfa9, 0x00b764b1c7cd76c8},
+			fe{0xc3a5819e87195638, 0xad8ca35e76592917, 0xc231679bf48512ca, 0x8d2bd9496b52ac50, 0x08739266f25305d7, 0x00f8d0291974e871},
+		},
+		&fe2{
+			fe{0x406fa24e92f5d386, 0xda63b4f471841bf8, 0xd9a0766d124849ac, 0xf014d49e00946017, 0xc0fd4d3947951470, 0x014ba1fada84629c},
+			fe{0x3fea71674351da4f, 0xfa17d1b71c20fb05, 0xdf8e79685f9ce13e, 0x7d873fc47d7afb36, 0x4636526f39506960, 0x0188b469852fe315},
+		},
+		&fe2{
+			fe{0x9fd4fd139d6ced33, 0x6eb9c07239bff140, 0x80928078e99a17f4, 0x754ad5fafdc32eb1, 0x40e1f381c5933ee3, 0x0184e461a2d1aa9f},
+			fe{0x31ee864de7d9871d, 0x342d11cee18b096c, 0xc67c6280082f28de, 0xf1eecd0adbbdce24, 0xf957c0a268c80708, 0x012f4813ae90f017},
+		},
+		&fe2{
+			fe{0x89f157d221c09501, 0xbaaf8f20029bfd78, 0x47324de826f33c0c, 0x35ed9aeacb5f7b4d, 0x461b334de38ffc5c, 0x0114c341b660fae5},
+			fe{0x07369296f42dbb44, 0x853d4dc42f6c4751, 0xd21df7a1f7403474, 0x8a62095294c0dbe3, 0x7a38cdfd282d6d30, 0x00035e674d8032e8},
+		},
+		&fe2{
+			fe{0x273cba5ff80e4e97, 0x5fb2ec9b20a68c02, 0xb901700147fde6b3, 0xc1aac8b17867c21b, 0xc78c0ba76f033c85, 0x008677990f6d0b25},
+			fe{0x4e894429dd63b340, 0x124233549c9ee554, 0x490b06358b368e7d, 0x601d72bd4cf63757, 0x1cd39185a2a407a0, 0x01155285e80959ae},
+		},
+		&fe2{
+			fe{0xa76869d84cf01cea, 0xa48c45641d9db3b5, 0xa4cde0af486cc694, 0x6bf592ad33030b99, 0x5be2936efdb157c6, 0x00ce58a56d8373d0},
+			fe{0xea4e3124e58f999e, 0xb4b1772f86672040, 0xbf602c6873141663, 0x15fcbc3defa26705, 0xc608575d09b8749a, 0x008c68013e66307c},
+		},
+		&fe2{
+			fe{0x91ab988eedd69f31, 0xf2215e7b124814ca, 0x6da97975fedbea30, 0xadcceba73a812353, 0xc2f372873fad29bc, 0x010de3d159d2003d},
+			fe{0x0ce3f033d8e0462a, 0xc6060c96faae1b21, 0xe12193a63500d455, 0x50902ef9d451e48b, 0xff6c7f29a2709d21, 0x0121f103e165e2aa},
+		},
+		&fe2{
+			fe{0xa605fcb90cbbb388, 0x90afba6371091c20, 0xfe73da940c1e3e42, 0xd15efc17e076acc8, 0xe5309b0d2ac1b97e, 0x006fee40fd472b00},
+			fe{0xad856bf76e396de5, 0x8069730cdc7f2cf0, 0x691cf55443e1c7f2, 0x84eeab3569890572, 0x45591feabb06765c, 0x000eac2f10e99461},
+		},
+		&fe2{
+			fe{0x1c310eaa54466d5f, 0x9d9f97bdcdf75ab8, 0x2ca22727d07b521e, 0xfebe3c752ef577cb, 0xcf32f61a8aae9f68, 0x00a076ae62c522fb},
+			fe{0x3aa113972cec0b76, 0x47a6026c6e592d9b, 0x8592dfb2ecd12327, 0x85ddcb9b31fe83f3, 0x2a38e3df8261ebb0, 0x019e7061afd376db},
+		},
+		&fe2{
+			fe{0x09d202bd20177ad3, 0x40e1148b2a47bddd, 0x6de17dcee727b0ea, 0x8c4c28ac60d51912, 0x7ccf032d59e11f4a, 0x010253300b0b877c},
+			fe{0xb67c18de7a2e3981, 0xbea7c35c92c04c5f, 0x1c3f8a0102012df6, 0xfb7491e2675b4164, 0x24857dbabd93d873, 0x0009eec00179da36},
+		},
+		&fe2{
+			fe{0x02cdffffffffff68, 0x51409f837fffffb1, 0x9f7db3a98a7d3ff2, 0x7b4e97b76e7c6305, 0x4cf495bf803c84e8, 0x008d6661e2fdf49a},
 			fe{0, 0, 0, 0, 0, 0},
 		},
 	},
-	[4]*fe2{
+	[]*fe2{
 		&fe2{
-			fe{0x96d8f684bdfc77be, 0xb530e4f43b66d0e2, 0x184a88ff379652fd, 0x57cb23ecfae804e1, 0x0fd2e39eada3eba9, 0x08c8055e31c5d5c3},
-			fe{0x96d8f684bdfc77be, 0xb530e4f43b66d0e2, 0x184a88ff379652fd, 0x57cb23ecfae804e1, 0x0fd2e39eada3eba9, 0x08c8055e31c5d5c3},
+			fe{0xf8c6bf8c7787c327, 0xc29e43cdf5ec883b, 0x58393c730756583f, 0x13d61e9d45fa04c1, 0x904da9443960cd84, 0x0077c4b3db6f2cbd},
+			fe{0x2206bc53ecebbadc, 0x7cabd3abd6f848a0, 0x28646712c13b854c, 0xc356a876fc0d323b, 0x7fd371886e6193b0, 0x000bece2aede3832},
 		},
 		&fe2{
-			fe{0, 0, 0, 0, 0, 0},
-			fe{0xbf0a71c71c91b406, 0x4d6d55d28b7638fd, 0x9d82f98e5f205aee, 0xa27aa27b1d1a18d5, 0x02c3b2b2d2938e86, 0x0c7d13420b09807f},
+			fe{0x4a6ea160b17ad8d8, 0x1741e799cc0e8b08, 0xd49d75b689c2236c, 0xe2091f655f18587a, 0x8e0888b6bc2180a3, 0x008ea615675d8945},
+			fe{0x1e9bb7389d9e6249, 0x3869cf252be31020, 0x38330a6562045858, 0xde1a3abbcdb72b7d, 0xb8716b8ba7b5b90c, 0x00f901e21f5a8ee8},
+		},
+		&fe2{
+			fe{0x1a30fc113f141e1f, 0x631fef9260296414, 0x9b4d780fd0582e2a, 0x5f80c5685c75638c, 0xa824feb90d499353, 0x016e2f2b409460ca},
+			fe{0xdd9c0a0ca8183239, 0x08a4c338a1d036cb, 0xd77ba3fc4db942a4, 0x1ed340bdc76db624, 0xe706c8a69aa0894d, 0x018865914ce45ef8},
+		},
+		&fe2{
+			fe{0x792f57ac74848132, 0x6df900c7fe81d180, 0xaeb1a2006fdf71f6, 0x8bb8d30c2c6c77b4, 0x0ae511b744c85e1f, 0x01ab8f055a435223},
+			fe{0xc96e4c81a364b23d, 0x4d1128c683ef3fe5, 0xb059f58a001249f1, 0x542fd7256d3ba2fd, 0x72e5013c97b923a7, 0x000ca9cf0d750739},
+		},
+		&fe2{
+			fe{0x8b4462d55baa6c3b, 0x876e75c009d650a7, 0x25ce5dc5a585c52d, 0x2ad80e0c02ed7925, 0xcfe4a62b06e87a85, 0x007ae371458c26a3},
+			fe{0xd9ea653d8aede23b, 0x64b5bbb0ab7d215a, 0x15bcc65e171f9578, 0x3801cab639b291be, 0xe2dae194b31bd0b0, 0x012abd5de7afe0d3},
+		},
+		&fe2{
+			fe{0x73edca4391f69bf8, 0x53830bf39cdc4f7d, 0xb57800d6a0f95c1c, 0x1a8ced5c2a872241, 0xb886e207a9254063, 0x009a7dc8b27650a1},
+			fe{0xae76373fc028229c, 0xc2201072e03484d2, 0x4eb807057348b321, 0x619c65131159df41, 0xe9cc24ee161ba71c, 0x009dfef3e472079e},
+		},
+		&fe2{
+			fe{0x124f0f2c8e18f966, 0x1614ae6f02564e98, 0x6c535653b348c01c, 0x4d4c4011d5f28d72, 0x4e35a4d80a17fdac, 0x010a1a15a95fe223},
+			fe{0x8e42ee6aa53aa2d2, 0x9042d753bc997a50, 0x2aa1b7b6f50f5f5d, 0xdfd0fb8f944aca2c, 0x46f0489da109e0a0, 0x0007b451d3929de2},
+		},
+		&fe2{
+			fe{0x6ac26d3bcb6c979c, 0x5d02ecb242eef1e2, 0x9cefef4135ef4a97, 0xa710dd803684512e, 0x63f01ea9c83c16f6, 0x0057f492c89a5a4f},
+			fe{0xf917290b4789edfa, 0x8cc7b8cf8c54fcd1, 0x47b6ce7203afd895, 0x38d2a3adced43317, 0xf8947da53f9cba4d, 0x00a254260a07d09c},
+		},
+		&fe2{
+			fe{0x36483abc1db8e1f0, 0xb72bb1765d880378, 0xb898ec69869f405d, 0xa0f693bac6708227, 0x56e1d8966c489ed1, 0x01161f17e0160df9},
+			fe{0x6d4e79370125c3c1, 0xbbad0336df72e2b3, 0x0da296070bac4c55, 0x1d74f935eb8e61c9, 0xcd719cde2e750c02, 0x00fdb818545d200d},
+		},
+		&fe2{
+			fe{0xcf1c707c56896b89, 0x2c08312e69b960de, 0x731832b72a35bdab, 0xe7f169d2966652fb, 0xc31515caea752b0e, 0x00ccbaf96236295a},
+			fe{0x12e84714e3125216, 0x2ddba643a53f3f87, 0xf49d9392c0aef673, 0xa30a0ef32dd83d8d, 0xc463f2eb77540c40, 0x0124f56ba19ffedc},
+		},
+		&fe2{
+			fe{0x328ade1cb5814637, 0x7c563df3b760e67c, 0x476fa0292db18d6b, 0xe94debeac8c26bf6, 0x14001b8ee866664c, 0x00b8c8dd006f21c1},
+			fe{0xf571d0414e06b6f5, 0x9f022caa8ec6d8ef, 0xa162a5e0d31c0b18, 0xde3b8d93b3260c38, 0x306ccd36e9d1d288, 0x01801733f183c132},
 		},
 		&fe2{
-			fe{0xd7f9555555531c74, 0x21cffff748daaaa8, 0x5a9ad1866c9bbe46, 0x4870a2210221d251, 0x4a0db369c0a32af1, 0x02b1ccc429ff56af},
-			fe{0xe205aaaaaaac8e37, 0xfcdc000768795556, 0x0c96011a8a1537dd, 0x1c06a963f163406e, 0x010df44c82a881e6, 0x174f45260f808feb},
+			fe{0x34e184bfc37b1656, 0x295eb60cc75dc0cd, 0x2715cfdb34a608f3, 0x0222a499ace3584f, 0xa46b3267fd09de31, 0x00a1d465f44ce873},
+			fe{0x30212658fb049ed6, 0x406c66443aa11834, 0x3ac1eb3ee2b34b23, 0xf2cb57dac2ec477e, 0x53d9cbd337de73c6, 0x00f08116403a483c},
 		},
 		&fe2{
-			fe{0xa470bda12f67f35c, 0xc0fe38e23327b425, 0xc9d3d0f2c6f0678d, 0x1c55c9935b5a982e, 0x27f6c0e2f0746764, 0x117c5e6e28aa9054},
+			fe{0x12632dca9515c952, 0xdcff22745cbe6ffd, 0x9e2a60882da56eba, 0x24c9fdabd0dc5b81, 0xdafb5e31826aa46a, 0x017d22e9d665add9},
+			fe{0x6c9adfe203355f1c, 0x7d55aa8a8a832fc1, 0x01f92848a2eebb54, 0x5b5a212ef9e7b319, 0xed57ea8d9745edc0, 0x004e755e0cfba584},
+		},
+		&fe2{
+			fe{0x484dddd49b07aecf, 0x17ab4f0c3e662769, 0x247ae5af04281e86, 0xbbda48ccaee5cf5e, 0xffa3d9b784622d99, 0x019b7d8c1ec99b87},
+			fe{0xd15140afe11efb52, 0x5b28b3eb01f2c4e2, 0xe1700684452b53e7, 0xf6e45e82092d8e5a, 0x6b517b772af474d9, 0x003388113855c225},
+		},
+		&fe2{
+			fe{0x17b5e80a4331243f, 0xc1b5c51e89842e43, 0xd007ed8c588f366f, 0x6d6a74ce12620df5, 0xe2a85516fd36f02b, 0x011e1d1bc51021a1},
+			fe{0xcfe38025500d8ec9, 0x545653be9eb11f8a, 0x73cc1c979fdafc7b, 0x8d06d530e3076817, 0x51c6b8b444044f52, 0x0136ec20e2d47727},
+		},
+		&fe2{
+			fe{0xbfd686a51da091f7, 0x058d4ec82895b6be, 0xcf6c78083042ec62, 0x2a82d059a4ec6ffb, 0xaacc4ff3e6bda447, 0x01255dd6d61d3f8e},
+			fe{0x44c512c2e9fa2874, 0xa0b55c0a209ce235, 0xbfa7b39dba559394, 0xd1ce4c0f46108d9a, 0x4f3d5c1661285560, 0x015b6dc224fc2c71},
+		},
+		&fe2{
+			fe{0x6f6d1dcddac9e2c7, 0x819dc2a39047d592, 0x1f5521aa64ea1263, 0xc017d565b0db4124, 0xa465d1c468ab7301, 0x002706e15ddafb39},
+			fe{0xb2f9cccc2aeef445, 0x9ed9625e7d0c7ad4, 0x6ee43b641d52d20c, 0x2e1e62cf49ea02f0, 0x723971a8708dbdd6, 0x0081fee016eeb4a8},
+		},
+		&fe2{
+			fe{0x807d3aba469c1631, 0xcd6357bd7e2ef9e4, 0x2e519f1a33c10a11, 0x6ce72ba156e5bfe0, 0x3dac300dfe1daa42, 0x00d53a9250a7311e},
+			fe{0x2520623ce06f33e4, 0xd240806563c60560, 0x6fa4256475c80827, 0xf749de0174c8fcda, 0x715a93d0ec7ae605, 0x00b47c561b043d1f},
+		},
+		&fe2{
+			fe{0x590c9c6ad571f770, 0x9a8ef739bd34e9cb, 0xa941bac3bd17f06c, 0x8e6736417dcc5d12, 0xe43164e07df03acc, 0x01498f26432d9645},
+			fe{0x24542488214ba499, 0xbb191fa7d27771c8, 0xc7d4c58f0ce1c294, 0xce0656e845f283a4, 0xe4b1f1dcd4c45948, 0x00d086573d669541},
+		},
+		&fe2{
+			fe{0x22abb7c6f2179240, 0xf3ef3d95f17be9c3, 0xf6c78b2976fcd89b, 0x2744eeda0beae469, 0x14cbfe466cdf7db8, 0x00c04c2bc9effcad},
+			fe{0xbda15d56028deb36, 0xd75fe3f1840006bd, 0x602da22a7a73a128, 0x45e0d57406b677ee, 0xd1d022aaf9e9373b, 0x010ec84959d92dc4},
+		},
+		&fe2{
+			fe{0x20be8046516d37f7, 0xd1ea5dfa25c0a7e0, 0x795e279582af9cea, 0xe180a5f8f6130fe9, 0x51b59820acc7877a, 0x0022a948b97cb433},
+			fe{0x3f57d0fe5487a40f, 0x624cab84aabb0c65, 0xb05f0b77c5178e1d, 0x6cf2bfe6b9a68f08, 0xc4c2fef1109c59ce, 0x011b0585ebdb5fa4},
+		},
+		&fe2{
+			fe{0x20cc621579dca91a, 0x71d1bc579ee22936, 0x320da7006855e85b, 0x9d5132972aab61cd, 0x64748be947f62274, 0x0064cb0f1937a011},
+			fe{0xf6a6cf3d6b6fb79e, 0x60b63663aa109535, 0x082cd352704b7d81, 0xa52a9c6ed65ed5e5, 0xf2fa4b94bf631083, 0x01779de21c9e8340},
+		},
+		&fe2{
+			fe{0x653f93733cd9eb26, 0x13b3f632db6b13ca, 0xdcf35167c7e76c70, 0x835a64bc31ea6e0b, 0x3b0960853b0dfbb3, 0x00f5a65d300f2711},
+			fe{0x2057ad4d6655a4e4, 0x605989d35d0c688b, 0xe87f54463a4c4db4, 0x472136e7de5cb922, 0x0a522de04f9fe7b6, 0x0133056119d8fe1f},
+		},
+		&fe2{
+			fe{0x8bccdfbcff507e36, 0x6880b5ec2d288e62, 0xa8a0d8c33936eadc, 0xcbfdb2c0d4c14e1d, 0x32c1eaa7b1d6607c, 0x00b40ab5630f1524},
+			fe{0xe10f8481656dfd6d, 0xa91278bb5dfd3308, 0x842a78cfe90c6b47, 0xbc2ba0688a13756d, 0xc5f54a2dd66e4822, 0x013cbe2dadbc8a06},
+		},
+		&fe2{
+			fe{0x75f7ee686892aa52, 0xbaf37b17aca89de5, 0x058abf72d6fa7080, 0xd4a1e64bfebaab65, 0x1816d3d3240aacf9, 0x0121d87c012b204a},
+			fe{0x2a061e0fbeb29c33, 0x87264732a72f96ff, 0x002eb3ce3ba233ef, 0xd64293031ee73f3e, 0xd4c6491172e8a3f7, 0x012b85b58c2aa8b1},
+		},
+		&fe2{
+			fe{0x7a109a865b5b7d1a, 0x08ec59a0dd7eac5d, 0xae4d0301148ac7bd, 0xd64477891e3e06fd, 0x71b2ce6492231371, 0x0134951104ca22a9},
+			fe{0xbda5e243efeb0a21, 0x6018dbb945becb27, 0x79c17f67ed559915, 0x3cdd68361d6d5c75, 0xccb684fb4dca0d5a, 0x012b593332bf94e4},
+		},
+		&fe2{
+			fe{0x578d29076c1b56bc, 0xc9a2b69d87a4c01e, 0x37698120e1d166a0, 0xde4be745cf2bcd0e, 0xcf5dfc3d61454c6d, 0x006d25631b3aab26},
+			fe{0x77e8487503d62958, 0x887d48314fcd1521, 0x0af5ed0780a68857, 0xf39db576cdee2b40, 0x1e50410dbf199035, 0x01770f5310d187db},
+		},
+		&fe2{
+			fe{0x973351794a4c9a4e, 0x4eb4652c1c946aac, 0x86eb60fd791a5d63, 0x6091430b3ec31144, 0xfb04d25d98f84664, 0x01ac3252da472baf},
+			fe{0x44ed90aa3a73bc3e, 0xfc55e19bd7eb6908, 0x8c0fe30feb9d687a, 0x9dc100b7994d5576, 0x66f162839dc699b7, 0x014f44ef5d0e1b03},
+		},
+		&fe2{
+			fe{0x4f3db8b0c60624cd, 0x90174d1e2db946de, 0x2fee834c7b37666b, 0xdfe53d96c1bbf35e, 0x72ca54f1ff127e36, 0x0021e4531a24befd},
+			fe{0x17a9063d132af270, 0x7cd7dd027ef2a3af, 0x8ab4de1e20325ee0, 0x66cf38b0e8326a26, 0xfa9317fbbb1cd64e, 0x0183c713dde4c378},
+		},
+		&fe2{
+			fe{0x73fbaa31815430c6, 0x500272bce58f3f3a, 0x591e5e8c4189d6ff, 0x16e9cfa2e3b2f7c0, 0x11de31143376e2e0, 0x015fa1ad659d1802},
+			fe{0x701e2d89669adba3, 0x380c66f7b15ced91, 0x7b057467c162d049, 0xa64e3ddf99dc04db, 0x234398916e9ac744, 0x006479b9e0d92c33},
+		},
+		&fe2{
+			fe{0xbaf84b52e0bb5506, 0x073bc8f9bd933708, 0x4085ae2ce7268116, 0x5070d978e7db5f83, 0x788750c02945294f, 0x00b54e61c4abb28b},
+			fe{0x2f512f39e96c5c89, 0x8ccbdb104915df3a, 0x377432c9d57b3439, 0xd078cf9a9ec62b78, 0xd386f935ff2c1c5e, 0x0105ae9223786400},
+		},
+		&fe2{
+			fe{0x8797a788d2fa52c0, 0xf3852a2e84fa3826, 0xc26df61bbcef9e56, 0x39cf439d346f84d0, 0xebae913e6bb2b4d1, 0x00668488b782b8d0},
+			fe{0xd4317274bd608128, 0xf23b6ced48acec3a, 0x21e626bc78235063, 0xfb98201b07ffe07e, 0x3a1e67835364485c, 0x004f28a7859d2de5},
+		},
+		&fe2{
+			fe{0xb463401fbb3ba71c, 0x4fd440e06d950f54, 0x5db6d17409ac3b71, 0x2bf244168458d99d, 0x89cc619d2b099499, 0x006865c259674fa2},
+			fe{0x798507b0d4b6b356, 0x01fd7db5cb4d943d, 0x258b13be29ae95e0, 0x47888b8ca0640126, 0x57b014373624af3d, 0x00372e4aa8f40c61},
+		},
+		&fe2{
+			fe{0xfd46d4d733d2a2fb, 0x67e432dee24ccc42, 0x7930f6a2ecf9d7a0, 0x0657b5be873fdeb1, 0xa8dd05b036329d6e, 0x00480a552ca602c4},
 			fe{0, 0, 0, 0, 0, 0},
 		},
 	},
-	[4]*fe2{
+	[]*fe2{
 		&fe2{
-			fe{0x0162fffffa765adf, 0x8f7bea480083fb75, 0x561b3c2259e93611, 0x11e19fc1a9c875d5, 0xca713efc00367660, 0x03c6a03d41da1151},
-			fe{0x0162fffffa765adf, 0x8f7bea480083fb75, 0x561b3c2259e93611, 0x11e19fc1a9c875d5, 0xca713efc00367660, 0x03c6a03d41da1151},
+			fe{0x26843ba0de43fb66, 0x7d05542f700b2348, 0x0f4e3b8c200ee6cb, 0xa00afb9e3dc775d3, 0x514716732c9885b0, 0x01613fe4e74e8a09},
+			fe{0xd7d152116f65c042, 0xa12f8ac29d3b5fdd, 0xf07a25f93af3151f, 0xd7b9dcbd5af31169, 0x1499e815e2d98c72, 0x01501ad76b02e041},
 		},
 		&fe2{
-			fe{0, 0, 0, 0, 0, 0},
-			fe{0x5db0fffffd3b02c5, 0xd713f52358ebfdba, 0x5ea60761a84d161a, 0xbb2c75a34ea6c44a, 0x0ac6735921c1119b, 0x0ee3d913bdacfbf6},
+			fe{0xd7a3cb5955aa49d9, 0x985c69d35aa40eea, 0xd0f5154bad8057d0, 0x792eaf6d29da7112, 0x77a39699cdac7568, 0x0004cd58fc8f19cd},
+			fe{0x2c103c4ba2173693, 0x8144011ca4651dd5, 0xc31c05f30703165e, 0x22b38fc0a185f2db, 0xfc72b0ce70b82099, 0x00ae4a801ebf1368},
+		},
+		&fe2{
+			fe{0x1e366fb54ec3f8e8, 0xfb8735b6f0b2ad43, 0x8232d6d853c2c02d, 0x6fa9cf7601162d89, 0x89d2f851f3156661, 0x001c8d1491bde47c},
+			fe{0x1581ca89a029f977, 0xd8c351c0e4ec8caf, 0x82e369a01b352e20, 0x278d67a16a20809b, 0xf37f9d86da816a1b, 0x01023cabda2524a6},
+		},
+		&fe2{
+			fe{0xe5fbfea06c01e7de, 0xb5fd9c67231f94f1, 0xb2a4f0ba7cc4a8d1, 0x2d2d7397002d49b5, 0xece6c8831fd84eaa, 0x00462352cf001c4c},
+			fe{0xaf5b16036efa11af, 0xa96067b1e98b76d0, 0x72956098232ceaae, 0xabd69aaf3a517f21, 0x0860b923c3aad00f, 0x00d87548baf2c906},
+		},
+		&fe2{
+			fe{0xd7133b21c91febcf, 0xe9cdb8189f881319, 0x720d5e0b4dd0fcc1, 0xd5135d9013bcfa90, 0x583a2a2aa17e1930, 0x0184cb2a048bd634},
+			fe{0xedad4da01b0259a0, 0x7619ca119ed7da1d, 0x21a3cf97e3b2c7d3, 0x80d8c16edceaa744, 0xb292b70cce98bb69, 0x00a617536a95d669},
+		},
+		&fe2{
+			fe{0x610effcd760019f6, 0x8b1d648ee5a72761, 0x5c21d3f9bbe3381b, 0xf38ff28611aab644, 0xa62285f02012ede6, 0x000efce1573b68bd},
+			fe{0x3922c7745973a275, 0x6fc125c75e2f4c37, 0x1a2502049ce06ae4, 0x0bb47dfb17e852a6, 0x127d9bb6ec82a13c, 0x0164ee94f28ef102},
+		},
+		&fe2{
+			fe{0x8b363db67f029f14, 0x5c16e2696bbeefb6, 0x20b872e3e4dca842, 0xac279da68a3a333d, 0x292e2e8c99146852, 0x00bcc19aa92b4f99},
+			fe{0x819f539a6394ed7a, 0x79d7413329f2c402, 0xa82e35e6a2e9097e, 0x7a047a728fff53ee, 0xa2c90e96b76bbdb5, 0x01590624af8f5ecd},
+		},
+		&fe2{
+			fe{0x7265ef8b742568ab, 0x0af563217bf020eb, 0x83dd0438b589e5d0, 0xd4dff22d08aba342, 0x2a23c90caceddfb4, 0x0010c52415fe4e1d},
+			fe{0x570db932f5f67542, 0xfc4f27eb8d87e165, 0x8ca716045484ae9e, 0xb4801d27765f2f8b, 0xaf08aaee6ca6e263, 0x000e86e5a219e92e},
+		},
+		&fe2{
+			fe{0xae4bb1bafba0ae13, 0xb6ac35b36fad5988, 0x2464800ecb2099ea, 0xebb74c6f0952eb7a, 0x941a53c10dc99f7c, 0x004e89e5f7dd6b34},
+			fe{0x8f86568959397231, 0x387de5f7a63d672b, 0x7d9a27accdb026ce, 0x5e12f0466869f95d, 0xe365976236fb9e4d, 0x018933e489b689dd},
+		},
+		&fe2{
+			fe{0x7297434bbbf0302e, 0xa154f764955caf42, 0x39c3035e3cf2a054, 0xd79628e2f0e30abd, 0xd4f72f4ea99bb051, 0x00af0f1d7e5dc561},
+			fe{0xf23471c97176e4fa, 0x160fd72db908205f, 0xf41d4f79904f8023, 0x7243607aa907d470, 0x09f699683de94d26, 0x0097a0fb419e7aa7},
+		},
+		&fe2{
+			fe{0xedccc6eee1648bfb, 0xa2b89bc711a8b615, 0xe2dc437bb949782e, 0xb8e8227f2082cd91, 0x78c7ea2e255d5f1f, 0x01600032c0cce202},
+			fe{0x7eb5dc6b845d4a2f, 0xa6aeab1b35fb1868, 0x7eedc1b2a0d0acb5, 0xab3aae90a3e7225d, 0x7eca7bf000c980bb, 0x00449f8d6f7ffc55},
+		},
+		&fe2{
+			fe{0xbee33f2e933fad4a, 0x00512b02e515c77e, 0x05084ad5e4e9c33b, 0xec1a5b296aa44bc5, 0xc058c3d71e6e82b9, 0x0092459a5b4380a6},
+			fe{0xe0018f384222a459, 0xfece68dac4221710, 0xe1b5ca84af43ad24, 0xad1aa0577bc91c10, 0xfe79885fc99bdeb5, 0x00e267d150f62681},
+		},
+		&fe2{
+			fe{0x32c6af42ffebde1b, 0x12b42965d7a83f8e, 0x3735c568ef46a9e5, 0x46866998296bce1c, 0x1273d75ad08fa877, 0x00e3bc7fac5008a7},
+			fe{0x60de833869bd75dd, 0xa3826d3c61394153, 0x4af0be769ba0ec64, 0x88ad9828aca58ae3, 0x6ed94131205b2d5e, 0x0060556b6d6f09cc},
+		},
+		&fe2{
+			fe{0x05a9aaad09057da9, 0xfabf43f30b1797a9, 0x2988e22944db2c2f, 0x7a464bf051d87531, 0x37952d927878fed5, 0x005c9a03b3d06440},
+			fe{0x98501a17e486bd8a, 0xda170889b187e93d, 0x698230e6ccf9c332, 0xb34458e59499812e, 0x05de94ab501d7db3, 0x0080fba08bf74ea0},
+		},
+		&fe2{
+			fe{0x9014e9d08f1e25f7, 0xdb5e5836d27929b0, 0xfef69ce5bdc6c904, 0xf4d85184a5955013, 0x8f81593d1353fab1, 0x00801f966e1faa91},
+			fe{0xf95efaed71c5b330, 0x7f5e4912046dde19, 0x287e498c535cde2b, 0x6240ae7d5def18df, 0x0aec1d8dd620d325, 0x017c6a1e1dd4f6dc},
+		},
+		&fe2{
+			fe{0x5a3f2ad7a5beec8b, 0x8942b21c7f045a94, 0xacb81521d1173e1f, 0x55aa696d27ea0523, 0xe60ae6fb99e9d80c, 0x0014ce4eb78b85f9},
+			fe{0x3e204abbac715946, 0x3455a4812bab0a43, 0x06c98bfca629e540, 0x5a10a926ab9e4a24, 0x03e61df7e96b48ed, 0x00f7a80d43b827ce},
+		},
+		&fe2{
+			fe{0x4e211fafc11dc17b, 0x49c8503916743786, 0x6ec5d454d46b4cb6, 0xd7b6ae92831c3f52, 0x5275b614e351591e, 0x001244f439c8ff9a},
+			fe{0x2fc6406d055d1bf2, 0x55a15cd4315fd52c, 0x1d7625f8cf7116dc, 0xf1098c84b800cc7a, 0xa94f85ea3c733ab9, 0x001a9e21657a16b6},
+		},
+		&fe2{
+			fe{0xd0676e3d7e63881f, 0x2000dc175281686c, 0x5f40f07ce920f6da, 0x0ab834f1b5c1ed7d, 0x6d2c77ea09988e28, 0x01a87941b716192a},
+			fe{0x077c98198ec0eb28, 0x9438dfdf07387c3c, 0x2153d1dd5b78acef, 0x0ab5f3dcdaedcf95, 0xef9e3911ca320018, 0x00a425909fb889ac},
+		},
+		&fe2{
+			fe{0xa7a57066337f28ad, 0x54f4f4b8bcbc36bb, 0xb9aba37158eeffd7, 0xeb9f64743a1115fc, 0xb7092c649ce8a619, 0x006f38cde55d1a13},
+			fe{0x53bb96dfd271e398, 0x8d937f5c95fe9509, 0x33c306eb6f3faf8c, 0x2396ad1fa681a721, 0x093311c8e33abeb7, 0x016a5d73a3bcd928},
+		},
+		&fe2{
+			fe{0xce499bd33006a797, 0xe1c1998226c8eddb, 0xc429c00ac2bb06d3, 0x09e32c9b69ea31c4, 0xa7c56cddaf5fd892, 0x0082e2d859296931},
+			fe{0xb5a5b60aa03db81f, 0x15c30938b930771b, 0x3b28a7a4ca54c07e, 0x07f39cf1e7a7628a, 0xb5ff9c68ebc7a82f, 0x0000c93ec1bbb24c},
+		},
+		&fe2{
+			fe{0xd50e887e68a5e5d8, 0xcd0f6da943378fcd, 0xbffc50dc327fba1f, 0xdf8bff96d99a5fb7, 0x68f7aff4c48f1aa5, 0x01339a73732ccd12},
+			fe{0xe955ccd7b3934770, 0x2a8ba2599178c03f, 0x7c0cb0f44ebe88e6, 0x1f4ee801a74e32ca, 0x6f403d0b66c05d2f, 0x012a9db0ca469182},
+		},
+		&fe2{
+			fe{0xd8b68ec30374596b, 0x4e87cb6dbb1558c0, 0xc096ff9325627f5b, 0xd5dac6ece7dd1f33, 0x054eef47594cb3e5, 0x0075a3e07c91e1f1},
+			fe{0x444b50432d3a6cd7, 0x1d0bf7c1808ffd92, 0xa4946b019a3fd24c, 0xcdf4c4aa017670c0, 0x2c3a941d7ae51b8b, 0x002df8dff9b8f45b},
+		},
+		&fe2{
+			fe{0x93d92e35c10f041d, 0xb47eedce8d4cad1f, 0xb884d674c5f92fb8, 0xe8d6a6f1bdf69172, 0x072a4a6b42ae8044, 0x01978f35000bb895},
+			fe{0x0343fd257bf6a30b, 0xce3b54096efe5a56, 0x3f47ed6dfd6516b9, 0xe6ec165c1b470cf7, 0x800d6da2265d1dbd, 0x003885137d2f5a4a},
+		},
+		&fe2{
+			fe{0xf09c4f82baeb7884, 0x7729c895aa3323a2, 0x14ebfd344f4b06a9, 0xb0427bb1fbc8cc4c, 0xf967e9d1eeec6b01, 0x0180a192ac594d46},
+			fe{0xeb2a25ee32d33bac, 0xafb7e6c9cafb9b79, 0x3423963b9c5f2424, 0xd3e178fb9fcaae2d, 0x41e1f075c1d8ba9e, 0x007b2462b7acc8ef},
+		},
+		&fe2{
+			fe{0xe484a3ecbe44659e, 0xc62be97dd2ca6ef1, 0x1f44cac29f21f4f2, 0x6ee53b17780e9339, 0x62dfef3c51b70998, 0x014e7e7f6254d336},
+			fe{0xd49f9f966b13558e, 0xd1ddc65c0b3a3a5e, 0xf35491726a8f5b79, 0xe93725436839d3ee, 0x1636c39b2f1b63b9, 0x009e0c64a61b17d6},
+		},
+		&fe2{
+			fe{0x7b0e6b4e1c229f53, 0xfc8fef94cfa1fff1, 0x30cfbf14980605a7, 0x0ac303477b1e327d, 0x5170ae1d9a075fa0, 0x001604cb00b1bbea},
+			fe{0x872137aa92b09adc, 0x85a3e537a25da95c, 0xa07c92ee0c10b7f1, 0x46a09a4c013a80a8, 0xee8be90b98d3e471, 0x017b1ec30f46ed1b},
+		},
+		&fe2{
+			fe{0x8e895decc5a388e2, 0xb783a91ccad8ca11, 0x7d0b550362e04c35, 0xdcd33f92a2d78135, 0x5f051f2046e3af62, 0x013310aa17b633f9},
+			fe{0xd643897d11c14d8e, 0xfcb2dfa1ac80f904, 0x17512e01e023a2e4, 0xb6ea3ea1ec599ed2, 0x66f2444b856fea81, 0x000cfae75bddeb74},
+		},
+		&fe2{
+			fe{0x0fb25568f397dc78, 0x8ff8bae52717f497, 0x49319cdbe2fa7dac, 0x85d4eb906579f782, 0x73f2c8f242605685, 0x003be6acb831fb37},
+			fe{0x6958de0be1d1526b, 0x332d2dc50fab0915, 0xcede9d474894e5ad, 0x665d4ff062921cb4, 0x2ddce645ea675359, 0x008bf0d2be572f7d},
+		},
+		&fe2{
+			fe{0xd10532ec773378ba, 0xbf4308c1ff375be9, 0x1234e36300a13902, 0xbcf11fb35f79b0be, 0x25f32296aee2b85e, 0x0093abac648d68e4},
+			fe{0x1f6d7f9c337a282a, 0x67928c4e7e40f38c, 0x45080549f20d1021, 0xaf4dc90f5d6b782c, 0xbf12446a678f56df, 0x00f830bb9ca8cd75},
+		},
+		&fe2{
+			fe{0xb41a6ea96f7d2a33, 0x85355f19d4035ba8, 0xb09f886ef04e7722, 0xc0941502f76c5d34, 0xe831adc749311542, 0x00ba156f1bc1a3f5},
+			fe{0x874d23a90180117f, 0x832ee59e41c0f4bf, 0xf61f04ad7f915a5c, 0x53b9300fcbca5852, 0x35ffc6993edd38c0, 0x0098a498e1b6a700},
+		},
+		&fe2{
+			fe{0xd207469e30b3376d, 0x24c8dbb57ab8a88d, 0xfff85278b403ad3e, 0x8bc71d5e91c470f8, 0x1666e8d604e7f9be, 0x001692d5b724f3e1},
+			fe{0x11467c5a644ff0ea, 0xe988d130364bf03e, 0x66d2c682ccedbffd, 0x6cb4fde33e478833, 0xc397d489be2e5c65, 0x014672759a23f81b},
+		},
+		&fe2{
+			fe{0x305103ff6365f66f, 0x142db35652cd2efd, 0xfe42502da8d8286e, 0xa2abe5894b6dc85b, 0x94f072be915292f2, 0x00fd1a1742d0f654},
+			fe{0x1d0812a7b31b0350, 0x890c4f7e3681c53a, 0xaf2f45a5b803c5fc, 0x68e71228ae1e9804, 0x6a82275db162fc12, 0x010d2dcf52074082},
 		},
 		&fe2{
-			fe{0x66b10000003affc5, 0xcb1400e764ec0030, 0xa73e5eb56fa5d106, 0x8984c913a0fe09a9, 0x11e10afb78ad7f13, 0x05429d0e3e918f52},
-			fe{0x534dffffffc4aae6, 0x5397ff174c67ffcf, 0xbff273eb870b251d, 0xdaf2827152870915, 0x393a9cbaca9e2dc3, 0x14be74dbfaee5748},
+			fe{0x4c36a41bb023383c, 0x55cbf02ea76b9ccb, 0x15588b9e7db6e55f, 0x4560d00910c51bd4, 0x581901e3d0810a52, 0x00ac5fa504aec2c5},
+			fe{0xd43e854db7455642, 0x298153acf420728f, 0xb9d90019600668f2, 0x864047cd1b836bdd, 0x99e5bf7852ae694b, 0x00e603430e194fc6},
 		},
 		&fe2{
-			fe{0x760900000002fffd, 0xebf4000bc40c0002, 0x5f48985753c758ba, 0x77ce585370525745, 0x5c071a97a256ec6d, 0x15f65ec3fa80e493},
+			fe{0x02cdffffffffff68, 0x51409f837fffffb1, 0x9f7db3a98a7d3ff2, 0x7b4e97b76e7c6305, 0x4cf495bf803c84e8, 0x008d6661e2fdf49a},
 			fe{0, 0, 0, 0, 0, 0},
 		},
 	},
@@ -0,0 +1,74 @@
+package bls12377
+
+import (
+	"math"
+	"sync"
+)
+
+// multiExpWindowSize returns bucket window size for multi exponentiation of n points.
+func multiExpWindowSize(n int) uint {
+	if n < 32 {
+		return 3
+	}
+	c := uint(math.Ceil(math.Log(float64(n))))
+	if c > 16 {
+		c = 16
+	}
+	return c
+}
+
+// signedDigits recodes scalars into signed c bit digits in range [-2^(c-1), 2^(c-1)]
+// such that e_i = Σ d_ij * 2^(c*j). Result is indexed as digits[j][i].
+// Each bucket window is then served by 2^(c-1) buckets, negative digits add negated points.
+func signedDigits(scalars []*Fr, c uint) [][]int32 {
+	// one more window than needed for a 253 bit scalar keeps room for the last carry
+	windows := frBitSize/int(c) + 1
+	digits := make([][]int32, windows)
+	for j := 0; j < windows; j++ {
+		digits[j] = make([]int32, len(scalars))
+	}
+	mask := uint64(1)<<c - 1
+	half := int64(1) << (c - 1)
+	for i, e := range scalars {
+		var carry int64
+		for j := 0; j < windows; j++ {
+			d := int64(e.sliceUint64(j*int(c))&mask) + carry
+			carry = 0
+			if d >= half && j != windows-1 {
+				d -= 1 << c
+				carry = 1
+			}
+			digits[j][i] = int32(d)
+		}
+	}
+	return digits
+}
+
+// parallelize splits n jobs into contiguous ranges among given number of workers
+// and waits until all of them are done.
+func parallelize(n, workers int, work func(start, end int)) {
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > n {
+		workers = n
+	}
+	if workers <= 1 {
+		work(0, n)
+		return
+	}
+	var wg sync.WaitGroup
+	size := (n + workers - 1) / workers
+	for start := 0; start < n; start += size {
+		end := start + size
+		if end > n {
+			end = n
+		}
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			work(start, end)
+		}(start, end)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,53 @@
+package bls12377
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+func TestSignedDigits(t *testing.T) {
+	for c := uint(2); c <= 16; c++ {
+		scalars := make([]*Fr, fuz+2)
+		for i := 0; i < fuz; i++ {
+			scalars[i], _ = new(Fr).Rand(rand.Reader)
+		}
+		scalars[fuz] = new(Fr).Zero()
+		scalars[fuz+1] = new(Fr).Set(&q)
+		lsubAssignFR(scalars[fuz+1], &Fr{1})
+		digits := signedDigits(scalars, c)
+		half := int32(1) << (c - 1)
+		for i, e := range scalars {
+			acc := new(big.Int)
+			for j := len(digits) - 1; j >= 0; j-- {
+				d := digits[j][i]
+				if d > half || d < -half {
+					t.Fatal("digit out of range", c, d)
+				}
+				acc.Lsh(acc, c)
+				acc.Add(acc, big.NewInt(int64(d)))
+			}
+			if acc.Cmp(e.ToBig()) != 0 {
+				t.Fatal("signed digit recoding failed", c)
+			}
+		}
+	}
+}
+
+func TestParallelize(t *testing.T) {
+	for _, n := range []int{0, 1, 5, 64} {
+		for _, workers := range []int{-1, 0, 1, 2, 7, 100} {
+			seen := make([]int, n)
+			parallelize(n, workers, func(start, end int) {
+				for i := start; i < end; i++ {
+					seen[i]++
+				}
+			})
+			for i := range seen {
+				if seen[i] != 1 {
+					t.Fatal("each job must run once", n, workers)
+				}
+			}
+		}
+	}
+}
@@ -49,7 +49,8 @@ func swuMapG1(u *fe) (*fe, *fe) {
 }
 
 // swuMapG2 is implementation of Simplified Shallue-van de Woestijne-Ulas Method
-// defined at draft-irtf-cfrg-hash-to-curve-06.
+// defined at draft-irtf-cfrg-hash-to-curve-06. It maps to the curve 23-isogenous to BLS12-377 G2
+// with Z = 12 + u, as gnark-crypto does.
 func swuMapG2(e *fp2, u *fe2) (*fe2, *fe2) {
 	if e == nil {
 		e = newFp2()
@@ -91,7 +92,7 @@ func swuMapG2(e *fp2, u *fe2) (*fe2, *fe2) {
 		y2.set(gx2)
 	}
 	y := e.new()
-	e.sqrtBLST(y, y2)
+	e.sqrt(y, y2)
 	if y.sign() != u.sign() {
 		e.neg(y, y)
 	}
@@ -120,23 +121,23 @@ var swuParamsForG2 = struct {
 	minusBOverA *fe2
 }{
 	a: &fe2{
-		fe{0, 0, 0, 0, 0, 0},
-		fe{0xe53a000003135242, 0x01080c0fdef80285, 0xe7889edbe340f6bd, 0x0b51375126310601, 0x02d6985717c744ab, 0x1220b4e979ea5467},
+		fe{0x3b523c8cdcff9889, 0xc4780e8aecb49c4e, 0xb6213978038aee6f, 0x3dfa913b0bb775fa, 0x7dc49dcc25fe2b32, 0x013fd9f9381384b3},
+		fe{0x4037a99f9382f7cf, 0xcf59a5e803bc10f3, 0xc5fe6b60f3e4a2f9, 0x8e73810c455247e7, 0x8b82b6cf45af0adf, 0x018d59c91b4959e9},
 	},
 	b: &fe2{
-		fe{0x22ea00000cf89db2, 0x6ec832df71380aa4, 0x6e1b94403db5a66e, 0x75bf3c53a79473ba, 0x3dd3a569412c0a34, 0x125cdb5e74dc4fd1},
-		fe{0x22ea00000cf89db2, 0x6ec832df71380aa4, 0x6e1b94403db5a66e, 0x75bf3c53a79473ba, 0x3dd3a569412c0a34, 0x125cdb5e74dc4fd1},
+		fe{0x8e12acc352f1d411, 0x75a312f9c520c883, 0x5c2bd664a307a847, 0x17e419dbc89cc18e, 0x782239981ee6b04f, 0x008a8dd122f55fe5},
+		fe{0x20c316aeaae12d45, 0x9033a807ecda62f5, 0xad04281c8e9f799f, 0x721250e7ea917628, 0x2b735c35b0e9c073, 0x0128b6ff2b34c941},
 	},
 	z: &fe2{
-		fe{0x87ebfffffff9555c, 0x656fffe5da8ffffa, 0x0fd0749345d33ad2, 0xd951e663066576f4, 0xde291a3d41e980d3, 0x0815664c7dfe040d},
-		fe{0x43f5fffffffcaaae, 0x32b7fff2ed47fffd, 0x07e83a49a2e99d69, 0xeca8f3318332bb7a, 0xef148d1ea0f4c069, 0x040ab3263eff0206},
+		fe{0x928dbffffffff8dd, 0x89e5625d6ffffc4a, 0x1d0a45634fc3275b, 0x79468ec02af56996, 0x48c5f3b8bcf25f34, 0x01961dc45c98447b},
+		fe{0x02cdffffffffff68, 0x51409f837fffffb1, 0x9f7db3a98a7d3ff2, 0x7b4e97b76e7c6305, 0x4cf495bf803c84e8, 0x008d6661e2fdf49a},
 	},
 	zInv: &fe2{
-		fe{0xacd0000000011110, 0x9dd9999dc88ccccd, 0xb5ca2ac9b76352bf, 0xf1b574bcf4bc90ce, 0x42dab41f28a77081, 0x132fc6ac14cd1e12},
-		fe{0xe396ffffffff2223, 0x4fbf332fcd0d9998, 0x0c4bbd3c1aff4cc4, 0x6b9c91267926ca58, 0x29ae4da6aef7f496, 0x10692e942f195791},
+		fe{0xc82e8225cc74d519, 0x01b93ad162af3f98, 0x40c6d924b91309b9, 0xaab13b98afa41cab, 0xd9d9a085ef3bda1d, 0x012f57a5610a504c},
+		fe{0x26bfc527844b98e9, 0xf41ff74af671655e, 0x5cd4d6d0d36cf285, 0x275515d88718706d, 0x6b1bca5a59538c56, 0x0099fba4c1d1405b},
 	},
 	minusBOverA: &fe2{
-		fe{0x903c555555474fb3, 0x5f98cc95ce451105, 0x9f8e582eefe0fade, 0xc68946b6aebbd062, 0x467a4ad10ee6de53, 0x0e7146f483e23a05},
-		fe{0x29c2aaaaaab85af8, 0xbf133368e30eeefa, 0xc7a27a7206cffb45, 0x9dee04ce44c9425c, 0x04a15ce53464ce83, 0x0b8fcaf5b59dac95},
+		fe{0x317ff87b6deeb6e2, 0x7f0861b41ab657bd, 0xeeea77f89b5243a5, 0xc1eab34f183be51f, 0x12f2ac58662048a6, 0x005960b34092a1a3},
+		fe{0xe014d72a43028ea5, 0xf47d9728dba48b54, 0x338bb4cf5917ff18, 0xff0c1972f7789f2c, 0x6de030de58d000da, 0x00062a26650bb37b},
 	},
 }
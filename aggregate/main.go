@@ -2,47 +2,67 @@ package main
 
 import (
 	"fmt"
+	"log"
+
 	"github.com/consensys/gnark-crypto/ecc"
+	bls12377_ecc "github.com/consensys/gnark-crypto/ecc/bls12-377"
 	"github.com/consensys/gnark/backend/groth16"
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/std/algebra/native/sw_bls12377"
+
 	bls_tools "gnark/aggregate/bls-tools"
-	"log"
 )
 
+// Circuit verifies an AugSchemeMPL aggregate signature, public keys are in G1 and the
+// signature is in G2. Messages are hashed with the public keys of their signers outside.
 type Circuit struct {
-	publicKeys         [][]byte
-	messages           [][]byte
-	aggregateSignature []byte
+	Pk  []sw_bls12377.G1Affine `gnark:",public"`
+	Hm  []sw_bls12377.G2Affine `gnark:",public"`
+	Sig sw_bls12377.G2Affine
 }
 
 var asm = new(bls_tools.AugSchemeMPL)
 
-// Define e(sig,g2) * e(hm,pk) == 1
-func (circuit *Circuit) Define(api frontend.API) error {
-	fmt.Println("Define Starting")
-	signatureIsValid := AggregateVerify(circuit.publicKeys, circuit.messages, circuit.aggregateSignature)
-
-	result := 0
-	if signatureIsValid {
-		result = 1
+func newCircuit(n int) *Circuit {
+	return &Circuit{
+		Pk: make([]sw_bls12377.G1Affine, n),
+		Hm: make([]sw_bls12377.G2Affine, n),
 	}
-	api.AssertIsEqual(result, 1)
+}
 
-	if signatureIsValid {
-		fmt.Println("Aggregated signature is valid")
-	} else {
-		fmt.Println("Aggregated signature is invalid")
-	}
+// assign returns the assignment of an aggregate signature.
+func assign(a *bls_tools.AugSchemeAssignment) *Circuit {
+	return &Circuit{Pk: a.Pk, Hm: a.Hm, Sig: a.Sig}
+}
 
+// Define e(-g1, sig) * ∏ e(pk_i, hm_i) == 1
+func (circuit *Circuit) Define(api frontend.API) error {
+	_, _, g1, _ := bls12377_ecc.Generators()
+	g1.Neg(&g1)
+	var negG1 sw_bls12377.G1Affine
+	negG1.Assign(&g1)
+
+	P := append([]sw_bls12377.G1Affine{negG1}, circuit.Pk...)
+	Q := append([]sw_bls12377.G2Affine{circuit.Sig}, circuit.Hm...)
+	f, err := sw_bls12377.Pair(api, P, Q)
+	if err != nil {
+		return err
+	}
+	var one sw_bls12377.GT
+	one.SetOne()
+	f.AssertIsEqual(api, one)
 	return nil
 }
 
 const signatureNum = 1
 
 func demo1() {
-	circuit := new(Circuit)
-	var signatures [][]byte
+	var (
+		publicKeys [][]byte
+		messages   [][]byte
+		signatures [][]byte
+	)
 	for i := 1; i <= signatureNum; i++ {
 		message := []byte(fmt.Sprintf("message:%d", i))
 		privateKey := bls_tools.KeyGen(message)
@@ -51,8 +71,8 @@ func demo1() {
 		publicKey := privateKey.GetPublicKey()
 		signature := asm.Sign(privateKey, message)
 
-		circuit.messages = append(circuit.messages, message)
-		circuit.publicKeys = append(circuit.publicKeys, publicKey.Bytes())
+		messages = append(messages, message)
+		publicKeys = append(publicKeys, publicKey.Bytes())
 		signatures = append(signatures, signature)
 	}
 	// aggregate signature
@@ -62,7 +82,7 @@ func demo1() {
 	}
 
 	// Verify aggregate signature
-	signatureIsValid := asm.AggregateVerify(circuit.publicKeys, circuit.messages, aggregateSignature)
+	signatureIsValid := asm.AggregateVerify(publicKeys, messages, aggregateSignature)
 	if signatureIsValid {
 		fmt.Println("Aggregated signature is valid")
 	} else {
@@ -71,34 +91,31 @@ func demo1() {
 }
 
 func demo2() {
-	msgs, sigs, pubks, isErr := generateBatchTestData(signatureNum)
-	if isErr {
-		log.Panic("generateBatchTestData failed: ")
-	}
+	msgs, sigs, pubks := generateBatchTestData(signatureNum)
 
 	// Generate aggregate signature
-	AggregateSignature, err := asm.Aggregate(sigs...)
+	aggregateSignature, err := asm.Aggregate(sigs...)
 	if err != nil {
 		log.Panic("asm.Aggregate failed: ", err.Error())
 	}
-	circuit := Circuit{
-		messages:           msgs,
-		aggregateSignature: AggregateSignature,
-		publicKeys:         pubks,
+	a, err := bls_tools.NewAugSchemeAssignment(pubks, msgs, aggregateSignature)
+	if err != nil {
+		log.Panic("NewAugSchemeAssignment failed: ", err.Error())
 	}
 
-	//circuit := Circuit{}
-	ccs, _ := frontend.Compile(ecc.BW6_761.ScalarField(), r1cs.NewBuilder, &circuit)
+	ccs, err := frontend.Compile(ecc.BW6_761.ScalarField(), r1cs.NewBuilder, newCircuit(signatureNum))
+	if err != nil {
+		log.Panicf("Failed to compile err: %s", err)
+	}
 
 	// witness definition
-	witness, err := frontend.NewWitness(&circuit, ecc.BW6_761.ScalarField())
+	witness, err := frontend.NewWitness(assign(a), ecc.BW6_761.ScalarField())
 	if err != nil {
 		log.Panicf("Failed to create witness err: %s", err)
 	}
-	fmt.Printf("witness: %+v\n", witness)
 	publicWitness, _ := witness.Public()
 
-	// groth16 zkSNerrRK: Setup
+	// groth16 zkSNARK: Setup
 	pk, vk, err := groth16.Setup(ccs)
 	if err != nil {
 		log.Panicf("Failed to Setup err: %s", err)
@@ -114,18 +131,10 @@ func demo2() {
 	if err != nil {
 		log.Panic("Verify err: ", err)
 	}
-
+	fmt.Println("Aggregated signature is proven")
 }
 
-// [29068439691932164 5212125205240116882 5158703585069274468 10701178991839105062 4772007468162561125 9336940151432106134]
-// [9586122913090633729 1660523435060625408 2230234197602682880 1883307231910630287 14284016967150029115 121098312706494698]
 func main() {
 	demo1()
-	//demo2()
-
-	// fromBytes: &[18103045581585958587 7806400890582735599 11623291730934869080 14080658508445169925 2780237799254240271 1725392847304644500]
-	//p := bls_tools.G1Generator()
-	// fromBytes: &[18103045581585958587 7806400890582735599 11623291730934869080 14080658508445169925 2780237799254240271 1725392847304644500]
-	//
-	//fmt.Println(p)
+	demo2()
 }
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+
+	bls_tools "gnark/aggregate/bls-tools"
+	"gnark/internal/circuittest"
+)
+
+func TestCircuit(t *testing.T) {
+	assert := test.NewAssert(t)
+	msgs, sigs, pks := generateBatchTestData(2)
+	agg, err := asm.Aggregate(sigs...)
+	assert.NoError(err)
+	assignment := func(pks, msgs [][]byte, sig []byte) frontend.Circuit {
+		a, err := bls_tools.NewAugSchemeAssignment(pks, msgs, sig)
+		assert.NoError(err)
+		return assign(a)
+	}
+
+	cases := []circuittest.Case{
+		{Name: "valid", Witness: assignment(pks, msgs, agg), Valid: true},
+		{Name: "wrong message", Witness: assignment(pks, [][]byte{msgs[0], []byte("other message")}, agg), Valid: false},
+		{Name: "swapped signers", Witness: assignment([][]byte{pks[1], pks[0]}, msgs, agg), Valid: false},
+		{Name: "missing signature", Witness: assignment(pks, msgs, sigs[0]), Valid: false},
+	}
+	circuittest.Solve(assert, newCircuit(2), ecc.BW6_761, cases)
+	circuittest.CheckBackends(assert, newCircuit(2), ecc.BW6_761, cases, backend.GROTH16)
+}
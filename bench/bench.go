@@ -0,0 +1,245 @@
+// Package bench measures circuits package variants at several sizes: constraint count,
+// compile, setup and proving times, peak resident memory, proving key and proof sizes.
+//
+// cmd/blsbench writes reports of RunAll in CSV or JSON. The Go benchmarks of the package run
+// the same Compile, Setup and Prove stages.
+package bench
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"runtime"
+	"runtime/debug"
+	"time"
+
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+
+	"gnark/circuits"
+)
+
+// Variant is a circuit family measured at several sizes, Config.Size is ignored.
+type Variant struct {
+	Name   string
+	Config circuits.Config
+}
+
+// Variants are the circuits package equivalents of the example programs.
+var Variants = []Variant{
+	{"bn254/loop", circuits.Config{Curve: circuits.BN254, Scheme: circuits.Loop}},
+	{"bn254/aggregate", circuits.Config{Curve: circuits.BN254, Scheme: circuits.Aggregate}},
+	{"bls12381/bn254", circuits.Config{Curve: circuits.BLS12381InBN254, Scheme: circuits.Loop}},
+	{"bls12377/aggregate", circuits.Config{Curve: circuits.BLS12377InBW6761, Scheme: circuits.Aggregate}},
+}
+
+// Sizes are the default numbers of signatures.
+var Sizes = []int{1, 2, 8, 64, 128}
+
+// VariantByName returns the variant of given name.
+func VariantByName(name string) (Variant, error) {
+	for _, v := range Variants {
+		if v.Name == name {
+			return v, nil
+		}
+	}
+	return Variant{}, fmt.Errorf("unknown variant %q", name)
+}
+
+// At returns the circuit configuration of the variant for size signatures.
+func (v Variant) At(size int) circuits.Config {
+	c := v.Config
+	c.Size = size
+	return c
+}
+
+// Circuit is a compiled variant with its keys and witness, filled stage by stage.
+type Circuit struct {
+	Config  circuits.Config
+	CS      constraint.ConstraintSystem
+	PK      groth16.ProvingKey
+	VK      groth16.VerifyingKey
+	Witness witness.Witness
+}
+
+// Compile compiles the circuit of the configuration.
+func Compile(c circuits.Config) (*Circuit, error) {
+	circuit, err := circuits.New(c)
+	if err != nil {
+		return nil, err
+	}
+	cs, err := frontend.Compile(c.Curve.Field(), r1cs.NewBuilder, circuit)
+	if err != nil {
+		return nil, err
+	}
+	return &Circuit{Config: c, CS: cs}, nil
+}
+
+// Setup runs the Groth16 setup of the circuit.
+func (c *Circuit) Setup() (err error) {
+	c.PK, c.VK, err = groth16.Setup(c.CS)
+	return err
+}
+
+// Assign sets the witness from signatures of fresh keys derived from seed.
+func (c *Circuit) Assign(seed int64) error {
+	in, err := signInput(c.Config, rand.New(rand.NewSource(seed)))
+	if err != nil {
+		return err
+	}
+	assignment, err := circuits.Assign(c.Config, in)
+	if err != nil {
+		return err
+	}
+	c.Witness, err = frontend.NewWitness(assignment, c.Config.Curve.Field())
+	return err
+}
+
+// Prove proves the witness and verifies the proof.
+func (c *Circuit) Prove() (groth16.Proof, error) {
+	if c.PK == nil || c.Witness == nil {
+		return nil, errors.New("circuit is not set up and assigned")
+	}
+	proof, err := groth16.Prove(c.CS, c.PK, c.Witness)
+	if err != nil {
+		return nil, err
+	}
+	public, err := c.Witness.Public()
+	if err != nil {
+		return nil, err
+	}
+	if err := groth16.Verify(proof, c.VK, public); err != nil {
+		return nil, fmt.Errorf("proof does not verify: %w", err)
+	}
+	return proof, nil
+}
+
+// Options of a run.
+type Options struct {
+	// CompileOnly skips setup and proving.
+	CompileOnly bool
+	// MaxConstraints skips setup and proving of larger circuits, and compilation of sizes
+	// whose constraint count extrapolated from the smaller sizes of the variant exceeds it.
+	// Zero is no limit.
+	MaxConstraints int
+	// Seed derives the signing keys.
+	Seed int64
+}
+
+// Result holds the measures of a variant at one size. Stages that did not run are zero.
+// PeakRSS is the peak resident memory of the process during the run, it is zero where
+// the platform does not report it.
+type Result struct {
+	Variant        string        `json:"variant"`
+	Curve          string        `json:"curve"`
+	Scheme         string        `json:"scheme"`
+	Size           int           `json:"size"`
+	Constraints    int           `json:"constraints"`
+	PublicInputs   int           `json:"publicInputs"`
+	CompileTime    time.Duration `json:"compileTimeNs"`
+	SetupTime      time.Duration `json:"setupTimeNs"`
+	ProveTime      time.Duration `json:"proveTimeNs"`
+	PeakRSS        uint64        `json:"peakRssBytes"`
+	ProvingKeySize int64         `json:"provingKeyBytes"`
+	ProofSize      int64         `json:"proofBytes"`
+	Skipped        string        `json:"skipped,omitempty"`
+	Error          string        `json:"error,omitempty"`
+}
+
+// Run measures the variant at given size. Failures are reported in the result.
+func Run(v Variant, size int, opts Options) Result {
+	c := v.At(size)
+	r := Result{Variant: v.Name, Curve: c.Curve.String(), Scheme: c.Scheme.String(), Size: size}
+	runtime.GC()
+	debug.FreeOSMemory()
+	resetPeakRSS()
+	if err := run(&r, c, opts); err != nil {
+		r.Error = err.Error()
+	}
+	r.PeakRSS = peakRSS()
+	return r
+}
+
+func run(r *Result, c circuits.Config, opts Options) error {
+	start := time.Now()
+	circuit, err := Compile(c)
+	if err != nil {
+		return err
+	}
+	r.CompileTime = time.Since(start)
+	r.Constraints = circuit.CS.GetNbConstraints()
+	r.PublicInputs = circuit.CS.GetNbPublicVariables() - 1
+	switch {
+	case opts.CompileOnly:
+		r.Skipped = "setup and proving"
+		return nil
+	case opts.MaxConstraints > 0 && r.Constraints > opts.MaxConstraints:
+		r.Skipped = fmt.Sprintf("setup and proving, over %d constraints", opts.MaxConstraints)
+		return nil
+	}
+
+	start = time.Now()
+	if err := circuit.Setup(); err != nil {
+		return err
+	}
+	r.SetupTime = time.Since(start)
+	if r.ProvingKeySize, err = circuit.PK.WriteTo(io.Discard); err != nil {
+		return err
+	}
+	if err := circuit.Assign(opts.Seed); err != nil {
+		return err
+	}
+	start = time.Now()
+	proof, err := circuit.Prove()
+	if err != nil {
+		return err
+	}
+	r.ProveTime = time.Since(start)
+	r.ProofSize, err = proof.WriteTo(io.Discard)
+	return err
+}
+
+// RunAll measures each variant at each size in increasing order, progress is called with
+// each result when not nil.
+func RunAll(variants []Variant, sizes []int, opts Options, progress func(Result)) []Result {
+	var results []Result
+	for _, v := range variants {
+		var done []Result
+		for _, size := range sizes {
+			var r Result
+			if est := extrapolate(done, size); opts.MaxConstraints > 0 && est > opts.MaxConstraints {
+				c := v.At(size)
+				r = Result{Variant: v.Name, Curve: c.Curve.String(), Scheme: c.Scheme.String(), Size: size}
+				r.Skipped = fmt.Sprintf("compilation, about %d constraints", est)
+			} else {
+				r = Run(v, size, opts)
+			}
+			if r.Constraints > 0 {
+				done = append(done, r)
+			}
+			if progress != nil {
+				progress(r)
+			}
+			results = append(results, r)
+		}
+	}
+	return results
+}
+
+// extrapolate estimates the constraint count at size from the last two compiled sizes, or
+// proportionally from a single one. It returns 0 without compiled sizes.
+func extrapolate(done []Result, size int) int {
+	switch n := len(done); {
+	case n == 0:
+		return 0
+	case n == 1 || done[n-1].Size == done[n-2].Size:
+		return done[n-1].Constraints * size / done[n-1].Size
+	default:
+		a, b := done[n-2], done[n-1]
+		return b.Constraints + (b.Constraints-a.Constraints)*(size-b.Size)/(b.Size-a.Size)
+	}
+}
@@ -0,0 +1,133 @@
+package bench
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/consensys/gnark/test"
+)
+
+func TestRun(t *testing.T) {
+	assert := test.NewAssert(t)
+	v, err := VariantByName("bls12377/aggregate")
+	assert.NoError(err)
+	r := Run(v, 2, Options{Seed: 1})
+	assert.Equal("", r.Error)
+	assert.Equal("", r.Skipped)
+	assert.True(r.Constraints > 0)
+	assert.True(r.ProvingKeySize > 0)
+	assert.True(r.ProofSize > 0)
+	assert.True(r.ProveTime > 0)
+
+	r = Run(v, 2, Options{MaxConstraints: 1})
+	assert.Equal("", r.Error)
+	assert.NotEqual("", r.Skipped)
+	assert.Equal(int64(0), r.ProvingKeySize)
+}
+
+func TestRunAll(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping emulated pairing circuits in short mode")
+	}
+	assert := test.NewAssert(t)
+	results := RunAll(Variants, []int{1, 2}, Options{CompileOnly: true}, nil)
+	assert.Equal(2*len(Variants), len(results))
+	for _, r := range results {
+		assert.Equal("", r.Error, r.Variant)
+		assert.True(r.Constraints > 0, r.Variant)
+	}
+}
+
+func TestExtrapolate(t *testing.T) {
+	assert := test.NewAssert(t)
+	assert.Equal(0, extrapolate(nil, 8))
+	assert.Equal(400, extrapolate([]Result{{Size: 2, Constraints: 100}}, 8))
+	assert.Equal(800, extrapolate([]Result{{Size: 1, Constraints: 100}, {Size: 2, Constraints: 200}}, 8))
+	assert.Equal(6500, extrapolate([]Result{{Size: 1, Constraints: 1000}, {Size: 2, Constraints: 1500}}, 12))
+}
+
+func TestWrite(t *testing.T) {
+	assert := test.NewAssert(t)
+	results := []Result{
+		{Variant: "bn254/loop", Curve: "bn254", Scheme: "loop", Size: 1, Constraints: 10},
+		{Variant: "bn254/loop", Curve: "bn254", Scheme: "loop", Size: 2, Skipped: "compilation"},
+	}
+	var buf bytes.Buffer
+	assert.NoError(WriteCSV(&buf, results))
+	records, err := csv.NewReader(&buf).ReadAll()
+	assert.NoError(err)
+	assert.Equal(3, len(records))
+	assert.Equal(csvHeader, records[0])
+	assert.Equal("compilation", records[2][12])
+
+	buf.Reset()
+	assert.NoError(WriteJSON(&buf, results))
+	var decoded []Result
+	assert.NoError(json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(results, decoded)
+}
+
+// benchmarkSizes are the sizes of the Go benchmarks, emulated pairings make larger sizes
+// a matter of cmd/blsbench.
+var benchmarkSizes = []int{1, 2, 8}
+
+func benchmarkVariants(b *testing.B, stage func(b *testing.B, v Variant, size int)) {
+	for _, v := range Variants {
+		for _, size := range benchmarkSizes {
+			v, size := v, size
+			b.Run(fmt.Sprintf("%s/%d", v.Name, size), func(b *testing.B) {
+				stage(b, v, size)
+			})
+		}
+	}
+}
+
+func compile(b *testing.B, v Variant, size int) *Circuit {
+	c, err := Compile(v.At(size))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportMetric(float64(c.CS.GetNbConstraints()), "constraints")
+	return c
+}
+
+func BenchmarkCompile(b *testing.B) {
+	benchmarkVariants(b, func(b *testing.B, v Variant, size int) {
+		for i := 0; i < b.N; i++ {
+			compile(b, v, size)
+		}
+	})
+}
+
+func BenchmarkSetup(b *testing.B) {
+	benchmarkVariants(b, func(b *testing.B, v Variant, size int) {
+		c := compile(b, v, size)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if err := c.Setup(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkProve(b *testing.B) {
+	benchmarkVariants(b, func(b *testing.B, v Variant, size int) {
+		c := compile(b, v, size)
+		if err := c.Setup(); err != nil {
+			b.Fatal(err)
+		}
+		if err := c.Assign(1); err != nil {
+			b.Fatal(err)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if _, err := c.Prove(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,60 @@
+package bench
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377"
+	bls12377fr "github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381"
+	bls12381fr "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	bn254fr "github.com/consensys/gnark-crypto/ecc/bn254/fr"
+
+	"gnark/circuits"
+)
+
+// signInput returns c.Size signatures by keys drawn from rng, over one message for the fast
+// aggregate scheme and distinct messages otherwise.
+func signInput(c circuits.Config, rng *rand.Rand) (*circuits.Input, error) {
+	in := &circuits.Input{}
+	for i := 0; i < c.Size; i++ {
+		msg := []byte(fmt.Sprintf("message %d", i))
+		if c.Scheme == circuits.FastAggregate {
+			msg = []byte("message")
+		}
+		var pk, sig []byte
+		switch c.Curve {
+		case circuits.BN254:
+			sk := new(big.Int).Rand(rng, bn254fr.Modulus())
+			_, _, _, g2 := bn254.Generators()
+			hm, err := bn254.HashToG1(msg, c.Curve.DST())
+			if err != nil {
+				return nil, err
+			}
+			pk = new(bn254.G2Affine).ScalarMultiplication(&g2, sk).Marshal()
+			sig = new(bn254.G1Affine).ScalarMultiplication(&hm, sk).Marshal()
+		case circuits.BLS12381InBN254:
+			sk := new(big.Int).Rand(rng, bls12381fr.Modulus())
+			_, _, _, g2 := bls12381.Generators()
+			hm, err := bls12381.HashToG1(msg, c.Curve.DST())
+			if err != nil {
+				return nil, err
+			}
+			pk = new(bls12381.G2Affine).ScalarMultiplication(&g2, sk).Marshal()
+			sig = new(bls12381.G1Affine).ScalarMultiplication(&hm, sk).Marshal()
+		default:
+			sk := new(big.Int).Rand(rng, bls12377fr.Modulus())
+			_, _, _, g2 := bls12377.Generators()
+			hm, err := bls12377.HashToG1(msg, c.Curve.DST())
+			if err != nil {
+				return nil, err
+			}
+			pk = new(bls12377.G2Affine).ScalarMultiplication(&g2, sk).Marshal()
+			sig = new(bls12377.G1Affine).ScalarMultiplication(&hm, sk).Marshal()
+		}
+		in.Signatures = append(in.Signatures, circuits.SignedMessage{PublicKey: *circuits.NewPoint(pk), Message: msg, Signature: circuits.NewPoint(sig)})
+	}
+	return in, nil
+}
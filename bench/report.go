@@ -0,0 +1,49 @@
+package bench
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+)
+
+var csvHeader = []string{
+	"variant", "curve", "scheme", "size", "constraints", "public_inputs",
+	"compile_s", "setup_s", "prove_s", "peak_rss_bytes", "proving_key_bytes", "proof_bytes",
+	"skipped", "error",
+}
+
+// WriteCSV writes results with a header line, durations in seconds.
+func WriteCSV(w io.Writer, results []Result) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	seconds := func(d time.Duration) string {
+		return strconv.FormatFloat(d.Seconds(), 'f', 3, 64)
+	}
+	for _, r := range results {
+		err := cw.Write([]string{
+			r.Variant, r.Curve, r.Scheme, strconv.Itoa(r.Size), strconv.Itoa(r.Constraints), strconv.Itoa(r.PublicInputs),
+			seconds(r.CompileTime), seconds(r.SetupTime), seconds(r.ProveTime),
+			strconv.FormatUint(r.PeakRSS, 10), strconv.FormatInt(r.ProvingKeySize, 10), strconv.FormatInt(r.ProofSize, 10),
+			r.Skipped, r.Error,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSON writes results as an indented JSON array, durations in nanoseconds.
+func WriteJSON(w io.Writer, results []Result) error {
+	if results == nil {
+		results = []Result{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(results)
+}
@@ -0,0 +1,10 @@
+//go:build !linux
+
+package bench
+
+func resetPeakRSS() {}
+
+// peakRSS is not reported outside of Linux.
+func peakRSS() uint64 {
+	return 0
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12377_ecc "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark/test"
+
+	bls_tools "gnark/aggregate/bls-tools"
+	"gnark/internal/circuittest"
+)
+
+// otherDST is a domain separation tag the circuit messages are not hashed with.
+var otherDST = bls_tools.BLS12377G1.Ciphersuite().WithScheme(bls_tools.POP).DST()
+
+func assign(sig *bls12377_ecc.G1Affine, hm []bls12377_ecc.G1Affine, pk []bls12377_ecc.G2Affine) *BlsCircuit {
+	var w BlsCircuit
+	w.Sig.Assign(sig)
+	w.G2.Assign(&g2Gen)
+	for k := range w.Hm {
+		w.Hm[k].Assign(&hm[k])
+		w.Pk[k].Assign(&pk[k])
+	}
+	return &w
+}
+
+// replace returns a copy of s with s[i] set to v.
+func replace[T any](s []T, i int, v T) []T {
+	c := append([]T(nil), s...)
+	c[i] = v
+	return c
+}
+
+func TestCircuit(t *testing.T) {
+	assert := test.NewAssert(t)
+	privateKeys, publicKeys, err := BatchGenerateKeyPairs(SignatureNum)
+	assert.NoError(err)
+	_, otherKey, err := GenerateKeyPair()
+	assert.NoError(err)
+
+	hm := make([]bls12377_ecc.G1Affine, SignatureNum)
+	pk := make([]bls12377_ecc.G2Affine, SignatureNum)
+	var sig bls12377_ecc.G1Affine
+	for k, v := range privateKeys {
+		hm[k] = *hashToG1([]byte(fmt.Sprintf("Signature_%d", k+1)))
+		pk[k] = *publicKeys[k].P
+		var s bls12377_ecc.G1Affine
+		s.ScalarMultiplication(&hm[k], v.X)
+		sig.Add(&sig, &s)
+	}
+
+	otherHm := hashToG1([]byte("Other Signature"))
+	swapped := replace(replace(pk, 0, pk[1]), 1, pk[0])
+	offCurveSig := sig
+	offCurveSig.Y.Double(&offCurveSig.Y)
+	// first signature over the first message hashed with another tag
+	otherDSTHm, err := bls12377_ecc.HashToG1([]byte("Signature_1"), otherDST)
+	assert.NoError(err)
+	var otherDSTSig, s bls12377_ecc.G1Affine
+	otherDSTSig.ScalarMultiplication(&hm[0], privateKeys[0].X)
+	otherDSTSig.Sub(&sig, &otherDSTSig)
+	s.ScalarMultiplication(&otherDSTHm, privateKeys[0].X)
+	otherDSTSig.Add(&otherDSTSig, &s)
+
+	cases := []circuittest.Case{
+		{Name: "valid", Witness: assign(&sig, hm, pk), Valid: true},
+		{Name: "wrong message", Witness: assign(&sig, replace(hm, 0, *otherHm), pk), Valid: false},
+		{Name: "wrong key", Witness: assign(&sig, hm, replace(pk, 0, *otherKey.P)), Valid: false},
+		{Name: "swapped signers", Witness: assign(&sig, hm, swapped), Valid: false},
+		{Name: "identity signature", Witness: assign(&bls12377_ecc.G1Affine{}, hm, pk), Valid: false},
+		{Name: "off-curve signature", Witness: assign(&offCurveSig, hm, pk), Valid: false},
+		{Name: "other DST", Witness: assign(&otherDSTSig, hm, pk), Valid: false},
+	}
+	circuittest.Solve(assert, &BlsCircuit{}, ecc.BW6_761, cases)
+	// every backend solves the witnesses, or proves them with the prover_checks build tag
+	circuittest.CheckBackends(assert, &BlsCircuit{}, ecc.BW6_761, cases)
+}
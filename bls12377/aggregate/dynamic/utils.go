@@ -8,12 +8,14 @@ import (
 
 	bls12_377_ecc "github.com/consensys/gnark-crypto/ecc/bls12-377"
 	bls12_377_fr "github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+
+	bls_tools "gnark/aggregate/bls-tools"
 )
 
 var (
 	g1Gen bls12_377_ecc.G1Affine
 	g2Gen bls12_377_ecc.G2Affine
-	dst   = []byte("BLS12_377_ECC_HASH")
+	dst   = bls_tools.BLS12377G1.Ciphersuite().DST()
 )
 
 type PrivateKey struct {
@@ -95,7 +97,7 @@ func Sign(privateKey *PrivateKey, Hm *bls12_377_ecc.G1Affine) (blsSignature *bls
 
 //func Sign(privateKey *PrivateKey, msg []byte) (blsSignature *bls12_377_ecc.G1Affine, err error) {
 //
-//	hashPointG1, _ := bls12_377_ecc.HashToG1(msg, g1Gen.Marshal())
+//	hashPointG1, _ := bls12_377_ecc.HashToG1(msg, dst)
 //
 //	sig := new(bls12_377_ecc.G1Affine).ScalarMultiplication(&hashPointG1, privateKey.X)
 //
@@ -116,7 +118,7 @@ func Verify(publicKey *PublicKey, sig, msg []byte) (bool, error) {
 	}
 
 	// e(P, H(m)) = e(H(m), P)
-	hashPointG1, _ := bls12_377_ecc.HashToG1(msg, g1Gen.Marshal())
+	hashPointG1, _ := bls12_377_ecc.HashToG1(msg, dst)
 	rp, err := bls12_377_ecc.Pair([]bls12_377_ecc.G1Affine{hashPointG1}, []bls12_377_ecc.G2Affine{*publicKey.P})
 	if err != nil {
 		return false, err
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12377_ecc "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark/frontend"
+	bls12377 "github.com/consensys/gnark/std/algebra/native/sw_bls12377"
+	"github.com/consensys/gnark/test"
+
+	bls_tools "gnark/aggregate/bls-tools"
+	"gnark/internal/circuittest"
+)
+
+// otherDST is a domain separation tag the circuit messages are not hashed with.
+var otherDST = bls_tools.BLS12377G1.Ciphersuite().WithScheme(bls_tools.POP).DST()
+
+// assign sets the fields of a BlsCircuit64 or BlsCircuit128, Hm1 and Pk1 to hm[0] and pk[0]
+// and so on.
+func assign(w frontend.Circuit, sig *bls12377_ecc.G1Affine, hm []bls12377_ecc.G1Affine, pk []bls12377_ecc.G2Affine) frontend.Circuit {
+	v := reflect.ValueOf(w).Elem()
+	v.FieldByName("Sig").Addr().Interface().(*bls12377.G1Affine).Assign(sig)
+	v.FieldByName("G2").Addr().Interface().(*bls12377.G2Affine).Assign(&g2Gen)
+	for k := range hm {
+		v.FieldByName(fmt.Sprintf("Hm%d", k+1)).Addr().Interface().(*bls12377.G1Affine).Assign(&hm[k])
+		v.FieldByName(fmt.Sprintf("Pk%d", k+1)).Addr().Interface().(*bls12377.G2Affine).Assign(&pk[k])
+	}
+	return w
+}
+
+// replace returns a copy of s with s[i] set to v.
+func replace[T any](s []T, i int, v T) []T {
+	c := append([]T(nil), s...)
+	c[i] = v
+	return c
+}
+
+func testCircuit(t *testing.T, n int, newCircuit func() frontend.Circuit) {
+	assert := test.NewAssert(t)
+	privateKeys, publicKeys, err := BatchGenerateKeyPairs(n)
+	assert.NoError(err)
+	_, otherKey, err := GenerateKeyPair()
+	assert.NoError(err)
+
+	hm := make([]bls12377_ecc.G1Affine, n)
+	pk := make([]bls12377_ecc.G2Affine, n)
+	var sig bls12377_ecc.G1Affine
+	for k, v := range privateKeys {
+		hm[k] = *hashToG1([]byte(fmt.Sprintf("Signature_%d", k+1)))
+		pk[k] = *publicKeys[k].P
+		var s bls12377_ecc.G1Affine
+		s.ScalarMultiplication(&hm[k], v.X)
+		sig.Add(&sig, &s)
+	}
+
+	otherHm := hashToG1([]byte("Other Signature"))
+	swapped := replace(replace(pk, 0, pk[1]), 1, pk[0])
+	offCurveSig := sig
+	offCurveSig.Y.Double(&offCurveSig.Y)
+	// first signature over the first message hashed with another tag
+	otherDSTHm, err := bls12377_ecc.HashToG1([]byte("Signature_1"), otherDST)
+	assert.NoError(err)
+	var otherDSTSig, s bls12377_ecc.G1Affine
+	otherDSTSig.ScalarMultiplication(&hm[0], privateKeys[0].X)
+	otherDSTSig.Sub(&sig, &otherDSTSig)
+	s.ScalarMultiplication(&otherDSTHm, privateKeys[0].X)
+	otherDSTSig.Add(&otherDSTSig, &s)
+
+	cases := []circuittest.Case{
+		{Name: "valid", Witness: assign(newCircuit(), &sig, hm, pk), Valid: true},
+		{Name: "wrong message", Witness: assign(newCircuit(), &sig, replace(hm, 0, *otherHm), pk), Valid: false},
+		{Name: "wrong key", Witness: assign(newCircuit(), &sig, hm, replace(pk, 0, *otherKey.P)), Valid: false},
+		{Name: "swapped signers", Witness: assign(newCircuit(), &sig, hm, swapped), Valid: false},
+		{Name: "identity signature", Witness: assign(newCircuit(), &bls12377_ecc.G1Affine{}, hm, pk), Valid: false},
+		{Name: "off-curve signature", Witness: assign(newCircuit(), &offCurveSig, hm, pk), Valid: false},
+		{Name: "other DST", Witness: assign(newCircuit(), &otherDSTSig, hm, pk), Valid: false},
+	}
+	circuittest.Solve(assert, newCircuit(), ecc.BW6_761, cases)
+	// every backend solves the witnesses, or proves them with the prover_checks build tag
+	circuittest.CheckBackends(assert, newCircuit(), ecc.BW6_761, cases)
+}
+
+func TestCircuit64(t *testing.T) {
+	testCircuit(t, 64, func() frontend.Circuit { return &BlsCircuit64{} })
+}
+
+func TestCircuit128(t *testing.T) {
+	testCircuit(t, 128, func() frontend.Circuit { return &BlsCircuit128{} })
+}
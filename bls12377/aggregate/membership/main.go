@@ -0,0 +1,98 @@
+// Command membership proves an aggregate signature of a few signers of a large validator set,
+// each signer key being proven to be a leaf of the validator set Merkle tree.
+package main
+
+import (
+	"crypto/rand"
+	"fmt"
+	"log"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12377_ecc "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	bls12377_fr "github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+
+	bls_tools "gnark/aggregate/bls-tools"
+	"gnark/bls12377/valset"
+)
+
+const (
+	ValidatorNum = 10000
+	TreeDepth    = 14
+	SignatureNum = 8
+)
+
+var dst = bls_tools.BLS12377G1.Ciphersuite().DST()
+
+func main() {
+	_, _, _, g2Gen := bls12377_ecc.Generators()
+	sks := make([]*big.Int, ValidatorNum)
+	validators := make([]valset.Validator, ValidatorNum)
+	for i := range sks {
+		sk, err := rand.Int(rand.Reader, bls12377_fr.Modulus())
+		if err != nil {
+			log.Panicf("GenerateKeyPair failed: %s", err)
+		}
+		sks[i] = sk
+		validators[i].PublicKey.ScalarMultiplication(&g2Gen, sk)
+		validators[i].Weight = 1
+	}
+	tree, err := valset.Build(TreeDepth, validators)
+	if err != nil {
+		log.Panicf("Build validator set err: %s", err)
+	}
+	root := tree.Root()
+	fmt.Printf("validator set of %d keys, root %s\n", ValidatorNum, root.String())
+
+	// every ValidatorNum/SignatureNum-th validator signs its own message
+	indices := make([]int, SignatureNum)
+	hms := make([]bls12377_ecc.G1Affine, SignatureNum)
+	signers := make([]valset.Validator, SignatureNum)
+	var signature bls12377_ecc.G1Affine
+	for k := range indices {
+		indices[k] = k * (ValidatorNum / SignatureNum)
+		hm, err := bls12377_ecc.HashToG1([]byte(fmt.Sprintf("Signature_%d", k+1)), dst)
+		if err != nil {
+			log.Panicf("HashToG1 err: %s", err)
+		}
+		hms[k] = hm
+		signers[k] = validators[indices[k]]
+		var sig bls12377_ecc.G1Affine
+		sig.ScalarMultiplication(&hm, sks[indices[k]])
+		signature.Add(&signature, &sig)
+	}
+
+	circuit := valset.NewAggregateCircuit(SignatureNum, TreeDepth)
+	ccs, err := frontend.Compile(ecc.BW6_761.ScalarField(), r1cs.NewBuilder, circuit)
+	if err != nil {
+		log.Panicf("Compile err: %s", err)
+	}
+	assignment, err := tree.AssignAggregate(&signature, hms, signers, indices)
+	if err != nil {
+		log.Panicf("AssignAggregate err: %s", err)
+	}
+
+	pk, vk, err := groth16.Setup(ccs)
+	if err != nil {
+		log.Panicf("Failed to Setup err: %s", err)
+	}
+	witness, err := frontend.NewWitness(assignment, ecc.BW6_761.ScalarField())
+	if err != nil {
+		log.Panicf("Failed to create witness err: %s", err)
+	}
+	publicWitness, err := witness.Public()
+	if err != nil {
+		log.Panicf("Failed to create witness Public err: %s", err)
+	}
+	proof, err := groth16.Prove(ccs, pk, witness)
+	if err != nil {
+		log.Panicf("Prove err: %s", err)
+	}
+	if err := groth16.Verify(proof, vk, publicWitness); err != nil {
+		log.Panicf("Verify err: %s", err)
+	}
+	fmt.Println("proof verified")
+}
@@ -8,12 +8,14 @@ import (
 
 	bls12_377_ecc "github.com/consensys/gnark-crypto/ecc/bls12-377"
 	bls12_377_fr "github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+
+	bls_tools "gnark/aggregate/bls-tools"
 )
 
 var (
 	g1Gen bls12_377_ecc.G1Affine
 	g2Gen bls12_377_ecc.G2Affine
-	dst   = []byte("BLS12_377_ECC_HASH")
+	dst   = bls_tools.BLS12377G1.Ciphersuite().DST()
 )
 
 type PrivateKey struct {
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+
+	bls12377 "gnark/bls12377/emulated/sw_bls12377"
+)
+
+// Circuit Boneh-Lynn-Shacham (BLS) signature verification
+// e(sig, -g2) * e(hm, pk) == 1
+// where:
+//   - Sig (in G1) the signature
+//   - Hm (in G1) the hashed-to-curve message
+//   - Pk (in G2) the public key of the signer
+//
+// and g2 is the generator of G2, a constant of the circuit.
+type Circuit struct {
+	Sig bls12377.G1Affine
+	Hm  bls12377.G1Affine
+	Pk  bls12377.G2Affine
+}
+
+// Define e(sig,-g2) * e(hm,pk) == 1 with a single final exponentiation, for a signature in G1
+// and a key in G2. The pairing does not check subgroups: a signature shifted by a point of small
+// order would pass it.
+func (circuit *Circuit) Define(api frontend.API) error {
+	pair, err := bls12377.NewPairing(api)
+	if err != nil {
+		return fmt.Errorf("new pairing: %w", err)
+	}
+	pair.AssertIsOnG1(&circuit.Sig)
+	pair.AssertIsOnG2(&circuit.Pk)
+
+	negG2 := bls12377.NewG2Affine(*new(bls377.G2Affine).Neg(&g2Gen))
+	return pair.PairingCheck([]*bls12377.G1Affine{&circuit.Sig, &circuit.Hm}, []*bls12377.G2Affine{&negG2, &circuit.Pk})
+}
+
+func main() {
+
+	circuit := Circuit{}
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &circuit)
+	if err != nil {
+		log.Panic("Compile err: ", err)
+	}
+	log.Printf("constraints: %d", ccs.GetNbConstraints())
+	// Create Pair privateKey and PublicKey
+	privateKey, PublicKey, err := GenerateKeyPair()
+	if err != nil {
+		log.Panic("GenerateKeyPair err: ", err)
+	}
+
+	msg := []byte("Sig Test")
+	hm, err := bls377.HashToG1(msg, dst)
+	if err != nil {
+		log.Panic("HashToG1 err: ", err)
+	}
+
+	sig := new(bls377.G1Affine).ScalarMultiplication(&hm, privateKey.X)
+	circuit = Circuit{
+		Sig: bls12377.NewG1Affine(*sig),
+		Hm:  bls12377.NewG1Affine(hm),
+		Pk:  bls12377.NewG2Affine(*PublicKey.P),
+	}
+
+	// witness definition
+	witness, err := frontend.NewWitness(&circuit, ecc.BN254.ScalarField())
+	if err != nil {
+		log.Panic("NewWitness err: ", err)
+	}
+	publicWitness, err := witness.Public()
+	if err != nil {
+		log.Panic("Public err: ", err)
+	}
+
+	// groth16 zkSNARK: Setup
+	pk, vk, err := groth16.Setup(ccs)
+	if err != nil {
+		log.Panic("Setup err: ", err)
+	}
+	// groth16: Prove & Verify
+	proof, err := groth16.Prove(ccs, pk, witness)
+	if err != nil {
+		log.Panic("Prove err: ", err)
+	}
+
+	err = groth16.Verify(proof, vk, publicWitness)
+	if err != nil {
+		log.Panic("Verify err: ", err)
+	}
+}
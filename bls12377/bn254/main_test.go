@@ -0,0 +1,87 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fp"
+	bls377fr "github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark/test"
+
+	bls_tools "gnark/aggregate/bls-tools"
+	bls12377 "gnark/bls12377/emulated/sw_bls12377"
+	"gnark/internal/circuittest"
+)
+
+// otherDST is a domain separation tag the circuit messages are not hashed with.
+var otherDST = bls_tools.BLS12377G1.Ciphersuite().WithScheme(bls_tools.POP).DST()
+
+func assign(sig, hm *bls377.G1Affine, pk *bls377.G2Affine) *Circuit {
+	return &Circuit{
+		Sig: bls12377.NewG1Affine(*sig),
+		Hm:  bls12377.NewG1Affine(*hm),
+		Pk:  bls12377.NewG2Affine(*pk),
+	}
+}
+
+// shiftOutOfG1 adds to p a point of order dividing the cofactor, which the pairing does not see.
+func shiftOutOfG1(assert *test.Assert, p *bls377.G1Affine) *bls377.G1Affine {
+	for x := uint64(1); ; x++ {
+		var q bls377.G1Affine
+		q.X.SetUint64(x)
+		var y2 fp.Element
+		y2.Square(&q.X).Mul(&y2, &q.X).Add(&y2, new(fp.Element).SetOne())
+		if q.Y.Sqrt(&y2) == nil {
+			continue
+		}
+		q.ScalarMultiplication(&q, bls377fr.Modulus())
+		if q.IsInfinity() {
+			continue
+		}
+		res := new(bls377.G1Affine).Add(p, &q)
+		assert.True(res.IsOnCurve() && !res.IsInSubGroup(), "shifted point must be on the curve and out of G1")
+		return res
+	}
+}
+
+// testCases returns a valid witness and witnesses each breaking one check of the circuit.
+func testCases(assert *test.Assert) []circuittest.Case {
+	privateKey, publicKey, err := GenerateKeyPair()
+	assert.NoError(err)
+	_, otherKey, err := GenerateKeyPair()
+	assert.NoError(err)
+
+	msg := []byte("Sig Test")
+	hm, err := bls377.HashToG1(msg, dst)
+	assert.NoError(err)
+	sig := new(bls377.G1Affine).ScalarMultiplication(&hm, privateKey.X)
+	otherHm, err := bls377.HashToG1([]byte("Other Test"), dst)
+	assert.NoError(err)
+	otherDSTHm, err := bls377.HashToG1(msg, otherDST)
+	assert.NoError(err)
+	otherDSTSig := new(bls377.G1Affine).ScalarMultiplication(&otherDSTHm, privateKey.X)
+	offCurveSig := *sig
+	offCurveSig.Y.Double(&offCurveSig.Y)
+
+	return []circuittest.Case{
+		{Name: "valid", Witness: assign(sig, &hm, publicKey.P), Valid: true},
+		{Name: "wrong message", Witness: assign(sig, &otherHm, publicKey.P), Valid: false},
+		{Name: "wrong key", Witness: assign(sig, &hm, otherKey.P), Valid: false},
+		{Name: "identity signature", Witness: assign(&bls377.G1Affine{}, &hm, publicKey.P), Valid: false},
+		{Name: "off-curve signature", Witness: assign(&offCurveSig, &hm, publicKey.P), Valid: false},
+		{Name: "other DST", Witness: assign(otherDSTSig, &hm, publicKey.P), Valid: false},
+		{Name: "signature out of G1", Witness: assign(shiftOutOfG1(assert, sig), &hm, publicKey.P), Valid: false},
+	}
+}
+
+// TestCircuit runs the circuit in the test engine, which checks the assertions shared by every
+// backend. Compiling the emulated pairings for each backend takes millions of constraints and is
+// left to TestCircuitBackends, built with the backends tag.
+func TestCircuit(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping emulated pairing circuit in short mode")
+	}
+	assert := test.NewAssert(t)
+	circuittest.Solve(assert, &Circuit{}, ecc.BN254, testCases(assert))
+}
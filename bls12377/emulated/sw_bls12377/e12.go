@@ -0,0 +1,249 @@
+package sw_bls12377
+
+import (
+	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+// E12 is an element of Fp12 = Fp6[w]/(w²-v).
+type E12 struct {
+	C0, C1 E6
+}
+
+// Ext12 is the arithmetic of Fp12 over the emulated base field.
+type Ext12 struct {
+	*Ext6
+	// frob are the Fp coefficients of the Frobenius map on w, w², .., w⁵ and
+	// frob2 those of its square.
+	frob, frob2 [5]*baseEl
+}
+
+// Frobenius coefficients, as in the native fields_bls12377 of gnark.
+var (
+	frobCoeffs = [5]string{
+		"92949345220277864758624960506473182677953048909283248980960104381795901929519566951595905490535835115111760994353",  // w
+		"80949648264912719408558363140637477264845294720710499478137287262712535938301461879813459410946",                    // w²
+		"216465761340224619389371505802605247630151569547285782856803747159100223055385581585702401816380679166954762214499", // w³
+		"80949648264912719408558363140637477264845294720710499478137287262712535938301461879813459410945",                    // w⁴
+		"123516416119946754630746545296132064952198520638002533875843642777304321125866014634106496325844844051843001220146", // w⁵
+	}
+	frob2Coeffs = [5]string{
+		"80949648264912719408558363140637477264845294720710499478137287262712535938301461879813459410946",
+		"80949648264912719408558363140637477264845294720710499478137287262712535938301461879813459410945",
+		"258664426012969094010652733694893533536393512754914660539884262666720468348340822774968888139573360124440321458176",
+		"258664426012969093929703085429980814127835149614277183275038967946009968870203535512256352201271898244626862047231",
+		"258664426012969093929703085429980814127835149614277183275038967946009968870203535512256352201271898244626862047232",
+	}
+)
+
+func NewExt12(api frontend.API) *Ext12 {
+	e := &Ext12{Ext6: NewExt6(api)}
+	for i := range frobCoeffs {
+		c := emulated.ValueOf[emulated.BLS12377Fp](frobCoeffs[i])
+		c2 := emulated.ValueOf[emulated.BLS12377Fp](frob2Coeffs[i])
+		e.frob[i], e.frob2[i] = &c, &c2
+	}
+	return e
+}
+
+func FromE12(y *bls12377.E12) E12 {
+	return E12{C0: FromE6(&y.C0), C1: FromE6(&y.C1)}
+}
+
+func (e Ext12) One() *E12 {
+	return &E12{C0: *e.Ext6.One(), C1: *e.Ext6.Zero()}
+}
+
+func (e Ext12) Add(x, y *E12) *E12 {
+	return &E12{C0: *e.Ext6.Add(&x.C0, &y.C0), C1: *e.Ext6.Add(&x.C1, &y.C1)}
+}
+
+func (e Ext12) Sub(x, y *E12) *E12 {
+	return &E12{C0: *e.Ext6.Sub(&x.C0, &y.C0), C1: *e.Ext6.Sub(&x.C1, &y.C1)}
+}
+
+// Conjugate returns x^(p⁶), the inverse of x in the cyclotomic subgroup.
+func (e Ext12) Conjugate(x *E12) *E12 {
+	return &E12{C0: x.C0, C1: *e.Ext6.Neg(&x.C1)}
+}
+
+func (e Ext12) Mul(x, y *E12) *E12 {
+	a := e.Ext6.Mul(e.Ext6.Add(&x.C0, &x.C1), e.Ext6.Add(&y.C0, &y.C1))
+	b := e.Ext6.Mul(&x.C0, &y.C0)
+	c := e.Ext6.Mul(&x.C1, &y.C1)
+	z1 := e.Ext6.Sub(e.Ext6.Sub(a, b), c)
+	z0 := e.Ext6.Add(e.Ext6.MulByNonResidue(c), b)
+	return &E12{C0: *z0, C1: *z1}
+}
+
+// Square is Algorithm 22 from https://eprint.iacr.org/2010/354.pdf
+func (e Ext12) Square(x *E12) *E12 {
+	c0 := e.Ext6.Sub(&x.C0, &x.C1)
+	c3 := e.Ext6.Sub(&x.C0, e.Ext6.MulByNonResidue(&x.C1))
+	c2 := e.Ext6.Mul(&x.C0, &x.C1)
+	c0 = e.Ext6.Add(e.Ext6.Mul(c0, c3), c2)
+	z1 := e.Ext6.Double(c2)
+	z0 := e.Ext6.Add(c0, e.Ext6.MulByNonResidue(c2))
+	return &E12{C0: *z0, C1: *z1}
+}
+
+// CyclotomicSquare squares an element of the cyclotomic subgroup, Granger-Scott
+// https://eprint.iacr.org/2009/565.pdf, 3.2
+func (e Ext12) CyclotomicSquare(x *E12) *E12 {
+	// 2ab from (a+b)²-a²-b²
+	twoProd := func(a, b, aa, bb *E2) *E2 {
+		return e.Ext2.Sub(e.Ext2.Sub(e.Ext2.Square(e.Ext2.Add(a, b)), aa), bb)
+	}
+	t0 := e.Ext2.Square(&x.C1.B1)
+	t1 := e.Ext2.Square(&x.C0.B0)
+	t6 := twoProd(&x.C1.B1, &x.C0.B0, t0, t1) // 2*x4*x0
+	t2 := e.Ext2.Square(&x.C0.B2)
+	t3 := e.Ext2.Square(&x.C1.B0)
+	t7 := twoProd(&x.C0.B2, &x.C1.B0, t2, t3) // 2*x2*x3
+	t4 := e.Ext2.Square(&x.C1.B2)
+	t5 := e.Ext2.Square(&x.C0.B1)
+	t8 := e.Ext2.MulByNonResidue(twoProd(&x.C1.B2, &x.C0.B1, t4, t5)) // 2*x5*x1*u
+	t0 = e.Ext2.Add(e.Ext2.MulByNonResidue(t0), t1)                   // x4²*u + x0²
+	t2 = e.Ext2.Add(e.Ext2.MulByNonResidue(t2), t3)                   // x2²*u + x3²
+	t4 = e.Ext2.Add(e.Ext2.MulByNonResidue(t4), t5)                   // x5²*u + x1²
+
+	// 3t - 2x and 3t + 2x
+	minus := func(t, x *E2) *E2 {
+		return e.Ext2.Add(e.Ext2.Double(e.Ext2.Sub(t, x)), t)
+	}
+	plus := func(t, x *E2) *E2 {
+		return e.Ext2.Add(e.Ext2.Double(e.Ext2.Add(t, x)), t)
+	}
+	return &E12{
+		C0: E6{B0: *minus(t0, &x.C0.B0), B1: *minus(t2, &x.C0.B1), B2: *minus(t4, &x.C0.B2)},
+		C1: E6{B0: *plus(t8, &x.C1.B0), B1: *plus(t6, &x.C1.B1), B2: *plus(t7, &x.C1.B2)},
+	}
+}
+
+// nCyclotomicSquare squares x n times in the cyclotomic subgroup.
+func (e Ext12) nCyclotomicSquare(x *E12, n int) *E12 {
+	for i := 0; i < n; i++ {
+		x = e.CyclotomicSquare(x)
+	}
+	return x
+}
+
+// Frobenius returns x^p.
+func (e Ext12) Frobenius(x *E12) *E12 {
+	frob := func(y *E2, i int) *E2 {
+		y = e.Ext2.Conjugate(y)
+		if i < 0 {
+			return y
+		}
+		return e.Ext2.MulByElement(y, e.frob[i])
+	}
+	return &E12{
+		C0: E6{B0: *frob(&x.C0.B0, -1), B1: *frob(&x.C0.B1, 1), B2: *frob(&x.C0.B2, 3)},
+		C1: E6{B0: *frob(&x.C1.B0, 0), B1: *frob(&x.C1.B1, 2), B2: *frob(&x.C1.B2, 4)},
+	}
+}
+
+// FrobeniusSquare returns x^(p²).
+func (e Ext12) FrobeniusSquare(x *E12) *E12 {
+	return &E12{
+		C0: E6{
+			B0: x.C0.B0,
+			B1: *e.Ext2.MulByElement(&x.C0.B1, e.frob2[1]),
+			B2: *e.Ext2.MulByElement(&x.C0.B2, e.frob2[3]),
+		},
+		C1: E6{
+			B0: *e.Ext2.MulByElement(&x.C1.B0, e.frob2[0]),
+			B1: *e.Ext2.MulByElement(&x.C1.B1, e.frob2[2]),
+			B2: *e.Ext2.MulByElement(&x.C1.B2, e.frob2[4]),
+		},
+	}
+}
+
+// limbs returns the 12 Fp coefficients of x.
+func (x *E12) limbs() []*baseEl {
+	return []*baseEl{
+		&x.C0.B0.A0, &x.C0.B0.A1, &x.C0.B1.A0, &x.C0.B1.A1, &x.C0.B2.A0, &x.C0.B2.A1,
+		&x.C1.B0.A0, &x.C1.B0.A1, &x.C1.B1.A0, &x.C1.B1.A1, &x.C1.B2.A0, &x.C1.B2.A1,
+	}
+}
+
+// DivUnchecked returns x/y. The circuit has no solution when y is zero.
+func (e Ext12) DivUnchecked(x, y *E12) *E12 {
+	res, err := e.fp.NewHint(divE12Hint, 12, append(x.limbs(), y.limbs()...)...)
+	if err != nil {
+		// err is non-nil only for invalid number of inputs
+		panic(err)
+	}
+	div := E12{
+		C0: E6{
+			B0: E2{A0: *res[0], A1: *res[1]},
+			B1: E2{A0: *res[2], A1: *res[3]},
+			B2: E2{A0: *res[4], A1: *res[5]},
+		},
+		C1: E6{
+			B0: E2{A0: *res[6], A1: *res[7]},
+			B1: E2{A0: *res[8], A1: *res[9]},
+			B2: E2{A0: *res[10], A1: *res[11]},
+		},
+	}
+	// x == div * y
+	e.AssertIsEqual(x, e.Mul(&div, y))
+	return &div
+}
+
+func (e Ext12) AssertIsEqual(x, y *E12) {
+	e.Ext6.AssertIsEqual(&x.C0, &y.C0)
+	e.Ext6.AssertIsEqual(&x.C1, &y.C1)
+}
+
+func (e Ext12) Select(selector frontend.Variable, z1, z0 *E12) *E12 {
+	return &E12{C0: *e.Ext6.Select(selector, &z1.C0, &z0.C0), C1: *e.Ext6.Select(selector, &z1.C1, &z0.C1)}
+}
+
+// MulBy034 multiplies z by the sparse element 1 + (c3 + c4v)w of a line.
+func (e Ext12) MulBy034(z *E12, c3, c4 *E2) *E12 {
+	b := e.Ext6.MulBy01(&z.C1, c3, c4)
+	d := e.Ext6.MulBy01(e.Ext6.Add(&z.C0, &z.C1), e.Ext2.Add(e.Ext2.One(), c3), c4)
+	z1 := e.Ext6.Sub(d, e.Ext6.Add(&z.C0, b))
+	z0 := e.Ext6.Add(e.Ext6.MulByNonResidue(b), &z.C0)
+	return &E12{C0: *z0, C1: *z1}
+}
+
+// Mul034By034 multiplies the sparse elements 1 + (d3 + d4v)w and 1 + (c3 + c4v)w
+// and returns the coefficients of 1, v, v², w, vw of the product.
+func (e Ext12) Mul034By034(d3, d4, c3, c4 *E2) *[5]E2 {
+	x3 := e.Ext2.Mul(c3, d3)
+	x4 := e.Ext2.Mul(c4, d4)
+	x04 := e.Ext2.Add(c4, d4)
+	x03 := e.Ext2.Add(c3, d3)
+	x34 := e.Ext2.Mul(e.Ext2.Add(d3, d4), e.Ext2.Add(c3, c4))
+	x34 = e.Ext2.Sub(e.Ext2.Sub(x34, x3), x4)
+	x00 := e.Ext2.Add(e.Ext2.MulByNonResidue(x4), e.Ext2.One())
+	return &[5]E2{*x00, *x3, *x34, *x03, *x04}
+}
+
+// MulBy01234 multiplies z by the product of two lines from Mul034By034.
+func (e Ext12) MulBy01234(z *E12, x *[5]E2) *E12 {
+	c0 := &E6{B0: x[0], B1: x[1], B2: x[2]}
+	c1 := &E6{B0: x[3], B1: x[4], B2: *e.Ext2.Zero()}
+	a := e.Ext6.Mul(e.Ext6.Add(&z.C0, &z.C1), e.Ext6.Add(c0, c1))
+	b := e.Ext6.Mul(&z.C0, c0)
+	c := e.Ext6.MulBy01(&z.C1, &x[3], &x[4])
+	z1 := e.Ext6.Sub(e.Ext6.Sub(a, b), c)
+	z0 := e.Ext6.Add(e.Ext6.MulByNonResidue(c), b)
+	return &E12{C0: *z0, C1: *z1}
+}
+
+// Expt returns x^x₀ for x in the cyclotomic subgroup, x₀=9586122913090633729 the seed
+// of BLS12-377.
+func (e Ext12) Expt(x *E12) *E12 {
+	// x₀ = ((((33 << 7) + 33) << 4 + 1) << 1 + 1) << 46 + 1
+	z := e.Mul(e.nCyclotomicSquare(x, 5), x)
+	x33 := z
+	z = e.Mul(e.nCyclotomicSquare(z, 7), x33)
+	z = e.Mul(e.nCyclotomicSquare(z, 4), x)
+	z = e.Mul(e.CyclotomicSquare(z), x)
+	z = e.Mul(e.nCyclotomicSquare(z, 46), x)
+	return z
+}
@@ -0,0 +1,148 @@
+package sw_bls12377
+
+import (
+	"math/big"
+
+	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+type curveF = emulated.Field[emulated.BLS12377Fp]
+type baseEl = emulated.Element[emulated.BLS12377Fp]
+
+// E2 is an element of Fp2 = Fp[u]/(u²+5).
+type E2 struct {
+	A0, A1 baseEl
+}
+
+// Ext2 is the arithmetic of Fp2 over the emulated base field.
+type Ext2 struct {
+	api frontend.API
+	fp  *curveF
+}
+
+func NewExt2(api frontend.API) *Ext2 {
+	fp, err := emulated.NewField[emulated.BLS12377Fp](api)
+	if err != nil {
+		panic(err)
+	}
+	return &Ext2{api: api, fp: fp}
+}
+
+func FromE2(y *bls12377.E2) E2 {
+	return E2{
+		A0: emulated.ValueOf[emulated.BLS12377Fp](y.A0),
+		A1: emulated.ValueOf[emulated.BLS12377Fp](y.A1),
+	}
+}
+
+func (e Ext2) One() *E2 {
+	return &E2{A0: *e.fp.One(), A1: *e.fp.Zero()}
+}
+
+func (e Ext2) Zero() *E2 {
+	return &E2{A0: *e.fp.Zero(), A1: *e.fp.Zero()}
+}
+
+func (e Ext2) IsZero(z *E2) frontend.Variable {
+	return e.api.And(e.fp.IsZero(&z.A0), e.fp.IsZero(&z.A1))
+}
+
+func (e Ext2) Add(x, y *E2) *E2 {
+	return &E2{A0: *e.fp.Add(&x.A0, &y.A0), A1: *e.fp.Add(&x.A1, &y.A1)}
+}
+
+func (e Ext2) Sub(x, y *E2) *E2 {
+	return &E2{A0: *e.fp.Sub(&x.A0, &y.A0), A1: *e.fp.Sub(&x.A1, &y.A1)}
+}
+
+func (e Ext2) Neg(x *E2) *E2 {
+	return &E2{A0: *e.fp.Neg(&x.A0), A1: *e.fp.Neg(&x.A1)}
+}
+
+func (e Ext2) Double(x *E2) *E2 {
+	return e.Add(x, x)
+}
+
+// mulByFive returns 5x, u² = -5 in Fp2.
+func (e Ext2) mulByFive(x *baseEl) *baseEl {
+	return e.fp.MulConst(x, big.NewInt(5))
+}
+
+func (e Ext2) Mul(x, y *E2) *E2 {
+	// Karatsuba: (a0+a1u)(b0+b1u) = a0b0-5a1b1 + ((a0+a1)(b0+b1)-a0b0-a1b1)u
+	a := e.fp.Add(&x.A0, &x.A1)
+	b := e.fp.Add(&y.A0, &y.A1)
+	a = e.fp.MulMod(a, b)
+	ac := e.fp.MulMod(&x.A0, &y.A0)
+	bd := e.fp.MulMod(&x.A1, &y.A1)
+	z1 := e.fp.Sub(e.fp.Sub(a, ac), bd)
+	z0 := e.fp.Sub(ac, e.mulByFive(bd))
+	return &E2{A0: *z0, A1: *z1}
+}
+
+func (e Ext2) Square(x *E2) *E2 {
+	// (a0+a1)(a0-5a1)+4a0a1 = a0²-5a1²
+	a := e.fp.Add(&x.A0, &x.A1)
+	b := e.fp.Sub(&x.A0, e.mulByFive(&x.A1))
+	a = e.fp.MulMod(a, b)
+	c := e.fp.MulMod(&x.A0, &x.A1)
+	z1 := e.fp.MulConst(c, big.NewInt(2))
+	z0 := e.fp.Add(a, e.fp.MulConst(c, big.NewInt(4)))
+	return &E2{A0: *z0, A1: *z1}
+}
+
+// MulByElement multiplies x by an element of Fp.
+func (e Ext2) MulByElement(x *E2, y *baseEl) *E2 {
+	return &E2{A0: *e.fp.MulMod(&x.A0, y), A1: *e.fp.MulMod(&x.A1, y)}
+}
+
+// MulByConstElement multiplies x by a small constant.
+func (e Ext2) MulByConstElement(x *E2, y *big.Int) *E2 {
+	return &E2{A0: *e.fp.MulConst(&x.A0, y), A1: *e.fp.MulConst(&x.A1, y)}
+}
+
+// MulByNonResidue returns x*u, the non residue of Fp6 = Fp2[v]/(v³-u).
+func (e Ext2) MulByNonResidue(x *E2) *E2 {
+	return &E2{A0: *e.fp.Neg(e.mulByFive(&x.A1)), A1: x.A0}
+}
+
+// Conjugate returns x^p.
+func (e Ext2) Conjugate(x *E2) *E2 {
+	return &E2{A0: x.A0, A1: *e.fp.Neg(&x.A1)}
+}
+
+func (e Ext2) AssertIsEqual(x, y *E2) {
+	e.fp.AssertIsEqual(&x.A0, &y.A0)
+	e.fp.AssertIsEqual(&x.A1, &y.A1)
+}
+
+func (e Ext2) Select(selector frontend.Variable, z1, z0 *E2) *E2 {
+	return &E2{A0: *e.fp.Select(selector, &z1.A0, &z0.A0), A1: *e.fp.Select(selector, &z1.A1, &z0.A1)}
+}
+
+func (e Ext2) Inverse(x *E2) *E2 {
+	res, err := e.fp.NewHint(inverseE2Hint, 2, &x.A0, &x.A1)
+	if err != nil {
+		// err is non-nil only for invalid number of inputs
+		panic(err)
+	}
+	inv := E2{A0: *res[0], A1: *res[1]}
+	// 1 == inv * x
+	e.AssertIsEqual(e.One(), e.Mul(&inv, x))
+	return &inv
+}
+
+// DivUnchecked returns x/y. The circuit has no solution when y is zero.
+func (e Ext2) DivUnchecked(x, y *E2) *E2 {
+	res, err := e.fp.NewHint(divE2Hint, 2, &x.A0, &x.A1, &y.A0, &y.A1)
+	if err != nil {
+		// err is non-nil only for invalid number of inputs
+		panic(err)
+	}
+	div := E2{A0: *res[0], A1: *res[1]}
+	// x == div * y
+	e.AssertIsEqual(x, e.Mul(&div, y))
+	return &div
+}
@@ -0,0 +1,132 @@
+package sw_bls12377
+
+import (
+	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark/frontend"
+)
+
+// E6 is an element of Fp6 = Fp2[v]/(v³-u).
+type E6 struct {
+	B0, B1, B2 E2
+}
+
+// Ext6 is the arithmetic of Fp6 over the emulated base field.
+type Ext6 struct {
+	*Ext2
+}
+
+func NewExt6(api frontend.API) *Ext6 {
+	return &Ext6{Ext2: NewExt2(api)}
+}
+
+func FromE6(y *bls12377.E6) E6 {
+	return E6{B0: FromE2(&y.B0), B1: FromE2(&y.B1), B2: FromE2(&y.B2)}
+}
+
+func (e Ext6) One() *E6 {
+	return &E6{B0: *e.Ext2.One(), B1: *e.Ext2.Zero(), B2: *e.Ext2.Zero()}
+}
+
+func (e Ext6) Zero() *E6 {
+	return &E6{B0: *e.Ext2.Zero(), B1: *e.Ext2.Zero(), B2: *e.Ext2.Zero()}
+}
+
+func (e Ext6) Add(x, y *E6) *E6 {
+	return &E6{
+		B0: *e.Ext2.Add(&x.B0, &y.B0),
+		B1: *e.Ext2.Add(&x.B1, &y.B1),
+		B2: *e.Ext2.Add(&x.B2, &y.B2),
+	}
+}
+
+func (e Ext6) Sub(x, y *E6) *E6 {
+	return &E6{
+		B0: *e.Ext2.Sub(&x.B0, &y.B0),
+		B1: *e.Ext2.Sub(&x.B1, &y.B1),
+		B2: *e.Ext2.Sub(&x.B2, &y.B2),
+	}
+}
+
+func (e Ext6) Neg(x *E6) *E6 {
+	return &E6{B0: *e.Ext2.Neg(&x.B0), B1: *e.Ext2.Neg(&x.B1), B2: *e.Ext2.Neg(&x.B2)}
+}
+
+func (e Ext6) Double(x *E6) *E6 {
+	return e.Add(x, x)
+}
+
+// Mul is Algorithm 13 from https://eprint.iacr.org/2010/354.pdf
+func (e Ext6) Mul(x, y *E6) *E6 {
+	t0 := e.Ext2.Mul(&x.B0, &y.B0)
+	t1 := e.Ext2.Mul(&x.B1, &y.B1)
+	t2 := e.Ext2.Mul(&x.B2, &y.B2)
+
+	c0 := e.Ext2.Mul(e.Ext2.Add(&x.B1, &x.B2), e.Ext2.Add(&y.B1, &y.B2))
+	c0 = e.Ext2.Sub(e.Ext2.Sub(c0, t1), t2)
+	c0 = e.Ext2.Add(e.Ext2.MulByNonResidue(c0), t0)
+
+	c1 := e.Ext2.Mul(e.Ext2.Add(&x.B0, &x.B1), e.Ext2.Add(&y.B0, &y.B1))
+	c1 = e.Ext2.Sub(e.Ext2.Sub(c1, t0), t1)
+	c1 = e.Ext2.Add(c1, e.Ext2.MulByNonResidue(t2))
+
+	c2 := e.Ext2.Mul(e.Ext2.Add(&x.B0, &x.B2), e.Ext2.Add(&y.B0, &y.B2))
+	c2 = e.Ext2.Add(e.Ext2.Sub(e.Ext2.Sub(c2, t0), t2), t1)
+
+	return &E6{B0: *c0, B1: *c1, B2: *c2}
+}
+
+// Square is Algorithm 16 from https://eprint.iacr.org/2010/354.pdf
+func (e Ext6) Square(x *E6) *E6 {
+	c4 := e.Ext2.Double(e.Ext2.Mul(&x.B0, &x.B1))
+	c5 := e.Ext2.Square(&x.B2)
+	c1 := e.Ext2.Add(e.Ext2.MulByNonResidue(c5), c4)
+	c2 := e.Ext2.Sub(c4, c5)
+	c3 := e.Ext2.Square(&x.B0)
+	c4 = e.Ext2.Add(e.Ext2.Sub(&x.B0, &x.B1), &x.B2)
+	c5 = e.Ext2.Double(e.Ext2.Mul(&x.B1, &x.B2))
+	c4 = e.Ext2.Square(c4)
+	c0 := e.Ext2.Add(e.Ext2.MulByNonResidue(c5), c3)
+	z2 := e.Ext2.Sub(e.Ext2.Add(e.Ext2.Add(c2, c4), c5), c3)
+	return &E6{B0: *c0, B1: *c1, B2: *z2}
+}
+
+// MulByE2 multiplies each coefficient of x by the Fp2 element y.
+func (e Ext6) MulByE2(x *E6, y *E2) *E6 {
+	return &E6{B0: *e.Ext2.Mul(&x.B0, y), B1: *e.Ext2.Mul(&x.B1, y), B2: *e.Ext2.Mul(&x.B2, y)}
+}
+
+// MulBy01 multiplies z by the sparse element c0+c1v.
+func (e Ext6) MulBy01(z *E6, c0, c1 *E2) *E6 {
+	a := e.Ext2.Mul(&z.B0, c0)
+	b := e.Ext2.Mul(&z.B1, c1)
+
+	t0 := e.Ext2.Mul(c1, e.Ext2.Add(&z.B1, &z.B2))
+	t0 = e.Ext2.Add(e.Ext2.MulByNonResidue(e.Ext2.Sub(t0, b)), a)
+
+	t2 := e.Ext2.Mul(c0, e.Ext2.Add(&z.B0, &z.B2))
+	t2 = e.Ext2.Add(e.Ext2.Sub(t2, a), b)
+
+	t1 := e.Ext2.Mul(e.Ext2.Add(c0, c1), e.Ext2.Add(&z.B0, &z.B1))
+	t1 = e.Ext2.Sub(e.Ext2.Sub(t1, a), b)
+
+	return &E6{B0: *t0, B1: *t1, B2: *t2}
+}
+
+// MulByNonResidue returns x*v, the non residue of Fp12 = Fp6[w]/(w²-v).
+func (e Ext6) MulByNonResidue(x *E6) *E6 {
+	return &E6{B0: *e.Ext2.MulByNonResidue(&x.B2), B1: x.B0, B2: x.B1}
+}
+
+func (e Ext6) AssertIsEqual(x, y *E6) {
+	e.Ext2.AssertIsEqual(&x.B0, &y.B0)
+	e.Ext2.AssertIsEqual(&x.B1, &y.B1)
+	e.Ext2.AssertIsEqual(&x.B2, &y.B2)
+}
+
+func (e Ext6) Select(selector frontend.Variable, z1, z0 *E6) *E6 {
+	return &E6{
+		B0: *e.Ext2.Select(selector, &z1.B0, &z0.B0),
+		B1: *e.Ext2.Select(selector, &z1.B1, &z0.B1),
+		B2: *e.Ext2.Select(selector, &z1.B2, &z0.B2),
+	}
+}
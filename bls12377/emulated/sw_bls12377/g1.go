@@ -0,0 +1,103 @@
+package sw_bls12377
+
+import (
+	"fmt"
+	"math/big"
+
+	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+// G1Affine is a point of G1 with emulated coordinates.
+type G1Affine = sw_emulated.AffinePoint[emulated.BLS12377Fp]
+
+func NewG1Affine(v bls12377.G1Affine) G1Affine {
+	return G1Affine{
+		X: emulated.ValueOf[emulated.BLS12377Fp](v.X),
+		Y: emulated.ValueOf[emulated.BLS12377Fp](v.Y),
+	}
+}
+
+// G1 is the arithmetic of G1: y² = x³ + 1 over Fp.
+type G1 struct {
+	fp *curveF
+	// w is the cube root of unity of the endomorphism ϕ(x,y) = (wx,y).
+	w *baseEl
+}
+
+func NewG1(api frontend.API) (*G1, error) {
+	fp, err := emulated.NewField[emulated.BLS12377Fp](api)
+	if err != nil {
+		return nil, fmt.Errorf("new base api: %w", err)
+	}
+	w := emulated.ValueOf[emulated.BLS12377Fp]("80949648264912719408558363140637477264845294720710499478137287262712535938301461879813459410945")
+	return &G1{fp: fp, w: &w}, nil
+}
+
+func (g1 *G1) phi(p *G1Affine) *G1Affine {
+	return &G1Affine{X: *g1.fp.MulMod(&p.X, g1.w), Y: p.Y}
+}
+
+func (g1 *G1) neg(p *G1Affine) *G1Affine {
+	return &G1Affine{X: p.X, Y: *g1.fp.Neg(&p.Y)}
+}
+
+// add returns p+q for p ≠ ±q.
+func (g1 *G1) add(p, q *G1Affine) *G1Affine {
+	// λ = (q.y-p.y)/(q.x-p.x)
+	λ := g1.fp.Div(g1.fp.Sub(&q.Y, &p.Y), g1.fp.Sub(&q.X, &p.X))
+	// xr = λ²-p.x-q.x
+	xr := g1.fp.Sub(g1.fp.MulMod(λ, λ), g1.fp.Add(&p.X, &q.X))
+	// yr = λ(p.x-xr)-p.y
+	yr := g1.fp.Sub(g1.fp.MulMod(λ, g1.fp.Sub(&p.X, xr)), &p.Y)
+	return &G1Affine{X: *xr, Y: *yr}
+}
+
+// double returns 2p for p not of order 2.
+func (g1 *G1) double(p *G1Affine) *G1Affine {
+	// λ = 3p.x²/2p.y
+	xx3 := g1.fp.MulConst(g1.fp.MulMod(&p.X, &p.X), big.NewInt(3))
+	λ := g1.fp.Div(xx3, g1.fp.MulConst(&p.Y, big.NewInt(2)))
+	// xr = λ²-2p.x
+	xr := g1.fp.Sub(g1.fp.MulMod(λ, λ), g1.fp.MulConst(&p.X, big.NewInt(2)))
+	// yr = λ(p.x-xr)-p.y
+	yr := g1.fp.Sub(g1.fp.MulMod(λ, g1.fp.Sub(&p.X, xr)), &p.Y)
+	return &G1Affine{X: *xr, Y: *yr}
+}
+
+func (g1 *G1) doubleN(p *G1Affine, n int) *G1Affine {
+	for i := 0; i < n; i++ {
+		p = g1.double(p)
+	}
+	return p
+}
+
+// scalarMulBySeed returns [x₀]p, x₀=9586122913090633729 the seed of BLS12-377.
+func (g1 *G1) scalarMulBySeed(p *G1Affine) *G1Affine {
+	// x₀ = ((((33 << 7) + 33) << 4 + 1) << 1 + 1) << 46 + 1
+	z := g1.add(g1.doubleN(p, 5), p)
+	p33 := z
+	z = g1.add(g1.doubleN(z, 7), p33)
+	z = g1.add(g1.doubleN(z, 4), p)
+	z = g1.add(g1.double(z), p)
+	return g1.add(g1.doubleN(z, 46), p)
+}
+
+// AssertIsOnCurve asserts that p is on y² = x³ + 1.
+func (g1 *G1) AssertIsOnCurve(p *G1Affine) {
+	left := g1.fp.MulMod(&p.Y, &p.Y)
+	right := g1.fp.Add(g1.fp.MulMod(g1.fp.MulMod(&p.X, &p.X), &p.X), g1.fp.One())
+	g1.fp.AssertIsEqual(left, right)
+}
+
+// AssertIsOnG1 asserts that p is on the curve and in the subgroup of order r.
+func (g1 *G1) AssertIsOnG1(p *G1Affine) {
+	g1.AssertIsOnCurve(p)
+	// [r]p == 0 <==> [x₀²]ϕ(p) == -p
+	xxPhiP := g1.scalarMulBySeed(g1.scalarMulBySeed(g1.phi(p)))
+	negP := g1.neg(p)
+	g1.fp.AssertIsEqual(&xxPhiP.X, &negP.X)
+	g1.fp.AssertIsEqual(&xxPhiP.Y, &negP.Y)
+}
@@ -0,0 +1,104 @@
+package sw_bls12377
+
+import (
+	"math/big"
+
+	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+// G2Affine is a point of G2 with emulated coordinates.
+type G2Affine struct {
+	X, Y E2
+}
+
+func NewG2Affine(v bls12377.G2Affine) G2Affine {
+	return G2Affine{X: FromE2(&v.X), Y: FromE2(&v.Y)}
+}
+
+// G2 is the arithmetic of G2: y² = x³ + 1/u over Fp2.
+type G2 struct {
+	*Ext2
+	bTwist *E2
+	// u, v are the Fp coefficients of ψ(x,y) = (u·x̄, v·ȳ).
+	u, v *baseEl
+}
+
+func NewG2(api frontend.API) *G2 {
+	var b bls12377.E2
+	b.A1.SetOne()
+	b.Inverse(&b)
+	bTwist := FromE2(&b)
+	u := emulated.ValueOf[emulated.BLS12377Fp]("80949648264912719408558363140637477264845294720710499478137287262712535938301461879813459410946")
+	v := emulated.ValueOf[emulated.BLS12377Fp]("216465761340224619389371505802605247630151569547285782856803747159100223055385581585702401816380679166954762214499")
+	return &G2{Ext2: NewExt2(api), bTwist: &bTwist, u: &u, v: &v}
+}
+
+func (g2 *G2) psi(q *G2Affine) *G2Affine {
+	return &G2Affine{
+		X: *g2.MulByElement(g2.Conjugate(&q.X), g2.u),
+		Y: *g2.MulByElement(g2.Conjugate(&q.Y), g2.v),
+	}
+}
+
+// add returns p+q for p ≠ ±q.
+func (g2 *G2) add(p, q *G2Affine) *G2Affine {
+	// λ = (q.y-p.y)/(q.x-p.x)
+	λ := g2.DivUnchecked(g2.Sub(&q.Y, &p.Y), g2.Sub(&q.X, &p.X))
+	// xr = λ²-p.x-q.x
+	xr := g2.Sub(g2.Square(λ), g2.Add(&p.X, &q.X))
+	// yr = λ(p.x-xr)-p.y
+	yr := g2.Sub(g2.Mul(λ, g2.Sub(&p.X, xr)), &p.Y)
+	return &G2Affine{X: *xr, Y: *yr}
+}
+
+// double returns 2p for p not of order 2.
+func (g2 *G2) double(p *G2Affine) *G2Affine {
+	// λ = 3p.x²/2p.y
+	xx3 := g2.MulByConstElement(g2.Square(&p.X), big.NewInt(3))
+	λ := g2.DivUnchecked(xx3, g2.Double(&p.Y))
+	// xr = λ²-2p.x
+	xr := g2.Sub(g2.Square(λ), g2.Double(&p.X))
+	// yr = λ(p.x-xr)-p.y
+	yr := g2.Sub(g2.Mul(λ, g2.Sub(&p.X, xr)), &p.Y)
+	return &G2Affine{X: *xr, Y: *yr}
+}
+
+func (g2 *G2) doubleN(p *G2Affine, n int) *G2Affine {
+	for i := 0; i < n; i++ {
+		p = g2.double(p)
+	}
+	return p
+}
+
+// scalarMulBySeed returns [x₀]q, x₀=9586122913090633729 the seed of BLS12-377.
+func (g2 *G2) scalarMulBySeed(q *G2Affine) *G2Affine {
+	// x₀ = ((((33 << 7) + 33) << 4 + 1) << 1 + 1) << 46 + 1
+	z := g2.add(g2.doubleN(q, 5), q)
+	q33 := z
+	z = g2.add(g2.doubleN(z, 7), q33)
+	z = g2.add(g2.doubleN(z, 4), q)
+	z = g2.add(g2.double(z), q)
+	return g2.add(g2.doubleN(z, 46), q)
+}
+
+// AssertIsOnTwist asserts that q is on y² = x³ + 1/u.
+func (g2 *G2) AssertIsOnTwist(q *G2Affine) {
+	left := g2.Square(&q.Y)
+	right := g2.Add(g2.Mul(g2.Square(&q.X), &q.X), g2.bTwist)
+	g2.Ext2.AssertIsEqual(left, right)
+}
+
+// AssertIsOnG2 asserts that q is on the twist and in the subgroup of order r.
+func (g2 *G2) AssertIsOnG2(q *G2Affine) {
+	g2.AssertIsOnTwist(q)
+	// [r]q == 0 <==> ψ(q) == [x₀]q
+	g2.AssertIsEqual(g2.scalarMulBySeed(q), g2.psi(q))
+}
+
+// AssertIsEqual asserts that p and q are the same point.
+func (g2 *G2) AssertIsEqual(p, q *G2Affine) {
+	g2.Ext2.AssertIsEqual(&p.X, &q.X)
+	g2.Ext2.AssertIsEqual(&p.Y, &q.Y)
+}
@@ -0,0 +1,89 @@
+package sw_bls12377
+
+import (
+	"math/big"
+
+	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark/constraint/solver"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+func init() {
+	solver.RegisterHint(GetHints()...)
+}
+
+// GetHints returns all hint functions used in the package.
+func GetHints() []solver.Hint {
+	return []solver.Hint{
+		divE2Hint,
+		inverseE2Hint,
+		divE12Hint,
+	}
+}
+
+func inverseE2Hint(nativeMod *big.Int, nativeInputs, nativeOutputs []*big.Int) error {
+	return emulated.UnwrapHint(nativeInputs, nativeOutputs,
+		func(mod *big.Int, inputs, outputs []*big.Int) error {
+			var a, c bls12377.E2
+			a.A0.SetBigInt(inputs[0])
+			a.A1.SetBigInt(inputs[1])
+			c.Inverse(&a)
+			c.A0.BigInt(outputs[0])
+			c.A1.BigInt(outputs[1])
+			return nil
+		})
+}
+
+func divE2Hint(nativeMod *big.Int, nativeInputs, nativeOutputs []*big.Int) error {
+	return emulated.UnwrapHint(nativeInputs, nativeOutputs,
+		func(mod *big.Int, inputs, outputs []*big.Int) error {
+			var a, b, c bls12377.E2
+			a.A0.SetBigInt(inputs[0])
+			a.A1.SetBigInt(inputs[1])
+			b.A0.SetBigInt(inputs[2])
+			b.A1.SetBigInt(inputs[3])
+			c.Inverse(&b).Mul(&c, &a)
+			c.A0.BigInt(outputs[0])
+			c.A1.BigInt(outputs[1])
+			return nil
+		})
+}
+
+func divE12Hint(nativeMod *big.Int, nativeInputs, nativeOutputs []*big.Int) error {
+	return emulated.UnwrapHint(nativeInputs, nativeOutputs,
+		func(mod *big.Int, inputs, outputs []*big.Int) error {
+			var a, b, c bls12377.E12
+			setE12(&a, inputs[:12])
+			setE12(&b, inputs[12:])
+			c.Inverse(&b).Mul(&c, &a)
+			c.C0.B0.A0.BigInt(outputs[0])
+			c.C0.B0.A1.BigInt(outputs[1])
+			c.C0.B1.A0.BigInt(outputs[2])
+			c.C0.B1.A1.BigInt(outputs[3])
+			c.C0.B2.A0.BigInt(outputs[4])
+			c.C0.B2.A1.BigInt(outputs[5])
+			c.C1.B0.A0.BigInt(outputs[6])
+			c.C1.B0.A1.BigInt(outputs[7])
+			c.C1.B1.A0.BigInt(outputs[8])
+			c.C1.B1.A1.BigInt(outputs[9])
+			c.C1.B2.A0.BigInt(outputs[10])
+			c.C1.B2.A1.BigInt(outputs[11])
+			return nil
+		})
+}
+
+// setE12 sets z from its 12 coefficients in the order of E12.limbs.
+func setE12(z *bls12377.E12, v []*big.Int) {
+	z.C0.B0.A0.SetBigInt(v[0])
+	z.C0.B0.A1.SetBigInt(v[1])
+	z.C0.B1.A0.SetBigInt(v[2])
+	z.C0.B1.A1.SetBigInt(v[3])
+	z.C0.B2.A0.SetBigInt(v[4])
+	z.C0.B2.A1.SetBigInt(v[5])
+	z.C1.B0.A0.SetBigInt(v[6])
+	z.C1.B0.A1.SetBigInt(v[7])
+	z.C1.B1.A0.SetBigInt(v[8])
+	z.C1.B1.A1.SetBigInt(v[9])
+	z.C1.B2.A0.SetBigInt(v[10])
+	z.C1.B2.A1.SetBigInt(v[11])
+}
@@ -0,0 +1,286 @@
+// Package sw_bls12377 implements the optimal Ate pairing of BLS12-377 with emulated
+// field arithmetic, so BLS12-377 signatures can be verified in circuits over any
+// scalar field, BN254 in particular. It follows std/algebra/emulated/sw_bls12381 of
+// gnark, which has no BLS12-377 counterpart, and the Fp12 tower of the native
+// std/algebra/native/sw_bls12377.
+package sw_bls12377
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+// GTEl is an element of the target group.
+type GTEl = E12
+
+func NewGTEl(v bls12377.GT) GTEl {
+	return FromE12(&v)
+}
+
+type Pairing struct {
+	*Ext12
+	curveF *curveF
+	g1     *G1
+	g2     *G2
+}
+
+func NewPairing(api frontend.API) (*Pairing, error) {
+	ba, err := emulated.NewField[emulated.BLS12377Fp](api)
+	if err != nil {
+		return nil, fmt.Errorf("new base api: %w", err)
+	}
+	g1, err := NewG1(api)
+	if err != nil {
+		return nil, fmt.Errorf("new G1 struct: %w", err)
+	}
+	return &Pairing{
+		Ext12:  NewExt12(api),
+		curveF: ba,
+		g1:     g1,
+		g2:     NewG2(api),
+	}, nil
+}
+
+// loopCounter is the binary decomposition of x₀=9586122913090633729 little endian.
+var loopCounter = [64]int8{
+	1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 1,
+	0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 1, 0, 0, 0, 0, 1,
+}
+
+// lineEvaluation is a line R0'y + R1'x + R2' = 0 divided by y at P, so that it is
+// the sparse element 1 + R0(-x/y)w + R1(1/y)vw of Fp12.
+type lineEvaluation struct {
+	R0, R1 E2
+}
+
+// Pair calculates the reduced pairing for a set of points
+// ∏ᵢ e(Pᵢ, Qᵢ).
+//
+// This function doesn't check that the inputs are in the correct subgroups.
+func (pr Pairing) Pair(P []*G1Affine, Q []*G2Affine) (*GTEl, error) {
+	res, err := pr.MillerLoop(P, Q)
+	if err != nil {
+		return nil, fmt.Errorf("miller loop: %w", err)
+	}
+	return pr.FinalExponentiation(res), nil
+}
+
+// PairingCheck calculates the reduced pairing for a set of points and asserts if the result is One
+// ∏ᵢ e(Pᵢ, Qᵢ) =? 1
+//
+// This function doesn't check that the inputs are in the correct subgroups.
+func (pr Pairing) PairingCheck(P []*G1Affine, Q []*G2Affine) error {
+	f, err := pr.Pair(P, Q)
+	if err != nil {
+		return err
+	}
+	pr.AssertIsEqual(f, pr.One())
+	return nil
+}
+
+func (pr Pairing) AssertIsEqual(x, y *GTEl) {
+	pr.Ext12.AssertIsEqual(x, y)
+}
+
+// AssertIsOnCurve asserts that P is on the BLS12-377 curve.
+func (pr Pairing) AssertIsOnCurve(P *G1Affine) {
+	pr.g1.AssertIsOnCurve(P)
+}
+
+// AssertIsOnTwist asserts that Q is on the twist of the BLS12-377 curve.
+func (pr Pairing) AssertIsOnTwist(Q *G2Affine) {
+	pr.g2.AssertIsOnTwist(Q)
+}
+
+// AssertIsOnG1 asserts that P is on the curve and in G1.
+func (pr Pairing) AssertIsOnG1(P *G1Affine) {
+	pr.g1.AssertIsOnG1(P)
+}
+
+// AssertIsOnG2 asserts that Q is on the twist and in G2.
+func (pr Pairing) AssertIsOnG2(Q *G2Affine) {
+	pr.g2.AssertIsOnG2(Q)
+}
+
+// MillerLoop computes the multi-Miller loop
+// ∏ᵢ { fᵢ_{x₀,Q}(P) }
+func (pr Pairing) MillerLoop(P []*G1Affine, Q []*G2Affine) (*GTEl, error) {
+	// check input size match
+	n := len(P)
+	if n == 0 || n != len(Q) {
+		return nil, errors.New("invalid inputs sizes")
+	}
+
+	res := pr.One()
+	Qacc := make([]*G2Affine, n)
+	yInv := make([]*baseEl, n)
+	xNegOverY := make([]*baseEl, n)
+	for k := 0; k < n; k++ {
+		Qacc[k] = Q[k]
+		// (x,0) is of order 2 and is not in G1, the circuit has no solution for it.
+		yInv[k] = pr.curveF.Inverse(&P[k].Y)
+		xNegOverY[k] = pr.curveF.Neg(pr.curveF.MulMod(&P[k].X, yInv[k]))
+	}
+	// eval returns the line evaluated at P[k].
+	eval := func(l *lineEvaluation, k int) (*E2, *E2) {
+		return pr.MulByElement(&l.R0, xNegOverY[k]), pr.MulByElement(&l.R1, yInv[k])
+	}
+
+	for i := 62; i >= 0; i-- {
+		// mutualize the square among n Miller loops
+		// (∏ᵢfᵢ)², skipped while res = 1
+		if i < 62 {
+			res = pr.Square(res)
+		}
+		for k := 0; k < n; k++ {
+			if loopCounter[i] == 0 {
+				// Qacc[k] ← 2Qacc[k] and l1 the tangent ℓ passing 2Qacc[k]
+				var l1 *lineEvaluation
+				Qacc[k], l1 = pr.doubleStep(Qacc[k])
+				// ℓ × res
+				c3, c4 := eval(l1, k)
+				res = pr.MulBy034(res, c3, c4)
+				continue
+			}
+			// Qacc[k] ← 2Qacc[k]+Q[k],
+			// l1 the line ℓ passing Qacc[k] and Q[k]
+			// l2 the line ℓ passing (Qacc[k]+Q[k]) and Qacc[k]
+			var l1, l2 *lineEvaluation
+			if i > 0 {
+				Qacc[k], l1, l2 = pr.doubleAndAddStep(Qacc[k], Q[k])
+			} else {
+				// last iteration, 2Qacc[k]+Q[k] is not needed
+				l1, l2 = pr.linesCompute(Qacc[k], Q[k])
+			}
+			// (ℓ × ℓ) × res
+			c3, c4 := eval(l1, k)
+			d3, d4 := eval(l2, k)
+			res = pr.MulBy01234(res, pr.Mul034By034(d3, d4, c3, c4))
+		}
+	}
+	return res, nil
+}
+
+// FinalExponentiation computes the exponentiation zᵈ where
+//
+//	d = (p¹²-1)/r = (p¹²-1)/Φ₁₂(p) ⋅ Φ₁₂(p)/r = (p⁶-1)(p²+1)(p⁴ - p² +1)/r
+//
+// we use instead
+//
+//	d=s ⋅ (p⁶-1)(p²+1)(p⁴ - p² +1)/r
+//
+// where s is the cofactor 3 (Hayashida et al.).
+func (pr Pairing) FinalExponentiation(z *GTEl) *GTEl {
+	// 1. Easy part
+	// (p⁶-1)(p²+1)
+	t0 := pr.DivUnchecked(pr.Conjugate(z), z)
+	result := pr.Mul(pr.FrobeniusSquare(t0), t0)
+
+	// 2. Hard part (up to permutation)
+	// 3(p⁴-p²+1)/r
+	// Daiki Hayashida, Kenichiro Hayasaka and Tadanori Teruya
+	// https://eprint.iacr.org/2020/875.pdf
+	t0 = pr.CyclotomicSquare(result)
+	t1 := pr.Expt(result)
+	t2 := pr.Conjugate(result)
+	t1 = pr.Mul(t1, t2)
+	t2 = pr.Expt(t1)
+	t1 = pr.Conjugate(t1)
+	t1 = pr.Mul(t1, t2)
+	t2 = pr.Expt(t1)
+	t1 = pr.Frobenius(t1)
+	t1 = pr.Mul(t1, t2)
+	result = pr.Mul(result, t0)
+	t0 = pr.Expt(t1)
+	t2 = pr.Expt(t0)
+	t0 = pr.FrobeniusSquare(t1)
+	t1 = pr.Conjugate(t1)
+	t1 = pr.Mul(t1, t2)
+	t1 = pr.Mul(t1, t0)
+	return pr.Mul(result, t1)
+}
+
+// doubleAndAddStep doubles p1 and adds p2 to the result in affine coordinates, and evaluates the line in Miller loop
+// https://eprint.iacr.org/2022/1162 (Section 6.1)
+func (pr Pairing) doubleAndAddStep(p1, p2 *G2Affine) (*G2Affine, *lineEvaluation, *lineEvaluation) {
+	line1, line2, l2, x3 := pr.addLines(p1, p2)
+
+	// compute x4 = λ2²-x1-x3
+	x4 := pr.Ext2.Square(l2)
+	x4 = pr.Ext2.Sub(x4, &p1.X)
+	x4 = pr.Ext2.Sub(x4, x3)
+
+	// compute y4 = λ2(x1 - x4)-y1
+	y4 := pr.Ext2.Sub(&p1.X, x4)
+	y4 = pr.Ext2.Mul(l2, y4)
+	y4 = pr.Ext2.Sub(y4, &p1.Y)
+
+	return &G2Affine{X: *x4, Y: *y4}, line1, line2
+}
+
+// linesCompute computes the lines that goes through p1 and p2, and (p1+p2) and p1 but does not compute 2p1+p2
+func (pr Pairing) linesCompute(p1, p2 *G2Affine) (*lineEvaluation, *lineEvaluation) {
+	line1, line2, _, _ := pr.addLines(p1, p2)
+	return line1, line2
+}
+
+// addLines returns the line through p1 and p2, the line through p1+p2 and p1, its
+// slope λ2 and the abscissa of p1+p2.
+func (pr Pairing) addLines(p1, p2 *G2Affine) (*lineEvaluation, *lineEvaluation, *E2, *E2) {
+	// compute λ1 = (y2-y1)/(x2-x1)
+	n := pr.Ext2.Sub(&p1.Y, &p2.Y)
+	d := pr.Ext2.Sub(&p1.X, &p2.X)
+	l1 := pr.Ext2.DivUnchecked(n, d)
+
+	// compute x3 =λ1²-x1-x2
+	x3 := pr.Ext2.Square(l1)
+	x3 = pr.Ext2.Sub(x3, &p1.X)
+	x3 = pr.Ext2.Sub(x3, &p2.X)
+
+	// omit y3 computation
+
+	// compute λ2 = -λ1-2y1/(x3-x1)
+	n = pr.Ext2.Double(&p1.Y)
+	d = pr.Ext2.Sub(x3, &p1.X)
+	l2 := pr.Ext2.DivUnchecked(n, d)
+	l2 = pr.Ext2.Add(l2, l1)
+	l2 = pr.Ext2.Neg(l2)
+
+	return pr.line(l1, p1), pr.line(l2, p1), l2, x3
+}
+
+// doubleStep doubles a point in affine coordinates, and evaluates the line in Miller loop
+// https://eprint.iacr.org/2022/1162 (Section 6.1)
+func (pr Pairing) doubleStep(p1 *G2Affine) (*G2Affine, *lineEvaluation) {
+	// λ = 3x²/2y
+	n := pr.Ext2.Square(&p1.X)
+	n = pr.Ext2.MulByConstElement(n, big.NewInt(3))
+	d := pr.Ext2.Double(&p1.Y)
+	λ := pr.Ext2.DivUnchecked(n, d)
+
+	// xr = λ²-2x
+	xr := pr.Ext2.Square(λ)
+	xr = pr.Ext2.Sub(xr, &p1.X)
+	xr = pr.Ext2.Sub(xr, &p1.X)
+
+	// yr = λ(x-xr)-y
+	yr := pr.Ext2.Sub(&p1.X, xr)
+	yr = pr.Ext2.Mul(λ, yr)
+	yr = pr.Ext2.Sub(yr, &p1.Y)
+
+	return &G2Affine{X: *xr, Y: *yr}, pr.line(λ, p1)
+}
+
+// line returns the line of slope λ through p.
+func (pr Pairing) line(λ *E2, p *G2Affine) *lineEvaluation {
+	r1 := pr.Ext2.Mul(λ, &p.X)
+	r1 = pr.Ext2.Sub(r1, &p.Y)
+	return &lineEvaluation{R0: *λ, R1: *r1}
+}
@@ -0,0 +1,194 @@
+package sw_bls12377
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fp"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+type e12Circuit struct {
+	A, B                                     E12
+	Mul, Square, Frobenius, FrobeniusSquare  E12
+	CyclotomicSquare, Expt, Div, Conjugation E12
+	// Cyclo is A in the cyclotomic subgroup.
+	Cyclo E12
+}
+
+func (c *e12Circuit) Define(api frontend.API) error {
+	e := NewExt12(api)
+	e.AssertIsEqual(e.Mul(&c.A, &c.B), &c.Mul)
+	e.AssertIsEqual(e.Square(&c.A), &c.Square)
+	e.AssertIsEqual(e.Frobenius(&c.A), &c.Frobenius)
+	e.AssertIsEqual(e.FrobeniusSquare(&c.A), &c.FrobeniusSquare)
+	e.AssertIsEqual(e.DivUnchecked(&c.A, &c.B), &c.Div)
+	e.AssertIsEqual(e.Conjugate(&c.A), &c.Conjugation)
+	e.AssertIsEqual(e.CyclotomicSquare(&c.Cyclo), &c.CyclotomicSquare)
+	e.AssertIsEqual(e.Expt(&c.Cyclo), &c.Expt)
+	return nil
+}
+
+func TestE12(t *testing.T) {
+	assert := test.NewAssert(t)
+	var a, b, mul, square, frob, frob2, div, conj, cyclo, cycloSquare, expt bls12377.E12
+	_, err := a.SetRandom()
+	assert.NoError(err)
+	_, err = b.SetRandom()
+	assert.NoError(err)
+	mul.Mul(&a, &b)
+	square.Square(&a)
+	frob.Frobenius(&a)
+	frob2.FrobeniusSquare(&a)
+	div.Inverse(&b).Mul(&div, &a)
+	conj.Conjugate(&a)
+	// a^((p⁶-1)(p²+1))
+	cyclo.Inverse(&a).Mul(&cyclo, &conj)
+	var t0 bls12377.E12
+	t0.FrobeniusSquare(&cyclo)
+	cyclo.Mul(&cyclo, &t0)
+	cycloSquare.CyclotomicSquare(&cyclo)
+	expt.Expt(&cyclo)
+
+	witness := e12Circuit{
+		A: FromE12(&a), B: FromE12(&b),
+		Mul: FromE12(&mul), Square: FromE12(&square),
+		Frobenius: FromE12(&frob), FrobeniusSquare: FromE12(&frob2),
+		Div: FromE12(&div), Conjugation: FromE12(&conj),
+		Cyclo: FromE12(&cyclo), CyclotomicSquare: FromE12(&cycloSquare), Expt: FromE12(&expt),
+	}
+	assert.NoError(test.IsSolved(&e12Circuit{}, &witness, ecc.BN254.ScalarField()))
+}
+
+type pairingCircuit struct {
+	P   [2]G1Affine
+	Q   [2]G2Affine
+	Res GTEl
+}
+
+func (c *pairingCircuit) Define(api frontend.API) error {
+	pr, err := NewPairing(api)
+	if err != nil {
+		return err
+	}
+	res, err := pr.Pair([]*G1Affine{&c.P[0], &c.P[1]}, []*G2Affine{&c.Q[0], &c.Q[1]})
+	if err != nil {
+		return err
+	}
+	pr.AssertIsEqual(res, &c.Res)
+	return nil
+}
+
+// randomPoints returns random points of G1 and G2.
+func randomPoints() (bls12377.G1Affine, bls12377.G2Affine) {
+	_, _, g1, g2 := bls12377.Generators()
+	s1, _ := rand.Int(rand.Reader, fr.Modulus())
+	s2, _ := rand.Int(rand.Reader, fr.Modulus())
+	g1.ScalarMultiplication(&g1, s1)
+	g2.ScalarMultiplication(&g2, s2)
+	return g1, g2
+}
+
+func TestPair(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping emulated pairing in short mode")
+	}
+	assert := test.NewAssert(t)
+	p0, q0 := randomPoints()
+	p1, q1 := randomPoints()
+	res, err := bls12377.Pair([]bls12377.G1Affine{p0, p1}, []bls12377.G2Affine{q0, q1})
+	assert.NoError(err)
+	witness := pairingCircuit{
+		P:   [2]G1Affine{NewG1Affine(p0), NewG1Affine(p1)},
+		Q:   [2]G2Affine{NewG2Affine(q0), NewG2Affine(q1)},
+		Res: NewGTEl(res),
+	}
+	assert.NoError(test.IsSolved(&pairingCircuit{}, &witness, ecc.BN254.ScalarField()))
+	// e(P, Q) ≠ e(P, -Q)
+	witness.Q[1] = NewG2Affine(*new(bls12377.G2Affine).Neg(&q1))
+	assert.Error(test.IsSolved(&pairingCircuit{}, &witness, ecc.BN254.ScalarField()))
+}
+
+type subgroupCircuit struct {
+	P G1Affine
+	Q G2Affine
+}
+
+func (c *subgroupCircuit) Define(api frontend.API) error {
+	pr, err := NewPairing(api)
+	if err != nil {
+		return err
+	}
+	pr.AssertIsOnG1(&c.P)
+	pr.AssertIsOnG2(&c.Q)
+	return nil
+}
+
+// notInG1 returns a point of the curve outside G1.
+func notInG1() bls12377.G1Affine {
+	var p bls12377.G1Affine
+	for {
+		p.X.SetRandom()
+		var y2 fp.Element
+		y2.Square(&p.X).Mul(&y2, &p.X).Add(&y2, new(fp.Element).SetOne())
+		if p.Y.Sqrt(&y2) != nil && !p.IsInSubGroup() {
+			return p
+		}
+	}
+}
+
+// notInG2 returns a point of the twist outside G2.
+func notInG2() bls12377.G2Affine {
+	var b bls12377.E2
+	b.A1.SetOne()
+	b.Inverse(&b)
+	var q bls12377.G2Affine
+	for {
+		q.X.SetRandom()
+		var y2 bls12377.E2
+		y2.Square(&q.X).Mul(&y2, &q.X).Add(&y2, &b)
+		if y2.Legendre() == 1 {
+			q.Y.Sqrt(&y2)
+			if q.IsOnCurve() && !q.IsInSubGroup() {
+				return q
+			}
+		}
+	}
+}
+
+func TestSubgroup(t *testing.T) {
+	assert := test.NewAssert(t)
+	p, q := randomPoints()
+	otherP, otherQ := notInG1(), notInG2()
+	offCurveP := p
+	offCurveP.Y.Double(&offCurveP.Y)
+	offTwistQ := q
+	offTwistQ.Y.Double(&offTwistQ.Y)
+	for _, tc := range []struct {
+		name  string
+		p     bls12377.G1Affine
+		q     bls12377.G2Affine
+		valid bool
+	}{
+		{"valid", p, q, true},
+		{"P not in G1", otherP, q, false},
+		{"Q not in G2", p, otherQ, false},
+		{"P off curve", offCurveP, q, false},
+		{"Q off twist", p, offTwistQ, false},
+	} {
+		tc := tc
+		assert.Run(func(assert *test.Assert) {
+			witness := subgroupCircuit{P: NewG1Affine(tc.p), Q: NewG2Affine(tc.q)}
+			err := test.IsSolved(&subgroupCircuit{}, &witness, ecc.BN254.ScalarField())
+			if tc.valid {
+				assert.NoError(err)
+			} else {
+				assert.Error(err)
+			}
+		}, tc.name)
+	}
+}
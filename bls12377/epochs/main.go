@@ -0,0 +1,63 @@
+// Command epochs simulates validator set changes over epochs, proves each hand over from a
+// set to the next one with valset.EpochCircuit and verifies the chain of proofs from the
+// genesis set.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+
+	"gnark/bls12377/valset"
+)
+
+func main() {
+	epochs := flag.Int("epochs", 4, "number of transitions")
+	validators := flag.Int("validators", 4, "validators in the set, all of them fill the circuit slots")
+	depth := flag.Int("depth", 4, "validator set tree depth")
+	rotate := flag.Int("rotate", 1, "validators replaced at each epoch")
+	seed := flag.Int64("seed", 1, "seed of keys, weights and participation")
+	flag.Parse()
+
+	sim, err := valset.NewSimulation(*depth, *validators, *seed)
+	if err != nil {
+		log.Panicf("NewSimulation err: %s", err)
+	}
+	ccs, err := frontend.Compile(ecc.BW6_761.ScalarField(), r1cs.NewBuilder, valset.NewEpochCircuit(*validators, *depth))
+	if err != nil {
+		log.Panicf("Compile err: %s", err)
+	}
+	pk, vk, err := groth16.Setup(ccs)
+	if err != nil {
+		log.Panicf("Failed to Setup err: %s", err)
+	}
+
+	genesis := sim.Commitment()
+	fmt.Printf("genesis set %s\n", genesis.String())
+	chain := make([]valset.Transition, *epochs)
+	for i := range chain {
+		tr, assignment, err := sim.Next(*rotate)
+		if err != nil {
+			log.Panicf("Next err: %s", err)
+		}
+		start := time.Now()
+		if err := valset.ProveTransition(ccs, pk, tr, assignment); err != nil {
+			log.Panicf("Prove epoch %d err: %s", tr.Epoch, err)
+		}
+		fmt.Printf("epoch %d: set %s proven in %s\n", tr.Epoch, tr.NewCommitment.String(), time.Since(start))
+		chain[i] = *tr
+	}
+
+	start := time.Now()
+	last, err := valset.VerifyChain(vk, genesis, 0, chain)
+	if err != nil {
+		log.Panicf("VerifyChain err: %s", err)
+	}
+	fmt.Printf("chain of %d transitions verified in %s, current set %s\n", len(chain), time.Since(start), last.String())
+}
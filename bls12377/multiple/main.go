@@ -48,7 +48,7 @@ func main() {
 			log.Panicf("GenerateKeyPair err: %s", err)
 		}
 
-		hm, err := bls12377_ecc.HashToG1(msg, g1Gen.Marshal())
+		hm, err := bls12377_ecc.HashToG1(msg, dst)
 		if err != nil {
 			log.Panicf("HashToG1 err: %s", err)
 		}
@@ -6,11 +6,14 @@ import (
 
 	bls12_377_ecc "github.com/consensys/gnark-crypto/ecc/bls12-377"
 	bls12_377_fr "github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+
+	bls_tools "gnark/aggregate/bls-tools"
 )
 
 var (
 	g1Gen bls12_377_ecc.G1Affine
 	g2Gen bls12_377_ecc.G2Affine
+	dst   = bls_tools.BLS12377G1.Ciphersuite().DST()
 )
 
 type PrivateKey struct {
@@ -60,7 +63,7 @@ func GenerateKeyPair() (*PrivateKey, *PublicKey, error) {
 // e(P, H(m)) = e(pk*G, H(m)) = e(G, pk*H(m)) = e(G, S)
 func Sign(privateKey *PrivateKey, msg []byte) (blsSignature *bls12_377_ecc.G1Affine, err error) {
 
-	hashPointG1, _ := bls12_377_ecc.HashToG1(msg, g1Gen.Marshal())
+	hashPointG1, _ := bls12_377_ecc.HashToG1(msg, dst)
 
 	sig := new(bls12_377_ecc.G1Affine).ScalarMultiplication(&hashPointG1, privateKey.X)
 
@@ -81,7 +84,7 @@ func Verify(publicKey *PublicKey, sig, msg []byte) (bool, error) {
 	}
 
 	// e(P, H(m)) = e(H(m), P)
-	hashPointG1, _ := bls12_377_ecc.HashToG1(msg, g1Gen.Marshal())
+	hashPointG1, _ := bls12_377_ecc.HashToG1(msg, dst)
 	rp, err := bls12_377_ecc.Pair([]bls12_377_ecc.G1Affine{hashPointG1}, []bls12_377_ecc.G2Affine{*publicKey.P})
 	if err != nil {
 		return false, err
@@ -41,7 +41,7 @@ func main() {
 		log.Panic("GenerateKeyPair err: ", err)
 	}
 	msg := []byte("Sig Test")
-	hm, err := bls12377_ecc.HashToG1(msg, g1Gen.Marshal())
+	hm, err := bls12377_ecc.HashToG1(msg, dst)
 	if err != nil {
 		log.Panic("HashToG1 err: ", err)
 	}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12377_ecc "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark/test"
+
+	bls_tools "gnark/aggregate/bls-tools"
+	"gnark/internal/circuittest"
+)
+
+// otherDST is a domain separation tag the circuit messages are not hashed with.
+var otherDST = bls_tools.BLS12377G1.Ciphersuite().WithScheme(bls_tools.POP).DST()
+
+func assign(sig, hm *bls12377_ecc.G1Affine, pk *bls12377_ecc.G2Affine) *BlsCircuit {
+	var w BlsCircuit
+	w.Sig.Assign(sig)
+	w.G2.Assign(&g2Gen)
+	w.Hm.Assign(hm)
+	w.Pk.Assign(pk)
+	return &w
+}
+
+func TestCircuit(t *testing.T) {
+	assert := test.NewAssert(t)
+	privateKey, publicKey, err := GenerateKeyPair()
+	assert.NoError(err)
+	_, otherKey, err := GenerateKeyPair()
+	assert.NoError(err)
+
+	msg := []byte("Sig Test")
+	hm, err := bls12377_ecc.HashToG1(msg, dst)
+	assert.NoError(err)
+	sig, err := Sign(privateKey, msg)
+	assert.NoError(err)
+	otherHm, err := bls12377_ecc.HashToG1([]byte("Other Test"), dst)
+	assert.NoError(err)
+	otherDSTHm, err := bls12377_ecc.HashToG1(msg, otherDST)
+	assert.NoError(err)
+	otherDSTSig := new(bls12377_ecc.G1Affine).ScalarMultiplication(&otherDSTHm, privateKey.X)
+	offCurveSig := *sig
+	offCurveSig.Y.Double(&offCurveSig.Y)
+
+	cases := []circuittest.Case{
+		{Name: "valid", Witness: assign(sig, &hm, publicKey.P), Valid: true},
+		{Name: "wrong message", Witness: assign(sig, &otherHm, publicKey.P), Valid: false},
+		{Name: "wrong key", Witness: assign(sig, &hm, otherKey.P), Valid: false},
+		{Name: "identity signature", Witness: assign(&bls12377_ecc.G1Affine{}, &hm, publicKey.P), Valid: false},
+		{Name: "off-curve signature", Witness: assign(&offCurveSig, &hm, publicKey.P), Valid: false},
+		{Name: "other DST", Witness: assign(otherDSTSig, &hm, publicKey.P), Valid: false},
+	}
+	circuittest.Solve(assert, &BlsCircuit{}, ecc.BW6_761, cases)
+	// every backend solves the witnesses, or proves them with the prover_checks build tag
+	circuittest.CheckBackends(assert, &BlsCircuit{}, ecc.BW6_761, cases)
+}
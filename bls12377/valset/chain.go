@@ -0,0 +1,174 @@
+package valset
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377"
+	bls12377fr "github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bw6-761/fr"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
+)
+
+// Transition is the proof of the hand over at Epoch from the set of commitment OldCommitment
+// to the set of commitment NewCommitment.
+type Transition struct {
+	Epoch         uint64
+	OldCommitment fr.Element
+	NewCommitment fr.Element
+	Proof         groth16.Proof
+}
+
+// ProveTransition proves the assignment of a transition and returns its proof.
+func ProveTransition(ccs constraint.ConstraintSystem, pk groth16.ProvingKey, tr *Transition, assignment *EpochCircuit) error {
+	w, err := frontend.NewWitness(assignment, ecc.BW6_761.ScalarField())
+	if err != nil {
+		return err
+	}
+	proof, err := groth16.Prove(ccs, pk, w)
+	if err != nil {
+		return err
+	}
+	tr.Proof = proof
+	return nil
+}
+
+// VerifyTransition verifies the proof of a transition, hashing its message natively.
+func VerifyTransition(vk groth16.VerifyingKey, tr *Transition) error {
+	w, err := EpochPublicWitness(tr.Epoch, &tr.OldCommitment, &tr.NewCommitment)
+	if err != nil {
+		return err
+	}
+	return groth16.Verify(tr.Proof, vk, w)
+}
+
+// VerifyChain verifies that transitions hand over from the trusted set of commitment trusted at
+// consecutive epochs starting at epoch, and returns the commitment of the last set.
+func VerifyChain(vk groth16.VerifyingKey, trusted fr.Element, epoch uint64, transitions []Transition) (fr.Element, error) {
+	current := trusted
+	for i := range transitions {
+		tr := &transitions[i]
+		if tr.Epoch != epoch+uint64(i) {
+			return fr.Element{}, fmt.Errorf("transition %d is for epoch %d, expected %d", i, tr.Epoch, epoch+uint64(i))
+		}
+		if !tr.OldCommitment.Equal(&current) {
+			return fr.Element{}, fmt.Errorf("transition of epoch %d does not start from the current set", tr.Epoch)
+		}
+		if err := VerifyTransition(vk, tr); err != nil {
+			return fr.Element{}, fmt.Errorf("transition of epoch %d: %w", tr.Epoch, err)
+		}
+		current = tr.NewCommitment
+	}
+	return current, nil
+}
+
+// Simulation runs a validator set of known secret keys through epochs, rotating some
+// validators at each transition. All validators of the set fill the slots of the circuit.
+type Simulation struct {
+	rng   *rand.Rand
+	size  int
+	tree  *Tree
+	sks   []*big.Int
+	vs    []Validator
+	epoch uint64
+}
+
+// NewSimulation returns a simulation of a set of size validators with random weights in a
+// tree of given depth, starting at epoch 0. Keys and weights are derived from seed.
+func NewSimulation(depth, size int, seed int64) (*Simulation, error) {
+	s := &Simulation{
+		rng:  rand.New(rand.NewSource(seed)),
+		size: size,
+		sks:  make([]*big.Int, size),
+		vs:   make([]Validator, size),
+	}
+	for i := range s.vs {
+		s.newValidator(i)
+	}
+	tree, err := Build(depth, s.vs)
+	if err != nil {
+		return nil, err
+	}
+	s.tree = tree
+	return s, nil
+}
+
+func (s *Simulation) newValidator(i int) {
+	_, _, _, g2 := bls12377.Generators()
+	s.sks[i] = new(big.Int).Rand(s.rng, bls12377fr.Modulus())
+	s.vs[i].PublicKey.ScalarMultiplication(&g2, s.sks[i])
+	s.vs[i].Weight = uint64(1 + s.rng.Intn(100))
+}
+
+// Epoch returns the current epoch.
+func (s *Simulation) Epoch() uint64 {
+	return s.epoch
+}
+
+// Commitment returns the commitment to the current set.
+func (s *Simulation) Commitment() fr.Element {
+	return s.tree.Commitment()
+}
+
+// Next replaces rotate random validators by new ones, signs the transition to the new set
+// with a random quorum of the current set and returns the transition, without proof, and
+// its assignment.
+func (s *Simulation) Next(rotate int) (*Transition, *EpochCircuit, error) {
+	if rotate > s.size {
+		return nil, nil, fmt.Errorf("cannot rotate %d of %d validators", rotate, s.size)
+	}
+	current, err := Build(s.tree.depth, s.vs)
+	if err != nil {
+		return nil, nil, err
+	}
+	signers := append([]Validator(nil), s.vs...)
+	sks := append([]*big.Int(nil), s.sks...)
+	for _, i := range s.rng.Perm(s.size)[:rotate] {
+		s.newValidator(i)
+		if err := s.tree.Update(i, &s.vs[i]); err != nil {
+			return nil, nil, err
+		}
+	}
+	next := s.tree.Commitment()
+
+	// about 9 of 10 validators sign, all of them when it is not enough
+	participating := make([]bool, s.size)
+	var signed uint64
+	for i := range participating {
+		if participating[i] = s.rng.Intn(10) != 0; participating[i] {
+			signed += signers[i].Weight
+		}
+	}
+	if signed*EpochDenominator <= current.TotalStake()*EpochNumerator {
+		for i := range participating {
+			participating[i] = true
+		}
+	}
+	hm, err := EpochMessageHash(s.epoch, &next)
+	if err != nil {
+		return nil, nil, err
+	}
+	sk := new(big.Int)
+	for i := range participating {
+		if participating[i] {
+			sk.Add(sk, sks[i])
+		}
+	}
+	sig := new(bls12377.G1Affine).ScalarMultiplication(&hm, sk.Mod(sk, bls12377fr.Modulus()))
+
+	indices := make([]int, s.size)
+	for i := range indices {
+		indices[i] = i
+	}
+	assignment, err := current.AssignEpoch(s.epoch, &next, sig, signers, indices, participating)
+	if err != nil {
+		return nil, nil, err
+	}
+	tr := &Transition{Epoch: s.epoch, OldCommitment: current.Commitment(), NewCommitment: next}
+	s.epoch++
+	return tr, assignment, nil
+}
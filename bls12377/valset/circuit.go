@@ -0,0 +1,154 @@
+package valset
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/accumulator/merkle"
+	"github.com/consensys/gnark/std/algebra/native/sw_bls12377"
+	"github.com/consensys/gnark/std/hash"
+	"github.com/consensys/gnark/std/hash/mimc"
+
+	"gnark/circuits"
+)
+
+// AggregateCircuit verifies an aggregate signature over distinct messages
+// e(sig, g2) == ∏ e(H(m_i), pk_i) where each pk_i is a key of the validator set of root Root.
+// Signer indices are strictly increasing so a key is counted once.
+type AggregateCircuit struct {
+	Root frontend.Variable      `gnark:",public"`
+	Hm   []sw_bls12377.G1Affine `gnark:",public"`
+
+	Sig    sw_bls12377.G1Affine
+	Pk     []sw_bls12377.G2Affine
+	Weight []frontend.Variable
+	Index  []frontend.Variable
+	Path   [][]frontend.Variable
+}
+
+// NewAggregateCircuit returns an empty circuit for given number of signers in a tree of given depth.
+func NewAggregateCircuit(signers, depth int) *AggregateCircuit {
+	c := &AggregateCircuit{
+		Hm:     make([]sw_bls12377.G1Affine, signers),
+		Pk:     make([]sw_bls12377.G2Affine, signers),
+		Weight: make([]frontend.Variable, signers),
+		Index:  make([]frontend.Variable, signers),
+		Path:   make([][]frontend.Variable, signers),
+	}
+	for i := range c.Path {
+		c.Path[i] = make([]frontend.Variable, depth)
+	}
+	return c
+}
+
+// Define declares the circuit constraints.
+func (c *AggregateCircuit) Define(api frontend.API) error {
+	if err := assertAreMembers(api, c.Root, c.Pk, c.Weight, c.Index, c.Path); err != nil {
+		return err
+	}
+	circuits.AssertIsOnG1BLS12377(api, c.Sig)
+	P, Q := []sw_bls12377.G1Affine{c.Sig}, []sw_bls12377.G2Affine{negG2()}
+	for i := range c.Hm {
+		P, Q = append(P, c.Hm[i]), append(Q, c.Pk[i])
+	}
+	return pairingCheck(api, P, Q)
+}
+
+// assertAreMembers asserts that validators are members of the tree of given root at strictly
+// increasing indices.
+func assertAreMembers(api frontend.API, root frontend.Variable, pks []sw_bls12377.G2Affine, weights, indices []frontend.Variable, paths [][]frontend.Variable) error {
+	h, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	for i := range pks {
+		AssertIsMember(api, &h, root, &pks[i], weights[i], indices[i], paths[i])
+		if i > 0 {
+			api.AssertIsLessOrEqual(api.Add(indices[i-1], 1), indices[i])
+		}
+	}
+	return nil
+}
+
+// AssertIsMember asserts that the validator of key pk and given weight is at index in the
+// tree of given root, path holds siblings from the leaf up.
+func AssertIsMember(api frontend.API, h hash.FieldHasher, root frontend.Variable, pk *sw_bls12377.G2Affine, weight, index frontend.Variable, path []frontend.Variable) {
+	h.Reset()
+	h.Write(pk.X.A0, pk.X.A1, pk.Y.A0, pk.Y.A1, weight)
+	proof := merkle.MerkleProof{
+		RootHash: root,
+		Path:     append([]frontend.Variable{h.Sum()}, path...),
+	}
+	proof.VerifyProof(api, h, index)
+}
+
+// negG2 returns -g2 as a circuit constant.
+func negG2() sw_bls12377.G2Affine {
+	_, _, _, g2 := bls12377.Generators()
+	g2.Neg(&g2)
+	var res sw_bls12377.G2Affine
+	res.Assign(&g2)
+	return res
+}
+
+// pairingCheck asserts ∏ e(P_i, Q_i) == 1.
+func pairingCheck(api frontend.API, P []sw_bls12377.G1Affine, Q []sw_bls12377.G2Affine) error {
+	f, err := sw_bls12377.Pair(api, P, Q)
+	if err != nil {
+		return err
+	}
+	var one sw_bls12377.GT
+	one.SetOne()
+	f.AssertIsEqual(api, one)
+	return nil
+}
+
+// assignMembers assigns the validators at given strictly increasing indices of the tree.
+func (t *Tree) assignMembers(vs []Validator, indices []int, pks []sw_bls12377.G2Affine, weights, idx []frontend.Variable, paths [][]frontend.Variable) error {
+	if len(indices) != len(vs) {
+		return fmt.Errorf("got %d validators and %d indices", len(vs), len(indices))
+	}
+	if len(vs) == 0 {
+		return errors.New("no validator")
+	}
+	root := t.Root()
+	for i := range vs {
+		if i > 0 && indices[i] <= indices[i-1] {
+			return errors.New("indices must be strictly increasing")
+		}
+		p, err := t.Prove(indices[i])
+		if err != nil {
+			return err
+		}
+		if !p.Verify(root, &vs[i]) {
+			return fmt.Errorf("validator %d is not at index %d", i, indices[i])
+		}
+		pks[i].Assign(&vs[i].PublicKey)
+		weights[i] = vs[i].Weight
+		idx[i] = indices[i]
+		for l := range p.Path {
+			paths[i][l] = p.Path[l]
+		}
+	}
+	return nil
+}
+
+// AssignAggregate returns the assignment of an aggregate signature by the validators at
+// given strictly increasing indices of the tree, vs[i] signing the message hashed to hms[i].
+func (t *Tree) AssignAggregate(sig *bls12377.G1Affine, hms []bls12377.G1Affine, vs []Validator, indices []int) (*AggregateCircuit, error) {
+	if len(hms) != len(vs) {
+		return nil, fmt.Errorf("got %d messages and %d validators", len(hms), len(vs))
+	}
+	c := NewAggregateCircuit(len(vs), t.depth)
+	if err := t.assignMembers(vs, indices, c.Pk, c.Weight, c.Index, c.Path); err != nil {
+		return nil, err
+	}
+	c.Root = t.Root()
+	c.Sig.Assign(sig)
+	for i := range hms {
+		c.Hm[i].Assign(&hms[i])
+	}
+	return c, nil
+}
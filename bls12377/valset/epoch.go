@@ -0,0 +1,170 @@
+package valset
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bw6-761/fr"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/native/sw_bls12377"
+	"github.com/consensys/gnark/std/hash/mimc"
+
+	bls_tools "gnark/aggregate/bls-tools"
+	"gnark/circuits"
+)
+
+// EpochDST is the domain separation tag of epoch transition signatures.
+var EpochDST = bls_tools.BLS12377G1.Ciphersuite().DST()
+
+// Quorum of an epoch transition, signers hold more than two thirds of the stake.
+const (
+	EpochNumerator   = 2
+	EpochDenominator = 3
+)
+
+// Commitment returns the commitment to the validator set of the tree, MiMC(root, total stake).
+func (t *Tree) Commitment() fr.Element {
+	var total fr.Element
+	total.SetUint64(t.total)
+	return mimcHash(t.Root(), total)
+}
+
+// EpochMessage returns the message signed by the set of an epoch to hand over to the set of
+// commitment next: the epoch as 8 bytes big endian followed by the commitment bytes.
+func EpochMessage(epoch uint64, next *fr.Element) []byte {
+	b := next.Bytes()
+	msg := make([]byte, 8, 8+len(b))
+	binary.BigEndian.PutUint64(msg, epoch)
+	return append(msg, b[:]...)
+}
+
+// EpochMessageHash returns the epoch message hashed to G1.
+func EpochMessageHash(epoch uint64, next *fr.Element) (bls12377.G1Affine, error) {
+	return bls12377.HashToG1(EpochMessage(epoch, next), EpochDST)
+}
+
+// EpochCircuit verifies the transition of epoch Epoch from the validator set of commitment
+// OldCommitment to the set of commitment NewCommitment: participating validators of the
+// current set, holding more than EpochNumerator/EpochDenominator of its stake, signed
+// EpochMessage(Epoch, NewCommitment).
+//
+// The set commitment binds the root and the total stake, both are private. Hash to G1 has
+// no circuit gadget in gnark v0.9.0, so Hm is public and the verifier checks
+// Hm == EpochMessageHash(Epoch, NewCommitment) natively, VerifyTransition does it.
+type EpochCircuit struct {
+	OldCommitment frontend.Variable    `gnark:",public"`
+	NewCommitment frontend.Variable    `gnark:",public"`
+	Epoch         frontend.Variable    `gnark:",public"`
+	Hm            sw_bls12377.G1Affine `gnark:",public"`
+
+	Root       frontend.Variable
+	TotalStake frontend.Variable
+	Sig        sw_bls12377.G1Affine
+	Pk         []sw_bls12377.G2Affine
+	Weight     []frontend.Variable
+	Bits       []frontend.Variable
+	Index      []frontend.Variable
+	Path       [][]frontend.Variable
+}
+
+// NewEpochCircuit returns an empty circuit for given number of slots in a tree of given depth.
+func NewEpochCircuit(slots, depth int) *EpochCircuit {
+	c := &EpochCircuit{
+		Pk:     make([]sw_bls12377.G2Affine, slots),
+		Weight: make([]frontend.Variable, slots),
+		Bits:   make([]frontend.Variable, slots),
+		Index:  make([]frontend.Variable, slots),
+		Path:   make([][]frontend.Variable, slots),
+	}
+	for i := range c.Path {
+		c.Path[i] = make([]frontend.Variable, depth)
+	}
+	return c
+}
+
+// Define declares the circuit constraints.
+func (c *EpochCircuit) Define(api frontend.API) error {
+	h, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	h.Write(c.Root, c.TotalStake)
+	api.AssertIsEqual(h.Sum(), c.OldCommitment)
+	api.ToBinary(c.Epoch, 64)
+	// gnark's Groth16 setup gives a public input of no constraint a zero point in the
+	// verifying key, any value of it verifies. NewCommitment enters no other constraint.
+	api.AssertIsEqual(c.NewCommitment, c.NewCommitment)
+
+	if err := assertAreMembers(api, c.Root, c.Pk, c.Weight, c.Index, c.Path); err != nil {
+		return err
+	}
+	circuits.AssertIsOnG1BLS12377(api, c.Sig)
+	api.ToBinary(c.TotalStake, 64)
+	agg, signed := aggregateParticipants(api, c.Pk, c.Weight, c.Bits)
+	assertQuorum(api, signed, c.TotalStake, EpochNumerator, EpochDenominator)
+	return pairingCheck(api, []sw_bls12377.G1Affine{c.Sig, c.Hm}, []sw_bls12377.G2Affine{negG2(), agg})
+}
+
+// AssignEpoch returns the assignment of the transition of given epoch from the set of the tree
+// to the set of commitment next, signed by the validators at given strictly increasing
+// indices for which participating is set.
+func (t *Tree) AssignEpoch(epoch uint64, next *fr.Element, sig *bls12377.G1Affine, vs []Validator, indices []int, participating []bool) (*EpochCircuit, error) {
+	if len(participating) != len(vs) {
+		return nil, fmt.Errorf("got %d validators and %d participation bits", len(vs), len(participating))
+	}
+	hm, err := EpochMessageHash(epoch, next)
+	if err != nil {
+		return nil, err
+	}
+	c := NewEpochCircuit(len(vs), t.depth)
+	if err := t.assignMembers(vs, indices, c.Pk, c.Weight, c.Index, c.Path); err != nil {
+		return nil, err
+	}
+	c.OldCommitment = t.Commitment()
+	c.NewCommitment = *next
+	c.Epoch = epoch
+	c.Hm.Assign(&hm)
+	c.Root = t.Root()
+	c.TotalStake = t.TotalStake()
+	c.Sig.Assign(sig)
+	for i := range participating {
+		c.Bits[i] = 0
+		if participating[i] {
+			c.Bits[i] = 1
+		}
+	}
+	return c, nil
+}
+
+// EpochPublicWitness returns the public witness of a transition.
+func EpochPublicWitness(epoch uint64, old, next *fr.Element) (witness.Witness, error) {
+	hm, err := EpochMessageHash(epoch, next)
+	if err != nil {
+		return nil, err
+	}
+	return epochPublicWitness(epoch, old, next, &hm)
+}
+
+func epochPublicWitness(epoch uint64, old, next *fr.Element, hm *bls12377.G1Affine) (witness.Witness, error) {
+	w, err := witness.New(ecc.BW6_761.ScalarField())
+	if err != nil {
+		return nil, err
+	}
+	// values in the order of the public fields of EpochCircuit, an assignment without slots
+	// would not be parsed
+	values := make(chan any, 5)
+	values <- *old
+	values <- *next
+	values <- epoch
+	values <- hm.X.BigInt(new(big.Int))
+	values <- hm.Y.BigInt(new(big.Int))
+	close(values)
+	if err := w.Fill(5, 0, values); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
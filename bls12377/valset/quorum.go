@@ -0,0 +1,137 @@
+package valset
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/native/sw_bls12377"
+
+	"gnark/circuits"
+)
+
+// aggregationOffset starts the in-circuit sum of participating keys, so that incomplete
+// affine additions never add a key to the point at infinity. Its discrete logarithm is
+// unknown, no key set can make the sum hit it.
+var aggregationOffset bls12377.G2Affine
+
+func init() {
+	var err error
+	aggregationOffset, err = bls12377.HashToG2([]byte("aggregation offset"), []byte("VALSET_BLS12377G2_XMD:SHA-256_SSWU_RO_"))
+	if err != nil {
+		panic(err)
+	}
+}
+
+// QuorumCircuit verifies an aggregate signature over a single message
+// e(sig, g2) == e(H(m), Σ bits_i · pk_i) by validators of the set of root Root, and that
+// participating validators hold more than Numerator/Denominator of TotalStake:
+//
+//	Σ bits_i · weight_i · Denominator > TotalStake · Numerator
+//
+// Each slot holds a member of the set at strictly increasing indices, Bits selects the
+// participating ones. Weights, TotalStake, Numerator and Denominator are 64 bit integers.
+// TotalStake is not derived from Root, the verifier takes it along with the root.
+//
+// It is a circuit of its own rather than a mode of bls12377/aggregate/dynamic: that circuit
+// verifies distinct messages signed by every key it holds, with no participation to weigh
+// and no commitment to bind weights to keys. A quorum over one message sums the keys of
+// participants, and weights are committed with the keys in the tree of Root.
+type QuorumCircuit struct {
+	Root        frontend.Variable    `gnark:",public"`
+	TotalStake  frontend.Variable    `gnark:",public"`
+	Numerator   frontend.Variable    `gnark:",public"`
+	Denominator frontend.Variable    `gnark:",public"`
+	Hm          sw_bls12377.G1Affine `gnark:",public"`
+
+	Sig    sw_bls12377.G1Affine
+	Pk     []sw_bls12377.G2Affine
+	Weight []frontend.Variable
+	Bits   []frontend.Variable
+	Index  []frontend.Variable
+	Path   [][]frontend.Variable
+}
+
+// NewQuorumCircuit returns an empty circuit for given number of slots in a tree of given depth.
+func NewQuorumCircuit(slots, depth int) *QuorumCircuit {
+	c := &QuorumCircuit{
+		Pk:     make([]sw_bls12377.G2Affine, slots),
+		Weight: make([]frontend.Variable, slots),
+		Bits:   make([]frontend.Variable, slots),
+		Index:  make([]frontend.Variable, slots),
+		Path:   make([][]frontend.Variable, slots),
+	}
+	for i := range c.Path {
+		c.Path[i] = make([]frontend.Variable, depth)
+	}
+	return c
+}
+
+// Define declares the circuit constraints.
+func (c *QuorumCircuit) Define(api frontend.API) error {
+	if err := assertAreMembers(api, c.Root, c.Pk, c.Weight, c.Index, c.Path); err != nil {
+		return err
+	}
+	for _, v := range []frontend.Variable{c.TotalStake, c.Numerator, c.Denominator} {
+		api.ToBinary(v, 64)
+	}
+
+	circuits.AssertIsOnG1BLS12377(api, c.Sig)
+	agg, signed := aggregateParticipants(api, c.Pk, c.Weight, c.Bits)
+	assertQuorum(api, signed, c.TotalStake, c.Numerator, c.Denominator)
+	return pairingCheck(api, []sw_bls12377.G1Affine{c.Sig, c.Hm}, []sw_bls12377.G2Affine{negG2(), agg})
+}
+
+// aggregateParticipants returns the sum of keys and the sum of 64 bit weights of the
+// validators for which bits are set.
+func aggregateParticipants(api frontend.API, pks []sw_bls12377.G2Affine, weights, bits []frontend.Variable) (sw_bls12377.G2Affine, frontend.Variable) {
+	var offset, agg sw_bls12377.G2Affine
+	offset.Assign(&aggregationOffset)
+	agg = offset
+	var signed frontend.Variable = 0
+	for i := range pks {
+		api.AssertIsBoolean(bits[i])
+		api.ToBinary(weights[i], 64)
+		sum := agg
+		sum.AddAssign(api, pks[i])
+		agg.Select(api, bits[i], sum, agg)
+		signed = api.Add(signed, api.Mul(bits[i], weights[i]))
+	}
+	agg.AddAssign(api, *new(sw_bls12377.G2Affine).Neg(api, offset))
+	return agg, signed
+}
+
+// assertQuorum asserts signed · denominator > total · numerator.
+func assertQuorum(api frontend.API, signed, total, numerator, denominator frontend.Variable) {
+	api.AssertIsLessOrEqual(api.Add(api.Mul(total, numerator), 1), api.Mul(signed, denominator))
+}
+
+// AssignQuorum returns the assignment of an aggregate signature over the message hashed to hm
+// by the validators at given strictly increasing indices of the tree for which participating
+// is set, with threshold numerator/denominator of the total stake.
+func (t *Tree) AssignQuorum(sig, hm *bls12377.G1Affine, vs []Validator, indices []int, participating []bool, numerator, denominator uint64) (*QuorumCircuit, error) {
+	if len(participating) != len(vs) {
+		return nil, fmt.Errorf("got %d validators and %d participation bits", len(vs), len(participating))
+	}
+	if denominator == 0 {
+		return nil, errors.New("denominator must be positive")
+	}
+	c := NewQuorumCircuit(len(vs), t.depth)
+	if err := t.assignMembers(vs, indices, c.Pk, c.Weight, c.Index, c.Path); err != nil {
+		return nil, err
+	}
+	c.Root = t.Root()
+	c.TotalStake = t.TotalStake()
+	c.Numerator = numerator
+	c.Denominator = denominator
+	c.Hm.Assign(hm)
+	c.Sig.Assign(sig)
+	for i := range participating {
+		c.Bits[i] = 0
+		if participating[i] {
+			c.Bits[i] = 1
+		}
+	}
+	return c, nil
+}
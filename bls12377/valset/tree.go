@@ -0,0 +1,233 @@
+// Package valset commits to a BLS12-377 validator set with a MiMC Merkle tree over the
+// BW6-761 scalar field, so that aggregate signature circuits prove membership of each
+// signer under a public root instead of taking every key of the set as input.
+//
+// A validator is serialized as its key coordinates X.A0, X.A1, Y.A0, Y.A1, which are BW6-761
+// scalars, followed by its stake weight, and hashed with MiMC into the leaf data. Tree
+// hashing follows gnark std/accumulator/merkle: a leaf is MiMC(data) and a node is
+// MiMC(left, right). Empty leaves have data zero.
+package valset
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fp"
+	"github.com/consensys/gnark-crypto/ecc/bw6-761/fr"
+	"github.com/consensys/gnark-crypto/ecc/bw6-761/fr/mimc"
+)
+
+// MaxDepth bounds the tree depth.
+const MaxDepth = 32
+
+// Validator is a member of the set.
+type Validator struct {
+	PublicKey bls12377.G2Affine
+	Weight    uint64
+}
+
+// LeafData returns the leaf data of a validator.
+func LeafData(v *Validator) fr.Element {
+	data := make([]fr.Element, 5)
+	pk := &v.PublicKey
+	for i, c := range []*fp.Element{&pk.X.A0, &pk.X.A1, &pk.Y.A0, &pk.Y.A1} {
+		data[i].SetBigInt(c.BigInt(new(big.Int)))
+	}
+	data[4].SetUint64(v.Weight)
+	return mimcHash(data...)
+}
+
+// mimcHash returns MiMC of the elements.
+func mimcHash(elements ...fr.Element) fr.Element {
+	h := mimc.NewMiMC()
+	for i := range elements {
+		b := elements[i].Bytes()
+		h.Write(b[:])
+	}
+	var res fr.Element
+	res.SetBytes(h.Sum(nil))
+	return res
+}
+
+// Tree is an incremental Merkle tree of fixed depth over validators. Validators are appended
+// at the next free index and can be replaced or removed in place.
+type Tree struct {
+	depth int
+	// weights of the leaves and their sum
+	weights []uint64
+	total   uint64
+	// levels[0] holds leaves and levels[depth] the root, nodes beyond the slice length are
+	// roots of empty subtrees.
+	levels [][]fr.Element
+	// zeros[i] is the root of an empty subtree of height i.
+	zeros []fr.Element
+}
+
+// NewTree returns an empty tree of given depth, holding up to 2^depth validators.
+func NewTree(depth int) (*Tree, error) {
+	if depth < 1 || depth > MaxDepth {
+		return nil, fmt.Errorf("depth must be between 1 and %d", MaxDepth)
+	}
+	t := &Tree{
+		depth:  depth,
+		levels: make([][]fr.Element, depth+1),
+		zeros:  make([]fr.Element, depth+1),
+	}
+	t.zeros[0] = mimcHash(fr.Element{})
+	for i := 1; i <= depth; i++ {
+		t.zeros[i] = mimcHash(t.zeros[i-1], t.zeros[i-1])
+	}
+	return t, nil
+}
+
+// Build returns a tree of given depth holding the validators at indices 0 to len(vs)-1.
+func Build(depth int, vs []Validator) (*Tree, error) {
+	t, err := NewTree(depth)
+	if err != nil {
+		return nil, err
+	}
+	if len(vs) > 1<<depth {
+		return nil, fmt.Errorf("%d validators exceed capacity %d", len(vs), 1<<depth)
+	}
+	t.levels[0] = make([]fr.Element, len(vs))
+	t.weights = make([]uint64, len(vs))
+	for i := range vs {
+		if err := t.setWeight(i, vs[i].Weight); err != nil {
+			return nil, err
+		}
+		t.levels[0][i] = mimcHash(LeafData(&vs[i]))
+	}
+	for l := 1; l <= depth; l++ {
+		n := (len(t.levels[l-1]) + 1) / 2
+		t.levels[l] = make([]fr.Element, n)
+		for i := 0; i < n; i++ {
+			t.levels[l][i] = mimcHash(t.node(l-1, 2*i), t.node(l-1, 2*i+1))
+		}
+	}
+	return t, nil
+}
+
+// Depth returns the depth of the tree.
+func (t *Tree) Depth() int {
+	return t.depth
+}
+
+// Len returns the index of the next appended validator.
+func (t *Tree) Len() int {
+	return len(t.levels[0])
+}
+
+// Root returns the root of the tree.
+func (t *Tree) Root() fr.Element {
+	return t.node(t.depth, 0)
+}
+
+// TotalStake returns the sum of the weights of the validators.
+func (t *Tree) TotalStake() uint64 {
+	return t.total
+}
+
+func (t *Tree) node(level, i int) fr.Element {
+	if i < len(t.levels[level]) {
+		return t.levels[level][i]
+	}
+	return t.zeros[level]
+}
+
+// Append adds a validator at index Len and returns its index.
+func (t *Tree) Append(v *Validator) (int, error) {
+	i := t.Len()
+	if i == 1<<t.depth {
+		return 0, errors.New("tree is full")
+	}
+	t.weights = append(t.weights, 0)
+	if err := t.setWeight(i, v.Weight); err != nil {
+		t.weights = t.weights[:i]
+		return 0, err
+	}
+	t.levels[0] = append(t.levels[0], fr.Element{})
+	t.set(i, mimcHash(LeafData(v)))
+	return i, nil
+}
+
+// Update replaces the validator at index i.
+func (t *Tree) Update(i int, v *Validator) error {
+	if i < 0 || i >= t.Len() {
+		return fmt.Errorf("index %d out of range [0, %d)", i, t.Len())
+	}
+	if err := t.setWeight(i, v.Weight); err != nil {
+		return err
+	}
+	t.set(i, mimcHash(LeafData(v)))
+	return nil
+}
+
+// Remove empties the leaf at index i, no validator has a membership proof at i afterwards.
+func (t *Tree) Remove(i int) error {
+	if i < 0 || i >= t.Len() {
+		return fmt.Errorf("index %d out of range [0, %d)", i, t.Len())
+	}
+	t.total -= t.weights[i]
+	t.weights[i] = 0
+	t.set(i, t.zeros[0])
+	return nil
+}
+
+// setWeight sets the weight of leaf i and updates the total stake.
+func (t *Tree) setWeight(i int, w uint64) error {
+	total := t.total - t.weights[i]
+	if total+w < total {
+		return errors.New("total stake overflows 64 bits")
+	}
+	t.weights[i], t.total = w, total+w
+	return nil
+}
+
+// set sets leaf i and updates its path to the root.
+func (t *Tree) set(i int, leaf fr.Element) {
+	t.levels[0][i] = leaf
+	for l := 1; l <= t.depth; l++ {
+		i /= 2
+		if i == len(t.levels[l]) {
+			t.levels[l] = append(t.levels[l], fr.Element{})
+		}
+		t.levels[l][i] = mimcHash(t.node(l-1, 2*i), t.node(l-1, 2*i+1))
+	}
+}
+
+// Proof is a membership proof of the validator at Index, Path holds siblings from the leaf up.
+type Proof struct {
+	Index int
+	Path  []fr.Element
+}
+
+// Prove returns the membership proof of index i.
+func (t *Tree) Prove(i int) (*Proof, error) {
+	if i < 0 || i >= t.Len() {
+		return nil, fmt.Errorf("index %d out of range [0, %d)", i, t.Len())
+	}
+	p := &Proof{Index: i, Path: make([]fr.Element, t.depth)}
+	for l := 0; l < t.depth; l++ {
+		p.Path[l] = t.node(l, i^1)
+		i /= 2
+	}
+	return p, nil
+}
+
+// Verify checks that v is at p.Index in the tree of given root.
+func (p *Proof) Verify(root fr.Element, v *Validator) bool {
+	if p.Index < 0 || p.Index>>len(p.Path) != 0 {
+		return false
+	}
+	node := mimcHash(LeafData(v))
+	for l := range p.Path {
+		if p.Index>>l&1 == 1 {
+			node = mimcHash(p.Path[l], node)
+		} else {
+			node = mimcHash(node, p.Path[l])
+		}
+	}
+	return node.Equal(&root)
+}
@@ -0,0 +1,436 @@
+package valset
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fp"
+	bls12377fr "github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bw6-761/fr"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/test"
+
+	bls_tools "gnark/aggregate/bls-tools"
+)
+
+var dst = bls_tools.BLS12377G1.Ciphersuite().DST()
+
+// testValidators returns n validators with their secret keys derived from seed, validator i
+// has weight i+1.
+func testValidators(n int, seed int64) ([]*big.Int, []Validator) {
+	rng := rand.New(rand.NewSource(seed))
+	_, _, _, g2 := bls12377.Generators()
+	sks := make([]*big.Int, n)
+	vs := make([]Validator, n)
+	for i := range sks {
+		sks[i] = new(big.Int).Rand(rng, bls12377fr.Modulus())
+		vs[i].PublicKey.ScalarMultiplication(&g2, sks[i])
+		vs[i].Weight = uint64(i + 1)
+	}
+	return sks, vs
+}
+
+// naiveRoot recomputes the root from all leaves.
+func naiveRoot(depth int, leaves []fr.Element) fr.Element {
+	layer := make([]fr.Element, 1<<depth)
+	for i := range layer {
+		layer[i] = mimcHash(fr.Element{})
+	}
+	copy(layer, leaves)
+	for len(layer) > 1 {
+		for i := 0; i < len(layer)/2; i++ {
+			layer[i] = mimcHash(layer[2*i], layer[2*i+1])
+		}
+		layer = layer[:len(layer)/2]
+	}
+	return layer[0]
+}
+
+func TestTree(t *testing.T) {
+	assert := test.NewAssert(t)
+	const depth = 4
+	_, vs := testValidators(11, 1)
+	leaves := make([]fr.Element, len(vs))
+	for i := range vs {
+		leaves[i] = mimcHash(LeafData(&vs[i]))
+	}
+
+	incremental, err := NewTree(depth)
+	assert.NoError(err)
+	for i := range vs {
+		idx, err := incremental.Append(&vs[i])
+		assert.NoError(err)
+		assert.Equal(i, idx)
+		root := incremental.Root()
+		expected := naiveRoot(depth, leaves[:i+1])
+		assert.True(root.Equal(&expected), "root after %d appends", i+1)
+	}
+	built, err := Build(depth, vs)
+	assert.NoError(err)
+	assert.Equal(incremental.Root(), built.Root())
+	assert.Equal(uint64(66), built.TotalStake())
+	assert.Equal(built.TotalStake(), incremental.TotalStake())
+
+	for i := range vs {
+		p, err := built.Prove(i)
+		assert.NoError(err)
+		assert.True(p.Verify(built.Root(), &vs[i]), "proof of %d", i)
+		assert.False(p.Verify(built.Root(), &vs[(i+1)%len(vs)]), "proof of %d with another validator", i)
+		other := vs[i]
+		other.Weight++
+		assert.False(p.Verify(built.Root(), &other), "proof of %d with another weight", i)
+	}
+
+	// update and removal change the root and invalidate old proofs
+	_, other := testValidators(1, 2)
+	old := built.Root()
+	oldProof, err := built.Prove(3)
+	assert.NoError(err)
+	assert.NoError(built.Update(3, &other[0]))
+	assert.Equal(uint64(66-4+1), built.TotalStake())
+	leaves[3] = mimcHash(LeafData(&other[0]))
+	root := built.Root()
+	expected := naiveRoot(depth, leaves)
+	assert.True(root.Equal(&expected))
+	assert.False(oldProof.Verify(root, &vs[3]))
+	p, err := built.Prove(3)
+	assert.NoError(err)
+	assert.True(p.Verify(root, &other[0]))
+	assert.NoError(built.Remove(3))
+	assert.Equal(uint64(66-4), built.TotalStake())
+	leaves[3] = mimcHash(fr.Element{})
+	root = built.Root()
+	expected = naiveRoot(depth, leaves)
+	assert.True(root.Equal(&expected))
+	assert.False(root.Equal(&old))
+
+	assert.Error(built.Update(11, &vs[0]))
+	assert.Error(built.Remove(-1))
+	_, err = built.Prove(11)
+	assert.Error(err)
+	_, err = Build(2, vs)
+	assert.Error(err, "validators beyond capacity")
+	_, err = NewTree(MaxDepth + 1)
+	assert.Error(err)
+
+	full, err := Build(2, vs[:4])
+	assert.NoError(err)
+	_, err = full.Append(&vs[4])
+	assert.Error(err, "append to a full tree")
+
+	heavy := vs[0]
+	heavy.Weight = ^uint64(0)
+	_, err = built.Append(&heavy)
+	assert.Error(err, "total stake overflow")
+	assert.Equal(11, built.Len())
+}
+
+// signAggregate returns an aggregate signature of the validators at indices over distinct messages.
+func signAggregate(t *testing.T, sks []*big.Int, vs []Validator, indices []int) (*bls12377.G1Affine, []bls12377.G1Affine, []Validator) {
+	var sig bls12377.G1Jac
+	hms := make([]bls12377.G1Affine, len(indices))
+	signers := make([]Validator, len(indices))
+	for i, idx := range indices {
+		hm, err := bls12377.HashToG1([]byte(fmt.Sprintf("message %d", i)), dst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		hms[i] = hm
+		signers[i] = vs[idx]
+		var s bls12377.G1Jac
+		s.FromAffine(&hm)
+		sig.AddAssign(s.ScalarMultiplication(&s, sks[idx]))
+	}
+	return new(bls12377.G1Affine).FromJacobian(&sig), hms, signers
+}
+
+// shiftOutOfG1 returns p plus a point of E(Fp) whose order divides the cofactor. The pairing
+// with a key of G2 does not change, only the subgroup check tells p from the result.
+func shiftOutOfG1(t *testing.T, p *bls12377.G1Affine) *bls12377.G1Affine {
+	for x := uint64(1); ; x++ {
+		var q bls12377.G1Affine
+		q.X.SetUint64(x)
+		var y2 fp.Element
+		y2.Square(&q.X).Mul(&y2, &q.X).Add(&y2, new(fp.Element).SetOne())
+		if q.Y.Sqrt(&y2) == nil {
+			continue
+		}
+		q.ScalarMultiplication(&q, bls12377fr.Modulus())
+		if q.IsInfinity() {
+			continue
+		}
+		res := new(bls12377.G1Affine).Add(p, &q)
+		if res.IsInSubGroup() || !res.IsOnCurve() {
+			t.Fatal("shifted point must be on the curve and out of G1")
+		}
+		return res
+	}
+}
+
+func TestAggregateCircuit(t *testing.T) {
+	assert := test.NewAssert(t)
+	const depth = 5
+	sks, vs := testValidators(20, 3)
+	tree, err := Build(depth, vs)
+	assert.NoError(err)
+	indices := []int{2, 7, 19}
+	sig, hms, signers := signAggregate(t, sks, vs, indices)
+	circuit := NewAggregateCircuit(len(indices), depth)
+
+	w, err := tree.AssignAggregate(sig, hms, signers, indices)
+	assert.NoError(err)
+	assert.NoError(test.IsSolved(circuit, w, ecc.BW6_761.ScalarField()))
+
+	// root of another set
+	_, others := testValidators(20, 4)
+	otherTree, err := Build(depth, others)
+	assert.NoError(err)
+	w, err = tree.AssignAggregate(sig, hms, signers, indices)
+	assert.NoError(err)
+	w.Root = otherTree.Root()
+	assert.Error(test.IsSolved(circuit, w, ecc.BW6_761.ScalarField()))
+
+	// key claimed at another index
+	w, err = tree.AssignAggregate(sig, hms, signers, indices)
+	assert.NoError(err)
+	w.Index[1] = 8
+	assert.Error(test.IsSolved(circuit, w, ecc.BW6_761.ScalarField()))
+
+	// signature out of G1
+	w, err = tree.AssignAggregate(shiftOutOfG1(t, sig), hms, signers, indices)
+	assert.NoError(err)
+	assert.Error(test.IsSolved(circuit, w, ecc.BW6_761.ScalarField()))
+
+	// signer outside the set
+	_, err = tree.AssignAggregate(sig, hms, []Validator{signers[0], others[1], signers[2]}, indices)
+	assert.Error(err)
+
+	// a key counted twice
+	indices = []int{2, 2, 7}
+	sig, hms, signers = signAggregate(t, sks, vs, indices)
+	_, err = tree.AssignAggregate(sig, hms, signers, indices)
+	assert.Error(err)
+	_, err = tree.AssignAggregate(sig, hms, signers, []int{2, 3, 7})
+	assert.Error(err)
+	w = NewAggregateCircuit(3, depth)
+	for i, idx := range indices {
+		p, err := tree.Prove(idx)
+		assert.NoError(err)
+		w.Hm[i].Assign(&hms[i])
+		w.Pk[i].Assign(&signers[i].PublicKey)
+		w.Weight[i] = signers[i].Weight
+		w.Index[i] = idx
+		for l := range p.Path {
+			w.Path[i][l] = p.Path[l]
+		}
+	}
+	w.Root = tree.Root()
+	w.Sig.Assign(sig)
+	assert.Error(test.IsSolved(circuit, w, ecc.BW6_761.ScalarField()))
+}
+
+// signQuorum returns a signature of the message by the validators at indices for which
+// participating is set.
+func signQuorum(t *testing.T, sks []*big.Int, vs []Validator, indices []int, participating []bool) (*bls12377.G1Affine, *bls12377.G1Affine, []Validator) {
+	hm, err := bls12377.HashToG1([]byte("block 42"), dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sk := new(big.Int)
+	slots := make([]Validator, len(indices))
+	for i, idx := range indices {
+		slots[i] = vs[idx]
+		if participating[i] {
+			sk.Add(sk, sks[idx])
+		}
+	}
+	sig := new(bls12377.G1Affine).ScalarMultiplication(&hm, sk.Mod(sk, bls12377fr.Modulus()))
+	return sig, &hm, slots
+}
+
+func TestQuorumCircuit(t *testing.T) {
+	assert := test.NewAssert(t)
+	const depth = 3
+	// weights 1 to 6, total 21
+	sks, vs := testValidators(6, 5)
+	tree, err := Build(depth, vs)
+	assert.NoError(err)
+	indices := []int{0, 3, 4, 5}
+	circuit := NewQuorumCircuit(len(indices), depth)
+	solve := func(participating []bool, numerator, denominator uint64) error {
+		sig, hm, slots := signQuorum(t, sks, vs, indices, participating)
+		w, err := tree.AssignQuorum(sig, hm, slots, indices, participating, numerator, denominator)
+		assert.NoError(err)
+		return test.IsSolved(circuit, w, ecc.BW6_761.ScalarField())
+	}
+
+	// 16 of 21 is above 2/3 and 5/7, 15 of 21 is exactly 5/7
+	assert.NoError(solve([]bool{true, true, true, true}, 2, 3))
+	assert.NoError(solve([]bool{true, true, true, true}, 5, 7))
+	assert.NoError(solve([]bool{false, true, true, true}, 2, 3))
+	assert.Error(solve([]bool{false, true, true, true}, 5, 7))
+	assert.Error(solve([]bool{true, false, true, true}, 2, 3))
+
+	// signature of a validator not marked as participating
+	sig, hm, slots := signQuorum(t, sks, vs, indices, []bool{true, true, true, true})
+	w, err := tree.AssignQuorum(sig, hm, slots, indices, []bool{false, true, true, true}, 2, 3)
+	assert.NoError(err)
+	assert.Error(test.IsSolved(circuit, w, ecc.BW6_761.ScalarField()))
+
+	// participant weight higher than committed
+	sig, hm, slots = signQuorum(t, sks, vs, indices, []bool{true, false, true, true})
+	w, err = tree.AssignQuorum(sig, hm, slots, indices, []bool{true, false, true, true}, 2, 3)
+	assert.NoError(err)
+	w.Weight[0] = 10
+	assert.Error(test.IsSolved(circuit, w, ecc.BW6_761.ScalarField()))
+
+	// signature out of G1
+	sig, hm, slots = signQuorum(t, sks, vs, indices, []bool{false, true, true, true})
+	w, err = tree.AssignQuorum(shiftOutOfG1(t, sig), hm, slots, indices, []bool{false, true, true, true}, 2, 3)
+	assert.NoError(err)
+	assert.Error(test.IsSolved(circuit, w, ecc.BW6_761.ScalarField()))
+
+	_, err = tree.AssignQuorum(sig, hm, slots, indices, []bool{true}, 2, 3)
+	assert.Error(err)
+	_, err = tree.AssignQuorum(sig, hm, slots, indices, []bool{true, false, true, true}, 2, 0)
+	assert.Error(err)
+}
+
+func TestEpochCircuit(t *testing.T) {
+	assert := test.NewAssert(t)
+	const depth, size = 2, 3
+	sim, err := NewSimulation(depth, size, 6)
+	assert.NoError(err)
+	circuit := NewEpochCircuit(size, depth)
+	var prev fr.Element
+	for i := 0; i < 2; i++ {
+		old := sim.Commitment()
+		tr, w, err := sim.Next(1)
+		assert.NoError(err)
+		assert.Equal(uint64(i), tr.Epoch)
+		assert.True(tr.OldCommitment.Equal(&old))
+		assert.False(tr.NewCommitment.Equal(&old), "rotation changes the set")
+		if i > 0 {
+			assert.True(tr.OldCommitment.Equal(&prev))
+		}
+		prev = tr.NewCommitment
+		assert.NoError(test.IsSolved(circuit, w, ecc.BW6_761.ScalarField()))
+
+		full, err := frontend.NewWitness(w, ecc.BW6_761.ScalarField())
+		assert.NoError(err)
+		public, err := full.Public()
+		assert.NoError(err)
+		expected, err := public.MarshalBinary()
+		assert.NoError(err)
+		public, err = EpochPublicWitness(tr.Epoch, &tr.OldCommitment, &tr.NewCommitment)
+		assert.NoError(err)
+		got, err := public.MarshalBinary()
+		assert.NoError(err)
+		assert.Equal(expected, got)
+	}
+
+	sks, vs := testValidators(size, 7)
+	tree, err := Build(depth, vs)
+	assert.NoError(err)
+	next := sim.Commitment()
+	indices := []int{0, 1, 2}
+	sign := func(epoch uint64, next *fr.Element, participating []bool) *bls12377.G1Affine {
+		hm, err := EpochMessageHash(epoch, next)
+		assert.NoError(err)
+		sk := new(big.Int)
+		for i := range participating {
+			if participating[i] {
+				sk.Add(sk, sks[i])
+			}
+		}
+		return new(bls12377.G1Affine).ScalarMultiplication(&hm, sk.Mod(sk, bls12377fr.Modulus()))
+	}
+
+	// weights 1, 2, 3: 5 of 6 is a quorum, 4 of 6 is not
+	solve := func(participating []bool) error {
+		w, err := tree.AssignEpoch(9, &next, sign(9, &next, participating), vs, indices, participating)
+		assert.NoError(err)
+		return test.IsSolved(circuit, w, ecc.BW6_761.ScalarField())
+	}
+	assert.NoError(solve([]bool{false, true, true}))
+	assert.Error(solve([]bool{true, false, true}))
+
+	// signature out of G1
+	participating := []bool{true, true, true}
+	w, err := tree.AssignEpoch(9, &next, shiftOutOfG1(t, sign(9, &next, participating)), vs, indices, participating)
+	assert.NoError(err)
+	assert.Error(test.IsSolved(circuit, w, ecc.BW6_761.ScalarField()))
+
+	// signature over another epoch or set
+	w, err = tree.AssignEpoch(9, &next, sign(10, &next, participating), vs, indices, participating)
+	assert.NoError(err)
+	assert.Error(test.IsSolved(circuit, w, ecc.BW6_761.ScalarField()))
+	other := tree.Commitment()
+	w, err = tree.AssignEpoch(9, &next, sign(9, &other, participating), vs, indices, participating)
+	assert.NoError(err)
+	assert.Error(test.IsSolved(circuit, w, ecc.BW6_761.ScalarField()))
+
+	// commitment to the set with another total stake
+	w, err = tree.AssignEpoch(9, &next, sign(9, &next, participating), vs, indices, participating)
+	assert.NoError(err)
+	w.TotalStake = 5
+	assert.Error(test.IsSolved(circuit, w, ecc.BW6_761.ScalarField()))
+}
+
+func TestChain(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping Groth16 setup in short mode")
+	}
+	assert := test.NewAssert(t)
+	const depth, size, epochs = 1, 2, 3
+	sim, err := NewSimulation(depth, size, 8)
+	assert.NoError(err)
+	ccs, err := frontend.Compile(ecc.BW6_761.ScalarField(), r1cs.NewBuilder, NewEpochCircuit(size, depth))
+	assert.NoError(err)
+	pk, vk, err := groth16.Setup(ccs)
+	assert.NoError(err)
+
+	genesis := sim.Commitment()
+	chain := make([]Transition, epochs)
+	for i := range chain {
+		tr, w, err := sim.Next(1)
+		assert.NoError(err)
+		assert.NoError(ProveTransition(ccs, pk, tr, w))
+		chain[i] = *tr
+	}
+	last, err := VerifyChain(vk, genesis, 0, chain)
+	assert.NoError(err)
+	expected := sim.Commitment()
+	assert.True(last.Equal(&expected))
+
+	_, err = VerifyChain(vk, genesis, 1, chain)
+	assert.Error(err, "chain starting at another epoch")
+	_, err = VerifyChain(vk, chain[0].NewCommitment, 0, chain)
+	assert.Error(err, "chain starting from another set")
+	_, err = VerifyChain(vk, genesis, 0, []Transition{chain[0], chain[2]})
+	assert.Error(err, "skipped transition")
+
+	// a proof does not hold for another next set or epoch
+	forged := []Transition{chain[0]}
+	forged[0].NewCommitment = chain[2].NewCommitment
+	_, err = VerifyChain(vk, genesis, 0, forged)
+	assert.Error(err)
+	forged[0] = chain[1]
+	forged[0].Epoch = 0
+	forged[0].OldCommitment = genesis
+	_, err = VerifyChain(vk, genesis, 0, forged)
+	assert.Error(err)
+
+	// nor for another next set with the message hash of the proven one
+	hm, err := EpochMessageHash(0, &chain[0].NewCommitment)
+	assert.NoError(err)
+	public, err := epochPublicWitness(0, &genesis, &chain[2].NewCommitment, &hm)
+	assert.NoError(err)
+	assert.Error(groth16.Verify(chain[0].Proof, vk, public))
+}
@@ -47,7 +47,7 @@ func main() {
 	}
 
 	msg := []byte("Sig Test")
-	hm, err := bls381.HashToG1(msg, g1Gen.Marshal())
+	hm, err := bls381.HashToG1(msg, dst)
 	if err != nil {
 		log.Panic("HashToG1 err: ", err)
 	}
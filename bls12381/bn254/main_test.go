@@ -0,0 +1,65 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	bls12381 "github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+	"github.com/consensys/gnark/test"
+
+	bls_tools "gnark/aggregate/bls-tools"
+	"gnark/internal/circuittest"
+)
+
+// otherDST is a domain separation tag the circuit messages are not hashed with.
+var otherDST = bls_tools.BLS12381G1.Ciphersuite().WithScheme(bls_tools.POP).DST()
+
+func assign(sig, hm *bls381.G1Affine, pk *bls381.G2Affine) *Circuit {
+	return &Circuit{
+		Sig: bls12381.NewG1Affine(*sig),
+		G2:  bls12381.NewG2Affine(g2Gen),
+		Hm:  bls12381.NewG1Affine(*hm),
+		Pk:  bls12381.NewG2Affine(*pk),
+	}
+}
+
+// testCases returns a valid witness and witnesses each breaking one check of the circuit.
+func testCases(assert *test.Assert) []circuittest.Case {
+	privateKey, publicKey, err := GenerateKeyPair()
+	assert.NoError(err)
+	_, otherKey, err := GenerateKeyPair()
+	assert.NoError(err)
+
+	msg := []byte("Sig Test")
+	hm, err := bls381.HashToG1(msg, dst)
+	assert.NoError(err)
+	sig := new(bls381.G1Affine).ScalarMultiplication(&hm, privateKey.X)
+	otherHm, err := bls381.HashToG1([]byte("Other Test"), dst)
+	assert.NoError(err)
+	otherDSTHm, err := bls381.HashToG1(msg, otherDST)
+	assert.NoError(err)
+	otherDSTSig := new(bls381.G1Affine).ScalarMultiplication(&otherDSTHm, privateKey.X)
+	offCurveSig := *sig
+	offCurveSig.Y.Double(&offCurveSig.Y)
+
+	return []circuittest.Case{
+		{Name: "valid", Witness: assign(sig, &hm, publicKey.P), Valid: true},
+		{Name: "wrong message", Witness: assign(sig, &otherHm, publicKey.P), Valid: false},
+		{Name: "wrong key", Witness: assign(sig, &hm, otherKey.P), Valid: false},
+		{Name: "identity signature", Witness: assign(&bls381.G1Affine{}, &hm, publicKey.P), Valid: false},
+		{Name: "off-curve signature", Witness: assign(&offCurveSig, &hm, publicKey.P), Valid: false},
+		{Name: "other DST", Witness: assign(otherDSTSig, &hm, publicKey.P), Valid: false},
+	}
+}
+
+// TestCircuit runs the circuit in the test engine, which checks the assertions shared by every
+// backend. Compiling the emulated pairings for each backend takes millions of constraints and is
+// left to TestCircuitBackends, built with the backends tag.
+func TestCircuit(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping emulated pairing circuit in short mode")
+	}
+	assert := test.NewAssert(t)
+	circuittest.Solve(assert, &Circuit{}, ecc.BN254, testCases(assert))
+}
@@ -0,0 +1,19 @@
+//go:build backends
+
+package main
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/test"
+
+	"gnark/internal/circuittest"
+)
+
+// TestCircuitBackends compiles the circuit for the Groth16 and PLONK backends and solves every
+// case of TestCircuit, or proves them with the prover_checks build tag.
+func TestCircuitBackends(t *testing.T) {
+	assert := test.NewAssert(t)
+	circuittest.CheckBackends(assert, &Circuit{}, ecc.BLS12_381, testCases(assert))
+}
@@ -0,0 +1,304 @@
+// Package synccommittee verifies an Ethereum light client sync committee update with
+// BLS12-381 signatures in a BN254 circuit.
+//
+// The circuit checks that the participating members of the current sync committee signed
+// the attested header, and that the next sync committee is the one committed in the
+// attested state:
+//
+//   - signing root = hash_tree_root(SigningData{hash_tree_root(header), domain}) where
+//     domain is computed from DOMAIN_SYNC_COMMITTEE, the fork version and the genesis
+//     validators root,
+//   - e(Σ bits_i · pk_i, H(signing root)) == e(g1, sig) with at least two thirds of the
+//     committee participating,
+//   - the SSZ root of the current committee, computed from its public keys compressed
+//     in circuit, is the public CurrentCommitteeRoot,
+//   - the SSZ root of the next committee public keys is the public NextCommitteeRoot and
+//     its Merkle branch leads to the state root of the attested header.
+//
+// Public keys are in G1 and signatures in G2 as in Ethereum. Hash to G2 is not available
+// in circuit, H(signing root) is a public input that the verifier recomputes from the
+// public SigningRoot with bls12-381 HashToG2 and DST.
+//
+// The package does not prove a mainnet committee and is not tested on mainnet data:
+//
+//   - The circuit takes any committee size but needs about 91M constraints for
+//     SyncCommitteeSize members, which gnark v0.9.0 cannot compile in a few GB of memory.
+//     Only committees of a few members are checked, with test.IsSolved.
+//   - The only fixture is a 4 member update in the light client API format, with keys and
+//     roots generated from a seed. No real mainnet update is stored.
+package synccommittee
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
+	"github.com/consensys/gnark/std/hash/sha2"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// SyncCommitteeSize is the number of members of a mainnet sync committee.
+const SyncCommitteeSize = 512
+
+// Generalized index of next_sync_committee in BeaconState from Altair to Deneb.
+const (
+	nextSyncCommitteeDepth = 5
+	nextSyncCommitteeIndex = 23
+)
+
+// domainSyncCommittee is DOMAIN_SYNC_COMMITTEE.
+var domainSyncCommittee = [4]byte{0x07, 0x00, 0x00, 0x00}
+
+// Circuit verifies a sync committee update for a committee of len(Pubkeys) members.
+type Circuit struct {
+	// Attested header, integers in little endian as in SSZ.
+	Slot          [8]uints.U8  `gnark:",public"`
+	ProposerIndex [8]uints.U8  `gnark:",public"`
+	ParentRoot    [32]uints.U8 `gnark:",public"`
+	StateRoot     [32]uints.U8 `gnark:",public"`
+	BodyRoot      [32]uints.U8 `gnark:",public"`
+
+	ForkVersion           [4]uints.U8  `gnark:",public"`
+	GenesisValidatorsRoot [32]uints.U8 `gnark:",public"`
+
+	// SigningRoot is the signed message and Hm its hash to G2.
+	SigningRoot [32]uints.U8         `gnark:",public"`
+	Hm          sw_bls12381.G2Affine `gnark:",public"`
+
+	CurrentCommitteeRoot [32]uints.U8      `gnark:",public"`
+	NextCommitteeRoot    [32]uints.U8      `gnark:",public"`
+	Participation        frontend.Variable `gnark:",public"`
+
+	Sig             sw_bls12381.G2Affine
+	Pubkeys         []sw_bls12381.G1Affine
+	Bits            []frontend.Variable
+	AggregatePubkey [48]uints.U8
+
+	NextPubkeys         [][48]uints.U8
+	NextAggregatePubkey [48]uints.U8
+	NextCommitteeBranch [nextSyncCommitteeDepth][32]uints.U8
+}
+
+// NewCircuit returns an empty circuit for a committee of given size.
+func NewCircuit(size int) *Circuit {
+	return &Circuit{
+		Pubkeys:     make([]sw_bls12381.G1Affine, size),
+		Bits:        make([]frontend.Variable, size),
+		NextPubkeys: make([][48]uints.U8, size),
+	}
+}
+
+// Define declares the circuit constraints.
+func (c *Circuit) Define(api frontend.API) error {
+	bf, err := uints.New[uints.U32](api)
+	if err != nil {
+		return err
+	}
+	h := &hasher{api: api, bf: bf}
+
+	// signing root of the attested header
+	headerRoot, err := h.merkleize([][]uints.U8{
+		pad32(c.Slot[:]), pad32(c.ProposerIndex[:]), c.ParentRoot[:], c.StateRoot[:], c.BodyRoot[:],
+	})
+	if err != nil {
+		return err
+	}
+	forkDataRoot, err := h.sum(pad32(c.ForkVersion[:]), c.GenesisValidatorsRoot[:])
+	if err != nil {
+		return err
+	}
+	domain := append(uints.NewU8Array(domainSyncCommittee[:]), forkDataRoot[:28]...)
+	signingRoot, err := h.sum(headerRoot, domain)
+	if err != nil {
+		return err
+	}
+	h.assertEqual(signingRoot, c.SigningRoot[:])
+
+	// aggregate public key of participants
+	curve, err := sw_emulated.New[emulated.BLS12381Fp, emulated.BLS12381Fr](api, sw_emulated.GetBLS12381Params())
+	if err != nil {
+		return err
+	}
+	fpField, err := emulated.NewField[emulated.BLS12381Fp](api)
+	if err != nil {
+		return err
+	}
+	zero := fpField.Zero()
+	agg := &sw_bls12381.G1Affine{X: *zero, Y: *zero}
+	var participation frontend.Variable = 0
+	compressed := make([][]uints.U8, len(c.Pubkeys))
+	for i := range c.Pubkeys {
+		api.AssertIsBoolean(c.Bits[i])
+		curve.AssertIsOnCurve(&c.Pubkeys[i])
+		agg = curve.Select(c.Bits[i], curve.AddUnified(agg, &c.Pubkeys[i]), agg)
+		participation = api.Add(participation, c.Bits[i])
+		compressed[i] = compressG1(api, bf, fpField, &c.Pubkeys[i])
+	}
+	api.AssertIsEqual(c.Participation, participation)
+	// supermajority 3 · participation ≥ 2 · size
+	api.AssertIsLessOrEqual(2*len(c.Pubkeys), api.Mul(3, participation))
+
+	// e(-g1, sig) · e(agg, H(m)) == 1
+	pair, err := sw_bls12381.NewPairing(api)
+	if err != nil {
+		return err
+	}
+	pair.AssertIsOnG2(&c.Sig)
+	negG1 := curve.Neg(curve.Generator())
+	if err := pair.PairingCheck([]*sw_bls12381.G1Affine{negG1, agg}, []*sw_bls12381.G2Affine{&c.Sig, &c.Hm}); err != nil {
+		return err
+	}
+
+	// current committee commitment
+	currentRoot, err := h.syncCommitteeRoot(compressed, c.AggregatePubkey[:])
+	if err != nil {
+		return err
+	}
+	h.assertEqual(currentRoot, c.CurrentCommitteeRoot[:])
+
+	// next committee commitment and its inclusion in the attested state
+	next := make([][]uints.U8, len(c.NextPubkeys))
+	for i := range c.NextPubkeys {
+		next[i] = c.NextPubkeys[i][:]
+	}
+	nextRoot, err := h.syncCommitteeRoot(next, c.NextAggregatePubkey[:])
+	if err != nil {
+		return err
+	}
+	h.assertEqual(nextRoot, c.NextCommitteeRoot[:])
+	node := nextRoot
+	for i := 0; i < nextSyncCommitteeDepth; i++ {
+		if nextSyncCommitteeIndex>>i&1 == 1 {
+			node, err = h.sum(c.NextCommitteeBranch[i][:], node)
+		} else {
+			node, err = h.sum(node, c.NextCommitteeBranch[i][:])
+		}
+		if err != nil {
+			return err
+		}
+	}
+	h.assertEqual(node, c.StateRoot[:])
+	return nil
+}
+
+// hasher computes SSZ roots with SHA-256.
+type hasher struct {
+	api frontend.API
+	bf  *uints.BinaryField[uints.U32]
+}
+
+// sum returns SHA-256 of the concatenated chunks.
+func (h *hasher) sum(chunks ...[]uints.U8) ([]uints.U8, error) {
+	d, err := sha2.New(h.api)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range chunks {
+		d.Write(c)
+	}
+	return d.Sum(), nil
+}
+
+// merkleize returns the root of 32 byte chunks padded with zero chunks to a power of two.
+func (h *hasher) merkleize(chunks [][]uints.U8) ([]uints.U8, error) {
+	n := 1
+	for n < len(chunks) {
+		n <<= 1
+	}
+	layer := make([][]uints.U8, n)
+	copy(layer, chunks)
+	for i := len(chunks); i < n; i++ {
+		layer[i] = uints.NewU8Array(make([]byte, 32))
+	}
+	for len(layer) > 1 {
+		for i := 0; i < len(layer)/2; i++ {
+			var err error
+			if layer[i], err = h.sum(layer[2*i], layer[2*i+1]); err != nil {
+				return nil, err
+			}
+		}
+		layer = layer[:len(layer)/2]
+	}
+	return layer[0], nil
+}
+
+// pubkeyRoot returns hash_tree_root of a 48 byte public key.
+func (h *hasher) pubkeyRoot(pk []uints.U8) ([]uints.U8, error) {
+	return h.sum(pk, uints.NewU8Array(make([]byte, 16)))
+}
+
+// syncCommitteeRoot returns hash_tree_root(SyncCommittee{pubkeys, aggregate_pubkey}).
+func (h *hasher) syncCommitteeRoot(pubkeys [][]uints.U8, aggregate []uints.U8) ([]uints.U8, error) {
+	leaves := make([][]uints.U8, len(pubkeys))
+	for i := range pubkeys {
+		var err error
+		if leaves[i], err = h.pubkeyRoot(pubkeys[i]); err != nil {
+			return nil, err
+		}
+	}
+	pubkeysRoot, err := h.merkleize(leaves)
+	if err != nil {
+		return nil, err
+	}
+	aggregateRoot, err := h.pubkeyRoot(aggregate)
+	if err != nil {
+		return nil, err
+	}
+	return h.sum(pubkeysRoot, aggregateRoot)
+}
+
+func (h *hasher) assertEqual(a, b []uints.U8) {
+	for i := range a {
+		h.bf.ByteAssertEq(a[i], b[i])
+	}
+}
+
+// pad32 right pads bytes with zeros to a 32 byte chunk.
+func pad32(b []uints.U8) []uints.U8 {
+	return append(append([]uints.U8{}, b...), uints.NewU8Array(make([]byte, 32-len(b)))...)
+}
+
+// halfP is (p-1)/2, y is the lexicographically largest root when y > halfP.
+var halfP = new(big.Int).Rsh(fp.Modulus(), 1)
+
+// compressG1 returns the zcash compressed encoding of a point which is not at infinity.
+func compressG1(api frontend.API, bf *uints.BinaryField[uints.U32], f *emulated.Field[emulated.BLS12381Fp], p *sw_bls12381.G1Affine) []uints.U8 {
+	xBits := canonicalBits(f, &p.X)
+	yBits := canonicalBits(f, &p.Y)
+	sign := isGreater(api, yBits, halfP)
+	out := make([]uints.U8, 48)
+	for i := range out {
+		bits := xBits[8*(47-i) : 8*(48-i)]
+		v := api.FromBinary(bits...)
+		if i == 0 {
+			// compressed flag and sign flag, x is below 2^381
+			v = api.Add(v, 0x80, api.Mul(sign, 0x20))
+		}
+		out[i] = bf.ByteValueOf(v)
+	}
+	return out
+}
+
+// canonicalBits returns 384 little endian bits of the reduced element.
+func canonicalBits(f *emulated.Field[emulated.BLS12381Fp], e *emulated.Element[emulated.BLS12381Fp]) []frontend.Variable {
+	r := f.Reduce(e)
+	f.AssertIsInRange(r)
+	return f.ToBits(r)[:384]
+}
+
+// isGreater returns 1 when the little endian bits encode an integer greater than c.
+func isGreater(api frontend.API, bits []frontend.Variable, c *big.Int) frontend.Variable {
+	var gt, eq frontend.Variable = 0, 1
+	for i := len(bits) - 1; i >= 0; i-- {
+		if c.Bit(i) == 1 {
+			eq = api.Mul(eq, bits[i])
+		} else {
+			gt = api.Add(gt, api.Mul(eq, bits[i]))
+			eq = api.Mul(eq, api.Sub(1, bits[i]))
+		}
+	}
+	return gt
+}
@@ -0,0 +1,96 @@
+package synccommittee
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+)
+
+// hashPair returns SHA-256(a || b).
+func hashPair(a, b []byte) [32]byte {
+	h := sha256.New()
+	h.Write(a)
+	h.Write(b)
+	var out [32]byte
+	h.Sum(out[:0])
+	return out
+}
+
+// merkleize returns the root of chunks padded with zero chunks to a power of two.
+func merkleize(chunks [][32]byte) [32]byte {
+	n := 1
+	for n < len(chunks) {
+		n <<= 1
+	}
+	layer := make([][32]byte, n)
+	copy(layer, chunks)
+	for len(layer) > 1 {
+		for i := 0; i < len(layer)/2; i++ {
+			layer[i] = hashPair(layer[2*i][:], layer[2*i+1][:])
+		}
+		layer = layer[:len(layer)/2]
+	}
+	return layer[0]
+}
+
+func uint64Chunk(v uint64) [32]byte {
+	var c [32]byte
+	binary.LittleEndian.PutUint64(c[:], v)
+	return c
+}
+
+// HeaderRoot returns hash_tree_root of a beacon block header.
+func HeaderRoot(h *BeaconBlockHeader) [32]byte {
+	return merkleize([][32]byte{
+		uint64Chunk(h.Slot),
+		uint64Chunk(h.ProposerIndex),
+		h.ParentRoot,
+		h.StateRoot,
+		h.BodyRoot,
+	})
+}
+
+// ComputeDomain returns the sync committee domain of a fork.
+func ComputeDomain(forkVersion Version, genesisValidatorsRoot Root) [32]byte {
+	var version [32]byte
+	copy(version[:], forkVersion[:])
+	forkDataRoot := hashPair(version[:], genesisValidatorsRoot[:])
+	var domain [32]byte
+	copy(domain[:], domainSyncCommittee[:])
+	copy(domain[4:], forkDataRoot[:28])
+	return domain
+}
+
+// SigningRoot returns hash_tree_root(SigningData{objectRoot, domain}).
+func SigningRoot(objectRoot, domain [32]byte) [32]byte {
+	return hashPair(objectRoot[:], domain[:])
+}
+
+// pubkeyRoot returns hash_tree_root of a 48 byte public key.
+func pubkeyRoot(pk BLSPubkey) [32]byte {
+	var pad [16]byte
+	return hashPair(pk[:], pad[:])
+}
+
+// Root returns hash_tree_root of the sync committee.
+func (sc *SyncCommittee) Root() [32]byte {
+	leaves := make([][32]byte, len(sc.Pubkeys))
+	for i := range sc.Pubkeys {
+		leaves[i] = pubkeyRoot(sc.Pubkeys[i])
+	}
+	pubkeysRoot := merkleize(leaves)
+	aggregateRoot := pubkeyRoot(sc.AggregatePubkey)
+	return hashPair(pubkeysRoot[:], aggregateRoot[:])
+}
+
+// branchRoot returns the root reached from leaf at index with the Merkle branch.
+func branchRoot(leaf [32]byte, branch [][32]byte, index int) [32]byte {
+	node := leaf
+	for i := range branch {
+		if index>>i&1 == 1 {
+			node = hashPair(branch[i][:], node[:])
+		} else {
+			node = hashPair(node[:], branch[i][:])
+		}
+	}
+	return node
+}
@@ -0,0 +1,233 @@
+package synccommittee
+
+import (
+	"encoding/json"
+	"flag"
+	"math/big"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+)
+
+var updateFixtures = flag.Bool("update", false, "regenerate fixtures in testdata")
+
+var fixtureFiles = map[string]int{
+	"update_small.json": 4,
+}
+
+func TestGenerateFixtures(t *testing.T) {
+	if !*updateFixtures {
+		t.Skip("run with -update to regenerate fixtures")
+	}
+	for name, size := range fixtureFiles {
+		f := generateFixture(t, size, int64(size))
+		b, err := json.MarshalIndent(f, "", "  ")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join("testdata", name), append(b, '\n'), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// generateFixture returns an update signed by all members of a committee but one, with keys
+// and roots derived from seed. Chain parameters are the mainnet ones at Deneb.
+func generateFixture(t *testing.T, size int, seed int64) *Fixture {
+	rng := rand.New(rand.NewSource(seed))
+	randRoot := func() (r Root) {
+		rng.Read(r[:])
+		return
+	}
+	committee := func() ([]*big.Int, SyncCommittee) {
+		_, _, g1, _ := bls12381.Generators()
+		sks := make([]*big.Int, size)
+		sc := SyncCommittee{Pubkeys: make([]BLSPubkey, size)}
+		var agg bls12381.G1Jac
+		for i := range sks {
+			sks[i] = new(big.Int).Rand(rng, fr.Modulus())
+			var pk bls12381.G1Affine
+			pk.ScalarMultiplication(&g1, sks[i])
+			sc.Pubkeys[i] = pk.Bytes()
+			agg.AddMixed(&pk)
+		}
+		var aggAff bls12381.G1Affine
+		sc.AggregatePubkey = aggAff.FromJacobian(&agg).Bytes()
+		return sks, sc
+	}
+
+	f := &Fixture{ForkVersion: Version{0x04, 0x00, 0x00, 0x00}, Update: VersionedUpdate{Version: "deneb"}}
+	hexRoot := "4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95"
+	if err := f.GenesisValidatorsRoot.UnmarshalText([]byte(hexRoot)); err != nil {
+		t.Fatal(err)
+	}
+	sks, current := committee()
+	f.CurrentSyncCommittee = current
+	u := &f.Update.Data
+	_, u.NextSyncCommittee = committee()
+	u.NextSyncCommitteeBranch = make([]Root, nextSyncCommitteeDepth)
+	branch := make([][32]byte, nextSyncCommitteeDepth)
+	for i := range branch {
+		u.NextSyncCommitteeBranch[i] = randRoot()
+		branch[i] = u.NextSyncCommitteeBranch[i]
+	}
+	slot := uint64(8_000_000 + seed*32)
+	u.AttestedHeader.Beacon = BeaconBlockHeader{
+		Slot:          slot,
+		ProposerIndex: uint64(rng.Intn(1_000_000)),
+		ParentRoot:    randRoot(),
+		StateRoot:     branchRoot(u.NextSyncCommittee.Root(), branch, nextSyncCommitteeIndex),
+		BodyRoot:      randRoot(),
+	}
+	u.FinalizedHeader.Beacon = BeaconBlockHeader{
+		Slot:          slot - 64,
+		ProposerIndex: uint64(rng.Intn(1_000_000)),
+		ParentRoot:    randRoot(),
+		StateRoot:     randRoot(),
+		BodyRoot:      randRoot(),
+	}
+	u.FinalityBranch = make([]Root, 6)
+	for i := range u.FinalityBranch {
+		u.FinalityBranch[i] = randRoot()
+	}
+	u.SignatureSlot = slot + 1
+
+	// all members but the last one sign
+	signingRoot := f.SigningRoot()
+	hm, err := bls12381.HashToG2(signingRoot[:], SignatureDST)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u.SyncAggregate.SyncCommitteeBits = make(Bits, (size+7)/8)
+	sk := new(big.Int)
+	for i := 0; i < size-1; i++ {
+		u.SyncAggregate.SyncCommitteeBits[i/8] |= 1 << (i % 8)
+		sk.Add(sk, sks[i])
+	}
+	var sig bls12381.G2Affine
+	sig.ScalarMultiplication(&hm, sk.Mod(sk, fr.Modulus()))
+	u.SyncAggregate.SyncCommitteeSignature = sig.Bytes()
+	return f
+}
+
+func readFixture(t *testing.T, name string) *Fixture {
+	f, err := ReadFixture(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func TestFixtures(t *testing.T) {
+	assert := test.NewAssert(t)
+	for name, size := range fixtureFiles {
+		f := readFixture(t, name)
+		assert.Equal(size, len(f.CurrentSyncCommittee.Pubkeys), name)
+		assert.NoError(f.Verify(), name)
+	}
+}
+
+func TestFixtureInvalid(t *testing.T) {
+	assert := test.NewAssert(t)
+	for name, tamper := range map[string]func(f *Fixture){
+		"missing participant": func(f *Fixture) {
+			f.Update.Data.SyncAggregate.SyncCommitteeBits[0] ^= 1
+		},
+		"wrong header": func(f *Fixture) {
+			f.Update.Data.AttestedHeader.Beacon.Slot++
+		},
+		"wrong fork": func(f *Fixture) {
+			f.ForkVersion[0]++
+		},
+		"wrong branch": func(f *Fixture) {
+			f.Update.Data.NextSyncCommitteeBranch[2][0]++
+		},
+		"wrong next committee": func(f *Fixture) {
+			f.Update.Data.NextSyncCommittee.Pubkeys[0] = f.CurrentSyncCommittee.Pubkeys[0]
+		},
+		"low participation": func(f *Fixture) {
+			for i := range f.Update.Data.SyncAggregate.SyncCommitteeBits {
+				f.Update.Data.SyncAggregate.SyncCommitteeBits[i] = 0
+			}
+		},
+	} {
+		f := readFixture(t, "update_small.json")
+		tamper(f)
+		assert.Error(f.Verify(), name)
+	}
+}
+
+type compressCircuit struct {
+	P        sw_bls12381.G1Affine
+	Expected [48]uints.U8
+}
+
+func (c *compressCircuit) Define(api frontend.API) error {
+	bf, err := uints.New[uints.U32](api)
+	if err != nil {
+		return err
+	}
+	f, err := emulated.NewField[emulated.BLS12381Fp](api)
+	if err != nil {
+		return err
+	}
+	out := compressG1(api, bf, f, &c.P)
+	for i := range out {
+		bf.ByteAssertEq(out[i], c.Expected[i])
+	}
+	return nil
+}
+
+func TestCompressG1(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, _, g1, _ := bls12381.Generators()
+	var p bls12381.G1Affine
+	p.ScalarMultiplication(&g1, big.NewInt(123456789))
+	for _, q := range []bls12381.G1Affine{g1, p, *new(bls12381.G1Affine).Neg(&p)} {
+		var w compressCircuit
+		w.P = sw_bls12381.NewG1Affine(q)
+		b := q.Bytes()
+		copy(w.Expected[:], uints.NewU8Array(b[:]))
+		assert.NoError(test.IsSolved(&compressCircuit{}, &w, ecc.BN254.ScalarField()))
+
+		b[47] ^= 1
+		copy(w.Expected[:], uints.NewU8Array(b[:]))
+		assert.Error(test.IsSolved(&compressCircuit{}, &w, ecc.BN254.ScalarField()))
+	}
+}
+
+func TestCircuit(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping emulated pairing circuit in short mode")
+	}
+	assert := test.NewAssert(t)
+	f := readFixture(t, "update_small.json")
+	size := len(f.CurrentSyncCommittee.Pubkeys)
+	w, err := f.Assign()
+	assert.NoError(err)
+	assert.NoError(test.IsSolved(NewCircuit(size), w, ecc.BN254.ScalarField()))
+
+	// next committee root that is not in the attested state
+	w, err = f.Assign()
+	assert.NoError(err)
+	nextRoot := f.Update.Data.NextSyncCommittee.Root()
+	w.NextCommitteeRoot[0] = uints.NewU8(nextRoot[0] ^ 1)
+	assert.Error(test.IsSolved(NewCircuit(size), w, ecc.BN254.ScalarField()))
+
+	// a non participating member claimed as signer
+	w, err = f.Assign()
+	assert.NoError(err)
+	w.Bits[size-1] = 1
+	w.Participation = size
+	assert.Error(test.IsSolved(NewCircuit(size), w, ecc.BN254.ScalarField()))
+}
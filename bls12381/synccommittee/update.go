@@ -0,0 +1,278 @@
+package synccommittee
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+	"github.com/consensys/gnark/std/math/uints"
+
+	bls_tools "gnark/aggregate/bls-tools"
+)
+
+// SignatureCiphersuite is the ciphersuite of Ethereum signatures, hashed to G2 with proofs of
+// possession of the keys.
+var SignatureCiphersuite = bls_tools.Ciphersuite{
+	Curve:   "BLS12381",
+	Group:   bls_tools.G2,
+	Hash:    bls_tools.XMDSHA256,
+	Mapping: bls_tools.SSWU,
+	Scheme:  bls_tools.POP,
+}
+
+// SignatureDST is the hash to G2 domain separation tag of Ethereum signatures.
+var SignatureDST = SignatureCiphersuite.DST()
+
+// Root is a 32 byte SSZ root.
+type Root [32]byte
+
+// Version is a fork version.
+type Version [4]byte
+
+// BLSPubkey is a compressed G1 public key.
+type BLSPubkey [48]byte
+
+// BLSSignature is a compressed G2 signature.
+type BLSSignature [96]byte
+
+// Bits is a bitvector, bit i is bit i%8 of byte i/8.
+type Bits []byte
+
+func (r Root) MarshalText() ([]byte, error)           { return marshalHex(r[:]) }
+func (r *Root) UnmarshalText(in []byte) error         { return unmarshalHex(r[:], in) }
+func (v Version) MarshalText() ([]byte, error)        { return marshalHex(v[:]) }
+func (v *Version) UnmarshalText(in []byte) error      { return unmarshalHex(v[:], in) }
+func (p BLSPubkey) MarshalText() ([]byte, error)      { return marshalHex(p[:]) }
+func (p *BLSPubkey) UnmarshalText(in []byte) error    { return unmarshalHex(p[:], in) }
+func (s BLSSignature) MarshalText() ([]byte, error)   { return marshalHex(s[:]) }
+func (s *BLSSignature) UnmarshalText(in []byte) error { return unmarshalHex(s[:], in) }
+func (b Bits) MarshalText() ([]byte, error)           { return marshalHex(b) }
+
+func (b *Bits) UnmarshalText(in []byte) error {
+	v, err := hex.DecodeString(strings.TrimPrefix(string(in), "0x"))
+	if err != nil {
+		return err
+	}
+	*b = v
+	return nil
+}
+
+func marshalHex(b []byte) ([]byte, error) {
+	return []byte("0x" + hex.EncodeToString(b)), nil
+}
+
+func unmarshalHex(dst, in []byte) error {
+	s := strings.TrimPrefix(string(in), "0x")
+	if len(s) != 2*len(dst) {
+		return fmt.Errorf("expected %d bytes hex, got %d characters", len(dst), len(s))
+	}
+	_, err := hex.Decode(dst, []byte(s))
+	return err
+}
+
+// Bit returns bit i of the bitvector.
+func (b Bits) Bit(i int) bool {
+	return b[i/8]>>(i%8)&1 == 1
+}
+
+// BeaconBlockHeader is the header signed by the sync committee.
+type BeaconBlockHeader struct {
+	Slot          uint64 `json:"slot,string"`
+	ProposerIndex uint64 `json:"proposer_index,string"`
+	ParentRoot    Root   `json:"parent_root"`
+	StateRoot     Root   `json:"state_root"`
+	BodyRoot      Root   `json:"body_root"`
+}
+
+// LightClientHeader wraps the beacon header, execution payload fields are ignored.
+type LightClientHeader struct {
+	Beacon BeaconBlockHeader `json:"beacon"`
+}
+
+// SyncCommittee is the set of public keys of a sync committee period.
+type SyncCommittee struct {
+	Pubkeys         []BLSPubkey `json:"pubkeys"`
+	AggregatePubkey BLSPubkey   `json:"aggregate_pubkey"`
+}
+
+// SyncAggregate is the signature of participating committee members.
+type SyncAggregate struct {
+	SyncCommitteeBits      Bits         `json:"sync_committee_bits"`
+	SyncCommitteeSignature BLSSignature `json:"sync_committee_signature"`
+}
+
+// LightClientUpdate is the update of the beacon light client API.
+type LightClientUpdate struct {
+	AttestedHeader          LightClientHeader `json:"attested_header"`
+	NextSyncCommittee       SyncCommittee     `json:"next_sync_committee"`
+	NextSyncCommitteeBranch []Root            `json:"next_sync_committee_branch"`
+	FinalizedHeader         LightClientHeader `json:"finalized_header"`
+	FinalityBranch          []Root            `json:"finality_branch"`
+	SyncAggregate           SyncAggregate     `json:"sync_aggregate"`
+	SignatureSlot           uint64            `json:"signature_slot,string"`
+}
+
+// VersionedUpdate is an update with its fork name as served by
+// /eth/v1/beacon/light_client/updates.
+type VersionedUpdate struct {
+	Version string            `json:"version"`
+	Data    LightClientUpdate `json:"data"`
+}
+
+// Fixture is an update with the chain parameters and the current committee needed to verify it.
+type Fixture struct {
+	GenesisValidatorsRoot Root            `json:"genesis_validators_root"`
+	ForkVersion           Version         `json:"fork_version"`
+	CurrentSyncCommittee  SyncCommittee   `json:"current_sync_committee"`
+	Update                VersionedUpdate `json:"update"`
+}
+
+// ReadFixture reads a fixture in JSON.
+func ReadFixture(path string) (*Fixture, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	f := new(Fixture)
+	if err := json.Unmarshal(b, f); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// SigningRoot returns the signing root of the attested header.
+func (f *Fixture) SigningRoot() [32]byte {
+	return SigningRoot(HeaderRoot(&f.Update.Data.AttestedHeader.Beacon), ComputeDomain(f.ForkVersion, f.GenesisValidatorsRoot))
+}
+
+// decoded is a fixture with decoded points.
+type decoded struct {
+	pubkeys []bls12381.G1Affine
+	sig     bls12381.G2Affine
+	hm      bls12381.G2Affine
+	count   int
+}
+
+func (f *Fixture) decode() (*decoded, error) {
+	u := &f.Update.Data
+	size := len(f.CurrentSyncCommittee.Pubkeys)
+	if size == 0 {
+		return nil, errors.New("current sync committee is empty")
+	}
+	if len(u.NextSyncCommittee.Pubkeys) != size {
+		return nil, fmt.Errorf("next sync committee has %d members, current has %d", len(u.NextSyncCommittee.Pubkeys), size)
+	}
+	if len(u.SyncAggregate.SyncCommitteeBits) != (size+7)/8 {
+		return nil, fmt.Errorf("sync committee bits have %d bytes, expected %d", len(u.SyncAggregate.SyncCommitteeBits), (size+7)/8)
+	}
+	if len(u.NextSyncCommitteeBranch) != nextSyncCommitteeDepth {
+		return nil, fmt.Errorf("next sync committee branch has %d nodes, expected %d", len(u.NextSyncCommitteeBranch), nextSyncCommitteeDepth)
+	}
+	d := &decoded{pubkeys: make([]bls12381.G1Affine, size)}
+	for i, pk := range f.CurrentSyncCommittee.Pubkeys {
+		if _, err := d.pubkeys[i].SetBytes(pk[:]); err != nil {
+			return nil, fmt.Errorf("public key %d: %w", i, err)
+		}
+		if d.pubkeys[i].IsInfinity() {
+			return nil, fmt.Errorf("public key %d is the point at infinity", i)
+		}
+		if u.SyncAggregate.SyncCommitteeBits.Bit(i) {
+			d.count++
+		}
+	}
+	if _, err := d.sig.SetBytes(u.SyncAggregate.SyncCommitteeSignature[:]); err != nil {
+		return nil, fmt.Errorf("signature: %w", err)
+	}
+	root := f.SigningRoot()
+	var err error
+	if d.hm, err = bls12381.HashToG2(root[:], SignatureDST); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Verify checks the update natively as the circuit does.
+func (f *Fixture) Verify() error {
+	d, err := f.decode()
+	if err != nil {
+		return err
+	}
+	u := &f.Update.Data
+	if 3*d.count < 2*len(d.pubkeys) {
+		return fmt.Errorf("participation %d of %d is below two thirds", d.count, len(d.pubkeys))
+	}
+	var agg bls12381.G1Jac
+	for i := range d.pubkeys {
+		if u.SyncAggregate.SyncCommitteeBits.Bit(i) {
+			agg.AddMixed(&d.pubkeys[i])
+		}
+	}
+	var aggAff bls12381.G1Affine
+	aggAff.FromJacobian(&agg)
+	_, _, g1, _ := bls12381.Generators()
+	g1.Neg(&g1)
+	ok, err := bls12381.PairingCheck([]bls12381.G1Affine{g1, aggAff}, []bls12381.G2Affine{d.sig, d.hm})
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("invalid sync committee signature")
+	}
+	branch := make([][32]byte, len(u.NextSyncCommitteeBranch))
+	for i := range branch {
+		branch[i] = u.NextSyncCommitteeBranch[i]
+	}
+	if Root(branchRoot(u.NextSyncCommittee.Root(), branch, nextSyncCommitteeIndex)) != u.AttestedHeader.Beacon.StateRoot {
+		return errors.New("next sync committee branch does not lead to attested state root")
+	}
+	return nil
+}
+
+// Assign returns the circuit assignment of the fixture.
+func (f *Fixture) Assign() (*Circuit, error) {
+	d, err := f.decode()
+	if err != nil {
+		return nil, err
+	}
+	u := &f.Update.Data
+	h := &u.AttestedHeader.Beacon
+	c := NewCircuit(len(d.pubkeys))
+	slot, proposer := uint64Chunk(h.Slot), uint64Chunk(h.ProposerIndex)
+	copy(c.Slot[:], uints.NewU8Array(slot[:8]))
+	copy(c.ProposerIndex[:], uints.NewU8Array(proposer[:8]))
+	copy(c.ParentRoot[:], uints.NewU8Array(h.ParentRoot[:]))
+	copy(c.StateRoot[:], uints.NewU8Array(h.StateRoot[:]))
+	copy(c.BodyRoot[:], uints.NewU8Array(h.BodyRoot[:]))
+	copy(c.ForkVersion[:], uints.NewU8Array(f.ForkVersion[:]))
+	copy(c.GenesisValidatorsRoot[:], uints.NewU8Array(f.GenesisValidatorsRoot[:]))
+	signingRoot := f.SigningRoot()
+	copy(c.SigningRoot[:], uints.NewU8Array(signingRoot[:]))
+	c.Hm = sw_bls12381.NewG2Affine(d.hm)
+
+	currentRoot, nextRoot := f.CurrentSyncCommittee.Root(), u.NextSyncCommittee.Root()
+	copy(c.CurrentCommitteeRoot[:], uints.NewU8Array(currentRoot[:]))
+	copy(c.NextCommitteeRoot[:], uints.NewU8Array(nextRoot[:]))
+	c.Participation = d.count
+
+	c.Sig = sw_bls12381.NewG2Affine(d.sig)
+	for i := range d.pubkeys {
+		c.Pubkeys[i] = sw_bls12381.NewG1Affine(d.pubkeys[i])
+		c.Bits[i] = frontend.Variable(0)
+		if u.SyncAggregate.SyncCommitteeBits.Bit(i) {
+			c.Bits[i] = 1
+		}
+		copy(c.NextPubkeys[i][:], uints.NewU8Array(u.NextSyncCommittee.Pubkeys[i][:]))
+	}
+	copy(c.AggregatePubkey[:], uints.NewU8Array(f.CurrentSyncCommittee.AggregatePubkey[:]))
+	copy(c.NextAggregatePubkey[:], uints.NewU8Array(u.NextSyncCommittee.AggregatePubkey[:]))
+	for i := range c.NextCommitteeBranch {
+		copy(c.NextCommitteeBranch[i][:], uints.NewU8Array(u.NextSyncCommitteeBranch[i][:]))
+	}
+	return c, nil
+}
@@ -42,7 +42,7 @@ func (circuit *BlsCircuit2) Define(api frontend.API) error {
 
 	pr, _ := pair.Pair(p1, p2)
 
-	api.AssertIsEqual(pl, pr)
+	pair.AssertIsEqual(pl, pr)
 	return nil
 }
 
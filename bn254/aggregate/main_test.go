@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bn254_ecc "github.com/consensys/gnark-crypto/ecc/bn254"
+	bn254 "github.com/consensys/gnark/std/algebra/emulated/sw_bn254"
+	"github.com/consensys/gnark/test"
+
+	bls_tools "gnark/aggregate/bls-tools"
+	"gnark/internal/circuittest"
+)
+
+// otherDST is a domain separation tag the circuit messages are not hashed with.
+var otherDST = bls_tools.BN254G1.Ciphersuite().WithScheme(bls_tools.POP).DST()
+
+func assign(sig *bn254_ecc.G1Affine, hm []bn254_ecc.G1Affine, pk []bn254_ecc.G2Affine) *BlsCircuit2 {
+	return &BlsCircuit2{
+		Sig: bn254.NewG1Affine(*sig),
+		G2:  bn254.NewG2Affine(g2Gen),
+		Hm1: bn254.NewG1Affine(hm[0]),
+		Hm2: bn254.NewG1Affine(hm[1]),
+		Pk1: bn254.NewG2Affine(pk[0]),
+		Pk2: bn254.NewG2Affine(pk[1]),
+	}
+}
+
+// replace returns a copy of s with s[i] set to v.
+func replace[T any](s []T, i int, v T) []T {
+	c := append([]T(nil), s...)
+	c[i] = v
+	return c
+}
+
+// testCases returns a valid witness and witnesses each breaking one check of the circuit.
+func testCases(assert *test.Assert) []circuittest.Case {
+	privateKeys, publicKeys, err := BatchGenerateKeyPairs(SignatureNum)
+	assert.NoError(err)
+	_, otherKey, err := GenerateKeyPair()
+	assert.NoError(err)
+
+	hm := make([]bn254_ecc.G1Affine, SignatureNum)
+	pk := make([]bn254_ecc.G2Affine, SignatureNum)
+	var sig bn254_ecc.G1Affine
+	for k, v := range privateKeys {
+		hm[k] = *hashToG1([]byte(fmt.Sprintf("Signature_%d", k+1)))
+		pk[k] = *publicKeys[k].P
+		var s bn254_ecc.G1Affine
+		s.ScalarMultiplication(&hm[k], v.X)
+		sig.Add(&sig, &s)
+	}
+
+	otherHm := hashToG1([]byte("Other Signature"))
+	swapped := replace(replace(pk, 0, pk[1]), 1, pk[0])
+	offCurveSig := sig
+	offCurveSig.Y.Double(&offCurveSig.Y)
+	// first signature over the first message hashed with another tag
+	otherDSTHm, err := bn254_ecc.HashToG1([]byte("Signature_1"), otherDST)
+	assert.NoError(err)
+	var otherDSTSig, s bn254_ecc.G1Affine
+	otherDSTSig.ScalarMultiplication(&hm[0], privateKeys[0].X)
+	otherDSTSig.Sub(&sig, &otherDSTSig)
+	s.ScalarMultiplication(&otherDSTHm, privateKeys[0].X)
+	otherDSTSig.Add(&otherDSTSig, &s)
+
+	return []circuittest.Case{
+		{Name: "valid", Witness: assign(&sig, hm, pk), Valid: true},
+		{Name: "wrong message", Witness: assign(&sig, replace(hm, 0, *otherHm), pk), Valid: false},
+		{Name: "wrong key", Witness: assign(&sig, hm, replace(pk, 0, *otherKey.P)), Valid: false},
+		{Name: "swapped signers", Witness: assign(&sig, hm, swapped), Valid: false},
+		{Name: "identity signature", Witness: assign(&bn254_ecc.G1Affine{}, hm, pk), Valid: false},
+		{Name: "off-curve signature", Witness: assign(&offCurveSig, hm, pk), Valid: false},
+		{Name: "other DST", Witness: assign(&otherDSTSig, hm, pk), Valid: false},
+	}
+}
+
+// TestCircuit runs the circuit in the test engine, which checks the assertions shared by every
+// backend. Compiling the emulated pairings for each backend takes millions of constraints and is
+// left to TestCircuitBackends, built with the backends tag.
+func TestCircuit(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping emulated pairing circuit in short mode")
+	}
+	assert := test.NewAssert(t)
+	circuittest.Solve(assert, &BlsCircuit2{}, ecc.BN254, testCases(assert))
+}
@@ -8,12 +8,14 @@ import (
 
 	bn254_ecc "github.com/consensys/gnark-crypto/ecc/bn254"
 	bn254_fr "github.com/consensys/gnark-crypto/ecc/bn254/fr"
+
+	bls_tools "gnark/aggregate/bls-tools"
 )
 
 var (
 	g1Gen bn254_ecc.G1Affine
 	g2Gen bn254_ecc.G2Affine
-	dst   = []byte("BLS12_377_ECC_HASH")
+	dst   = bls_tools.BN254G1.Ciphersuite().DST()
 )
 
 type PrivateKey struct {
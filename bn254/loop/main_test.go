@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bn254_ecc "github.com/consensys/gnark-crypto/ecc/bn254"
+	bn254 "github.com/consensys/gnark/std/algebra/emulated/sw_bn254"
+	"github.com/consensys/gnark/test"
+
+	bls_tools "gnark/aggregate/bls-tools"
+	"gnark/internal/circuittest"
+)
+
+// otherDST is a domain separation tag the circuit messages are not hashed with.
+var otherDST = bls_tools.BN254G1.Ciphersuite().WithScheme(bls_tools.POP).DST()
+
+func assign(sig, hm []bn254_ecc.G1Affine, pk []bn254_ecc.G2Affine) *Circuit {
+	var w Circuit
+	for k := range w.Sig {
+		w.Sig[k] = bn254.NewG1Affine(sig[k])
+		w.G2[k] = bn254.NewG2Affine(g2Gen)
+		w.Hm[k] = bn254.NewG1Affine(hm[k])
+		w.Pk[k] = bn254.NewG2Affine(pk[k])
+	}
+	return &w
+}
+
+// replace returns a copy of s with s[i] set to v.
+func replace[T any](s []T, i int, v T) []T {
+	c := append([]T(nil), s...)
+	c[i] = v
+	return c
+}
+
+// testCases returns a valid witness and witnesses each breaking one check of the circuit.
+func testCases(assert *test.Assert) []circuittest.Case {
+	privateKeys, publicKeys, err := BatchGenerateKeyPairs(SignatureNum)
+	assert.NoError(err)
+	_, otherKey, err := GenerateKeyPair()
+	assert.NoError(err)
+
+	sig := make([]bn254_ecc.G1Affine, SignatureNum)
+	hm := make([]bn254_ecc.G1Affine, SignatureNum)
+	pk := make([]bn254_ecc.G2Affine, SignatureNum)
+	for k, v := range privateKeys {
+		hm[k] = *hashToG1([]byte(fmt.Sprintf("Signature_%d", k+1)))
+		pk[k] = *publicKeys[k].P
+		sig[k].ScalarMultiplication(&hm[k], v.X)
+	}
+
+	otherHm := hashToG1([]byte("Other Signature"))
+	swapped := replace(replace(pk, 0, pk[1]), 1, pk[0])
+	offCurveSig := sig[0]
+	offCurveSig.Y.Double(&offCurveSig.Y)
+	otherDSTHm, err := bn254_ecc.HashToG1([]byte("Signature_1"), otherDST)
+	assert.NoError(err)
+	var otherDSTSig bn254_ecc.G1Affine
+	otherDSTSig.ScalarMultiplication(&otherDSTHm, privateKeys[0].X)
+
+	return []circuittest.Case{
+		{Name: "valid", Witness: assign(sig, hm, pk), Valid: true},
+		{Name: "wrong message", Witness: assign(sig, replace(hm, 0, *otherHm), pk), Valid: false},
+		{Name: "wrong key", Witness: assign(sig, hm, replace(pk, 0, *otherKey.P)), Valid: false},
+		{Name: "swapped signers", Witness: assign(sig, hm, swapped), Valid: false},
+		{Name: "identity signature", Witness: assign(replace(sig, 0, bn254_ecc.G1Affine{}), hm, pk), Valid: false},
+		{Name: "off-curve signature", Witness: assign(replace(sig, 0, offCurveSig), hm, pk), Valid: false},
+		{Name: "other DST", Witness: assign(replace(sig, 0, otherDSTSig), hm, pk), Valid: false},
+	}
+}
+
+// TestCircuit runs the circuit in the test engine, which checks the assertions shared by every
+// backend. Compiling the emulated pairings for each backend takes millions of constraints and is
+// left to TestCircuitBackends, built with the backends tag.
+func TestCircuit(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping emulated pairing circuit in short mode")
+	}
+	assert := test.NewAssert(t)
+	circuittest.Solve(assert, &Circuit{}, ecc.BN254, testCases(assert))
+}
@@ -8,12 +8,14 @@ import (
 
 	bn254_ecc "github.com/consensys/gnark-crypto/ecc/bn254"
 	bn254_fr "github.com/consensys/gnark-crypto/ecc/bn254/fr"
+
+	bls_tools "gnark/aggregate/bls-tools"
 )
 
 var (
 	g1Gen bn254_ecc.G1Affine
 	g2Gen bn254_ecc.G2Affine
-	dst   = []byte("BN254_ECC_HASH")
+	dst   = bls_tools.BN254G1.Ciphersuite().DST()
 )
 
 type PrivateKey struct {
@@ -105,7 +107,7 @@ func Verify(publicKey *PublicKey, sig, msg []byte) (bool, error) {
 	}
 
 	// e(P, H(m)) = e(H(m), P)
-	hashPointG1, _ := bn254_ecc.HashToG1(msg, g1Gen.Marshal())
+	hashPointG1, _ := bn254_ecc.HashToG1(msg, dst)
 	rp, err := bn254_ecc.Pair([]bn254_ecc.G1Affine{hashPointG1}, []bn254_ecc.G2Affine{*publicKey.P})
 	if err != nil {
 		return false, err
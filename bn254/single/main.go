@@ -43,7 +43,7 @@ func main() {
 		log.Panic("GenerateKeyPair err: ", err)
 	}
 	msg := []byte("Sig Test")
-	hm, err := sw_bn254_ecc.HashToG1(msg, g1Gen.Marshal())
+	hm, err := sw_bn254_ecc.HashToG1(msg, dst)
 	if err != nil {
 		log.Panic("HashToG1 err: ", err)
 	}
@@ -6,11 +6,14 @@ import (
 
 	bn_254_ecc "github.com/consensys/gnark-crypto/ecc/bn254"
 	bn_254_fr "github.com/consensys/gnark-crypto/ecc/bn254/fr"
+
+	bls_tools "gnark/aggregate/bls-tools"
 )
 
 var (
 	g1Gen bn_254_ecc.G1Affine
 	g2Gen bn_254_ecc.G2Affine
+	dst   = bls_tools.BN254G1.Ciphersuite().DST()
 )
 
 type PrivateKey struct {
@@ -60,7 +63,7 @@ func GenerateKeyPair() (*PrivateKey, *PublicKey, error) {
 // e(P, H(m)) = e(pk*G, H(m)) = e(G, pk*H(m)) = e(G, S)
 func Sign(privateKey *PrivateKey, msg []byte) (blsSignature *bn_254_ecc.G1Affine, err error) {
 
-	hashPointG1, _ := bn_254_ecc.HashToG1(msg, g1Gen.Marshal())
+	hashPointG1, _ := bn_254_ecc.HashToG1(msg, dst)
 
 	sig := new(bn_254_ecc.G1Affine).ScalarMultiplication(&hashPointG1, privateKey.X)
 
@@ -81,7 +84,7 @@ func Verify(publicKey *PublicKey, sig, msg []byte) (bool, error) {
 	}
 
 	// e(P, H(m)) = e(H(m), P)
-	hashPointG1, _ := bn_254_ecc.HashToG1(msg, g1Gen.Marshal())
+	hashPointG1, _ := bn_254_ecc.HashToG1(msg, dst)
 	rp, err := bn_254_ecc.Pair([]bn_254_ecc.G1Affine{hashPointG1}, []bn_254_ecc.G2Affine{*publicKey.P})
 	if err != nil {
 		return false, err
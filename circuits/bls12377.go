@@ -0,0 +1,245 @@
+package circuits
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fp"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/native/fields_bls12377"
+	"github.com/consensys/gnark/std/algebra/native/sw_bls12377"
+)
+
+// BLS12377Circuit verifies BLS12-377 signatures in a BW6-761 circuit.
+type BLS12377Circuit struct {
+	scheme     Scheme
+	commitment PkCommitment
+	Sig        []sw_bls12377.G1Affine
+	Hm         []sw_bls12377.G1Affine `gnark:",public"`
+	Pk         []sw_bls12377.G2Affine `gnark:",public"`
+
+	// CommittedPk replaces Pk when public keys are committed in PkCommitment.
+	CommittedPk  []sw_bls12377.G2Affine
+	PkCommitment []Commitment `gnark:",public"`
+}
+
+func newBLS12377Circuit(s Scheme, sigs, msgs, pks int, k PkCommitment) *BLS12377Circuit {
+	committed := 0
+	if k != NoPkCommitment {
+		pks, committed = 0, pks
+	}
+	return &BLS12377Circuit{
+		scheme:       s,
+		commitment:   k,
+		Sig:          make([]sw_bls12377.G1Affine, sigs),
+		Hm:           make([]sw_bls12377.G1Affine, msgs),
+		Pk:           make([]sw_bls12377.G2Affine, pks),
+		CommittedPk:  make([]sw_bls12377.G2Affine, committed),
+		PkCommitment: make([]Commitment, k.size()),
+	}
+}
+
+// Define checks e(sig, -g2) * ∏ e(hm, pk) == 1 as the scheme requires.
+func (c *BLS12377Circuit) Define(api frontend.API) error {
+	pks := c.Pk
+	if c.commitment != NoPkCommitment {
+		pks = c.CommittedPk
+		var coords []frontend.Variable
+		for i := range pks {
+			coords = append(coords, pks[i].X.A0, pks[i].X.A1, pks[i].Y.A0, pks[i].Y.A1)
+		}
+		if err := assertPkCommitment(api, c.commitment, coords, fp.Bytes, c.PkCommitment); err != nil {
+			return err
+		}
+	}
+	for i := range c.Sig {
+		AssertIsOnG1BLS12377(api, c.Sig[i])
+	}
+	_, _, _, g2 := bls12377.Generators()
+	g2.Neg(&g2)
+	var negG2 sw_bls12377.G2Affine
+	negG2.Assign(&g2)
+	switch c.scheme {
+	case Loop:
+		for i := range c.Sig {
+			err := pairingCheckBLS12377(api, []sw_bls12377.G1Affine{c.Sig[i], c.Hm[i]}, []sw_bls12377.G2Affine{negG2, pks[i]})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	case FastAggregate:
+		pk := pks[0]
+		for i := 1; i < len(pks); i++ {
+			pk = addG2BLS12377(api, pk, pks[i])
+		}
+		return pairingCheckBLS12377(api, []sw_bls12377.G1Affine{c.Sig[0], c.Hm[0]}, []sw_bls12377.G2Affine{negG2, pk})
+	}
+	P, Q := []sw_bls12377.G1Affine{c.Sig[0]}, []sw_bls12377.G2Affine{negG2}
+	for i := range c.Hm {
+		P, Q = append(P, c.Hm[i]), append(Q, pks[i])
+	}
+	return pairingCheckBLS12377(api, P, Q)
+}
+
+// omegaBLS12377 is the cube root of unity of the endomorphism ϕ(x,y) = (ωx,y) of G1.
+var omegaBLS12377, _ = new(big.Int).SetString("80949648264912719408558363140637477264845294720710499478137287262712535938301461879813459410945", 10)
+
+// AssertIsOnG1BLS12377 asserts that p is on y² = x³ + 1 and in the subgroup of order r, as
+// AssertIsOnG1 of the emulated pairings. The native pairing of sw_bls12377 checks neither,
+// circuits taking a BLS12-377 signature as witness call it on the signature.
+func AssertIsOnG1BLS12377(api frontend.API, p sw_bls12377.G1Affine) {
+	api.AssertIsEqual(api.Mul(p.Y, p.Y), api.Add(api.Mul(p.X, p.X, p.X), 1))
+	// [r]p == 0 <==> [x₀²]ϕ(p) == -p
+	phi := sw_bls12377.G1Affine{X: api.Mul(p.X, omegaBLS12377), Y: p.Y}
+	xxPhi := scalarMulBySeedBLS12377(api, scalarMulBySeedBLS12377(api, phi))
+	api.AssertIsEqual(xxPhi.X, p.X)
+	api.AssertIsEqual(xxPhi.Y, api.Neg(p.Y))
+}
+
+// scalarMulBySeedBLS12377 returns [x₀]p, x₀=9586122913090633729 the seed of BLS12-377.
+func scalarMulBySeedBLS12377(api frontend.API, p sw_bls12377.G1Affine) sw_bls12377.G1Affine {
+	// x₀ = ((((33 << 7) + 33) << 4 + 1) << 1 + 1) << 46 + 1
+	z := addG1BLS12377(api, doubleG1BLS12377(api, p, 5), p)
+	p33 := z
+	z = addG1BLS12377(api, doubleG1BLS12377(api, z, 7), p33)
+	z = addG1BLS12377(api, doubleG1BLS12377(api, z, 4), p)
+	z = addG1BLS12377(api, doubleG1BLS12377(api, z, 1), p)
+	return addG1BLS12377(api, doubleG1BLS12377(api, z, 46), p)
+}
+
+// addG1BLS12377 returns p+q for p ≠ ±q. Unlike AddAssign of sw_bls12377 the division is
+// checked, so that a point of small order cannot make λ free.
+func addG1BLS12377(api frontend.API, p, q sw_bls12377.G1Affine) sw_bls12377.G1Affine {
+	// λ = (q.y - p.y) / (q.x - p.x)
+	λ := api.Div(api.Sub(q.Y, p.Y), api.Sub(q.X, p.X))
+	// x = λ² - p.x - q.x
+	x := api.Sub(api.Mul(λ, λ), api.Add(p.X, q.X))
+	// y = λ(p.x - x) - p.y
+	y := api.Sub(api.Mul(λ, api.Sub(p.X, x)), p.Y)
+	return sw_bls12377.G1Affine{X: x, Y: y}
+}
+
+// doubleG1BLS12377 returns [2ⁿ]p for p not of order 2.
+func doubleG1BLS12377(api frontend.API, p sw_bls12377.G1Affine, n int) sw_bls12377.G1Affine {
+	for i := 0; i < n; i++ {
+		// λ = 3p.x² / 2p.y
+		λ := api.Div(api.Mul(p.X, p.X, 3), api.Mul(p.Y, 2))
+		// x = λ² - 2p.x
+		x := api.Sub(api.Mul(λ, λ), api.Mul(p.X, 2))
+		// y = λ(p.x - x) - p.y
+		p.Y = api.Sub(api.Mul(λ, api.Sub(p.X, x)), p.Y)
+		p.X = x
+	}
+	return p
+}
+
+// addG2BLS12377 returns p+q for p ≠ ±q. Unlike AddAssign of sw_bls12377 the division is
+// checked, so that duplicate or opposite keys make the proof fail instead of leaving λ free.
+func addG2BLS12377(api frontend.API, p, q sw_bls12377.G2Affine) sw_bls12377.G2Affine {
+	var λ, x, y fields_bls12377.E2
+	// λ = (q.y - p.y) / (q.x - p.x)
+	x.Sub(api, q.X, p.X)
+	λ.Inverse(api, x)
+	y.Sub(api, q.Y, p.Y)
+	λ.Mul(api, λ, y)
+	// x = λ² - p.x - q.x
+	x.Square(api, λ).Sub(api, x, p.X).Sub(api, x, q.X)
+	// y = λ(p.x - x) - p.y
+	y.Sub(api, p.X, x).Mul(api, λ, y).Sub(api, y, p.Y)
+	return sw_bls12377.G2Affine{X: x, Y: y}
+}
+
+// pairingCheckBLS12377 asserts ∏ e(P_i, Q_i) == 1.
+func pairingCheckBLS12377(api frontend.API, P []sw_bls12377.G1Affine, Q []sw_bls12377.G2Affine) error {
+	f, err := sw_bls12377.Pair(api, P, Q)
+	if err != nil {
+		return err
+	}
+	var one sw_bls12377.GT
+	one.SetOne()
+	f.AssertIsEqual(api, one)
+	return nil
+}
+
+// decodeBLS12377 decodes signatures and public keys of the input. Signatures are aggregated
+// unless scheme verifies them one by one.
+func decodeBLS12377(s Scheme, in *Input) ([]bls12377.G1Affine, []bls12377.G2Affine, error) {
+	sigInputs, err := in.signatureInputs(s)
+	if err != nil {
+		return nil, nil, err
+	}
+	sigs := make([]bls12377.G1Affine, len(sigInputs))
+	for i, pt := range sigInputs {
+		p := &sigs[i]
+		err := pt.decode(p, fp.Modulus(), 2, func(v []*big.Int) {
+			p.X.SetBigInt(v[0])
+			p.Y.SetBigInt(v[1])
+		})
+		if err != nil {
+			return nil, nil, signatureError(i, err)
+		}
+	}
+	if len(sigs) > 1 && s != Loop {
+		var acc bls12377.G1Jac
+		for i := range sigs {
+			acc.AddMixed(&sigs[i])
+		}
+		sigs = []bls12377.G1Affine{*new(bls12377.G1Affine).FromJacobian(&acc)}
+	}
+	pks, err := decodePublicKeysBLS12377(in)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sigs, pks, nil
+}
+
+// decodePublicKeysBLS12377 decodes public keys of the input.
+func decodePublicKeysBLS12377(in *Input) ([]bls12377.G2Affine, error) {
+	pks := make([]bls12377.G2Affine, len(in.Signatures))
+	for i := range in.Signatures {
+		p := &pks[i]
+		err := in.Signatures[i].PublicKey.decodePublicKey(p, fp.Modulus(), func(v []*big.Int) {
+			p.X.A0.SetBigInt(v[0])
+			p.X.A1.SetBigInt(v[1])
+			p.Y.A0.SetBigInt(v[2])
+			p.Y.A1.SetBigInt(v[3])
+		})
+		if err != nil {
+			return nil, publicKeyError(i, err)
+		}
+	}
+	return pks, nil
+}
+
+func assignBLS12377(s Scheme, k PkCommitment, in *Input) (frontend.Circuit, error) {
+	sigs, pks, err := decodeBLS12377(s, in)
+	if err != nil {
+		return nil, err
+	}
+	nSigs, nMsgs, nPks := s.counts(len(in.Signatures))
+	c := newBLS12377Circuit(s, nSigs, nMsgs, nPks, k)
+	for i := range c.Sig {
+		c.Sig[i].Assign(&sigs[i])
+	}
+	for i := range c.Hm {
+		hm, err := bls12377.HashToG1(in.Signatures[i].Message, BLS12377InBW6761.DST())
+		if err != nil {
+			return nil, err
+		}
+		c.Hm[i].Assign(&hm)
+	}
+	if k != NoPkCommitment {
+		for i := range c.CommittedPk {
+			c.CommittedPk[i].Assign(&pks[i])
+		}
+		for i, v := range CommitmentBLS12377(k, pks) {
+			c.PkCommitment[i].Value = v
+		}
+		return c, nil
+	}
+	for i := range c.Pk {
+		c.Pk[i].Assign(&pks[i])
+	}
+	return c, nil
+}
@@ -0,0 +1,182 @@
+package circuits
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/fields_bn254"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bn254"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+// BN254Circuit verifies BN254 signatures in a BN254 circuit.
+type BN254Circuit struct {
+	scheme     Scheme
+	commitment PkCommitment
+	Sig        []sw_bn254.G1Affine
+	Hm         []sw_bn254.G1Affine `gnark:",public"`
+	Pk         []sw_bn254.G2Affine `gnark:",public"`
+
+	// CommittedPk replaces Pk when public keys are committed in PkCommitment.
+	CommittedPk  []sw_bn254.G2Affine
+	PkCommitment []Commitment `gnark:",public"`
+}
+
+func newBN254Circuit(s Scheme, sigs, msgs, pks int, k PkCommitment) *BN254Circuit {
+	committed := 0
+	if k != NoPkCommitment {
+		pks, committed = 0, pks
+	}
+	return &BN254Circuit{
+		scheme:       s,
+		commitment:   k,
+		Sig:          make([]sw_bn254.G1Affine, sigs),
+		Hm:           make([]sw_bn254.G1Affine, msgs),
+		Pk:           make([]sw_bn254.G2Affine, pks),
+		CommittedPk:  make([]sw_bn254.G2Affine, committed),
+		PkCommitment: make([]Commitment, k.size()),
+	}
+}
+
+// Define checks e(sig, -g2) * ∏ e(hm, pk) == 1 as the scheme requires.
+func (c *BN254Circuit) Define(api frontend.API) error {
+	pks := c.Pk
+	if c.commitment != NoPkCommitment {
+		pks = c.CommittedPk
+		var coords []*emulated.Element[emulated.BN254Fp]
+		for i := range pks {
+			coords = append(coords, &pks[i].X.A0, &pks[i].X.A1, &pks[i].Y.A0, &pks[i].Y.A1)
+		}
+		if err := assertPkCommitmentEmulated(api, c.commitment, coords, c.PkCommitment); err != nil {
+			return err
+		}
+	}
+	pair, err := sw_bn254.NewPairing(api)
+	if err != nil {
+		return err
+	}
+	for i := range c.Sig {
+		pair.AssertIsOnG1(&c.Sig[i])
+	}
+	_, _, _, g2 := bn254.Generators()
+	g2.Neg(&g2)
+	negG2 := sw_bn254.NewG2Affine(g2)
+	switch c.scheme {
+	case Loop:
+		for i := range c.Sig {
+			err := pair.PairingCheck([]*sw_bn254.G1Affine{&c.Sig[i], &c.Hm[i]}, []*sw_bn254.G2Affine{&negG2, &pks[i]})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	case FastAggregate:
+		e2 := fields_bn254.NewExt2(api)
+		pk := &pks[0]
+		for i := 1; i < len(pks); i++ {
+			pk = addG2BN254(e2, pk, &pks[i])
+		}
+		return pair.PairingCheck([]*sw_bn254.G1Affine{&c.Sig[0], &c.Hm[0]}, []*sw_bn254.G2Affine{&negG2, pk})
+	}
+	P, Q := []*sw_bn254.G1Affine{&c.Sig[0]}, []*sw_bn254.G2Affine{&negG2}
+	for i := range c.Hm {
+		P, Q = append(P, &c.Hm[i]), append(Q, &pks[i])
+	}
+	return pair.PairingCheck(P, Q)
+}
+
+// addG2BN254 returns p+q for p ≠ ±q. The division is checked, so that duplicate or opposite
+// keys make the proof fail instead of leaving λ free.
+func addG2BN254(e2 *fields_bn254.Ext2, p, q *sw_bn254.G2Affine) *sw_bn254.G2Affine {
+	// λ = (q.y - p.y) / (q.x - p.x)
+	λ := e2.Mul(e2.Sub(&q.Y, &p.Y), e2.Inverse(e2.Sub(&q.X, &p.X)))
+	// x = λ² - p.x - q.x
+	x := e2.Sub(e2.Square(λ), e2.Add(&p.X, &q.X))
+	// y = λ(p.x - x) - p.y
+	y := e2.Sub(e2.Mul(λ, e2.Sub(&p.X, x)), &p.Y)
+	return &sw_bn254.G2Affine{X: *x, Y: *y}
+}
+
+// decodeBN254 decodes signatures and public keys of the input. Signatures are aggregated
+// unless scheme verifies them one by one.
+func decodeBN254(s Scheme, in *Input) ([]bn254.G1Affine, []bn254.G2Affine, error) {
+	sigInputs, err := in.signatureInputs(s)
+	if err != nil {
+		return nil, nil, err
+	}
+	sigs := make([]bn254.G1Affine, len(sigInputs))
+	for i, pt := range sigInputs {
+		p := &sigs[i]
+		err := pt.decode(p, fp.Modulus(), 2, func(v []*big.Int) {
+			p.X.SetBigInt(v[0])
+			p.Y.SetBigInt(v[1])
+		})
+		if err != nil {
+			return nil, nil, signatureError(i, err)
+		}
+	}
+	if len(sigs) > 1 && s != Loop {
+		var acc bn254.G1Jac
+		for i := range sigs {
+			acc.AddMixed(&sigs[i])
+		}
+		sigs = []bn254.G1Affine{*new(bn254.G1Affine).FromJacobian(&acc)}
+	}
+	pks, err := decodePublicKeysBN254(in)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sigs, pks, nil
+}
+
+// decodePublicKeysBN254 decodes public keys of the input.
+func decodePublicKeysBN254(in *Input) ([]bn254.G2Affine, error) {
+	pks := make([]bn254.G2Affine, len(in.Signatures))
+	for i := range in.Signatures {
+		p := &pks[i]
+		err := in.Signatures[i].PublicKey.decodePublicKey(p, fp.Modulus(), func(v []*big.Int) {
+			p.X.A0.SetBigInt(v[0])
+			p.X.A1.SetBigInt(v[1])
+			p.Y.A0.SetBigInt(v[2])
+			p.Y.A1.SetBigInt(v[3])
+		})
+		if err != nil {
+			return nil, publicKeyError(i, err)
+		}
+	}
+	return pks, nil
+}
+
+func assignBN254(s Scheme, k PkCommitment, in *Input) (frontend.Circuit, error) {
+	sigs, pks, err := decodeBN254(s, in)
+	if err != nil {
+		return nil, err
+	}
+	nSigs, nMsgs, nPks := s.counts(len(in.Signatures))
+	c := newBN254Circuit(s, nSigs, nMsgs, nPks, k)
+	for i := range c.Sig {
+		c.Sig[i] = sw_bn254.NewG1Affine(sigs[i])
+	}
+	for i := range c.Hm {
+		hm, err := bn254.HashToG1(in.Signatures[i].Message, BN254.DST())
+		if err != nil {
+			return nil, err
+		}
+		c.Hm[i] = sw_bn254.NewG1Affine(hm)
+	}
+	if k != NoPkCommitment {
+		for i := range c.CommittedPk {
+			c.CommittedPk[i] = sw_bn254.NewG2Affine(pks[i])
+		}
+		for i, v := range CommitmentBN254(k, pks) {
+			c.PkCommitment[i].Value = v
+		}
+		return c, nil
+	}
+	for i := range c.Pk {
+		c.Pk[i] = sw_bn254.NewG2Affine(pks[i])
+	}
+	return c, nil
+}
@@ -0,0 +1,259 @@
+// Package circuits gathers BLS signature verification circuits of each curve pair and scheme
+// behind one constructor, so that command line tools can compile, set up and prove them
+// without a dedicated main package per variant.
+//
+// Signatures are in G1 and public keys are in G2. Public keys and hashed messages are public
+// inputs, signatures are private.
+package circuits
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+
+	bls_tools "gnark/aggregate/bls-tools"
+)
+
+// CurvePair names the signature curve and the curve whose scalar field the circuit is defined on.
+type CurvePair int
+
+const (
+	// BN254 verifies BN254 signatures in BN254 circuit with field emulation.
+	BN254 CurvePair = iota
+	// BLS12381InBN254 verifies BLS12-381 signatures in BN254 circuit with field emulation.
+	BLS12381InBN254
+	// BLS12377InBW6761 verifies BLS12-377 signatures natively in BW6-761 circuit.
+	BLS12377InBW6761
+)
+
+var curvePairNames = map[CurvePair]string{
+	BN254:            "bn254",
+	BLS12381InBN254:  "bls12381-in-bn254",
+	BLS12377InBW6761: "bls12377-in-bw6761",
+}
+
+// String returns name of the curve pair.
+func (c CurvePair) String() string {
+	if name, ok := curvePairNames[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("CurvePair(%d)", int(c))
+}
+
+// ParseCurvePair returns the curve pair with given name.
+func ParseCurvePair(name string) (CurvePair, error) {
+	for c, s := range curvePairNames {
+		if s == name {
+			return c, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown curve pair %q", name)
+}
+
+// ID returns the curve on which proofs are generated.
+func (c CurvePair) ID() ecc.ID {
+	if c == BLS12377InBW6761 {
+		return ecc.BW6_761
+	}
+	return ecc.BN254
+}
+
+// Field returns the scalar field the circuit is compiled on.
+func (c CurvePair) Field() *big.Int {
+	return c.ID().ScalarField()
+}
+
+// SchemeCurve returns the curve of bls-tools signing as the circuits of the curve pair do.
+func (c CurvePair) SchemeCurve() bls_tools.Curve {
+	switch c {
+	case BN254:
+		return bls_tools.BN254G1
+	case BLS12381InBN254:
+		return bls_tools.BLS12381G1
+	}
+	return bls_tools.BLS12377G1
+}
+
+// Ciphersuite returns the basic scheme ciphersuite of the signatures verified on the curve pair.
+func (c CurvePair) Ciphersuite() bls_tools.Ciphersuite {
+	return c.SchemeCurve().Ciphersuite()
+}
+
+// DST returns the domain separation tag of messages hashed to G1 for the curve pair.
+func (c CurvePair) DST() []byte {
+	return c.Ciphersuite().DST()
+}
+
+// MarshalJSON encodes curve pair by name.
+func (c CurvePair) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.String())
+}
+
+// UnmarshalJSON decodes curve pair by name.
+func (c *CurvePair) UnmarshalJSON(in []byte) error {
+	var s string
+	if err := json.Unmarshal(in, &s); err != nil {
+		return err
+	}
+	v, err := ParseCurvePair(s)
+	if err != nil {
+		return err
+	}
+	*c = v
+	return nil
+}
+
+// Scheme names how signatures are checked in a circuit.
+type Scheme int
+
+const (
+	// Single verifies one signature e(sig, g2) == e(H(m), pk).
+	Single Scheme = iota
+	// Loop verifies each signature separately e(sig_i, g2) == e(H(m_i), pk_i).
+	Loop
+	// Aggregate verifies an aggregate signature over distinct messages e(sig, g2) == ∏ e(H(m_i), pk_i).
+	Aggregate
+	// FastAggregate verifies an aggregate signature over a single message e(sig, g2) == e(H(m), Σ pk_i).
+	FastAggregate
+)
+
+var schemeNames = map[Scheme]string{
+	Single:        "single",
+	Loop:          "loop",
+	Aggregate:     "aggregate",
+	FastAggregate: "fast-aggregate",
+}
+
+// String returns name of the scheme.
+func (s Scheme) String() string {
+	if name, ok := schemeNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("Scheme(%d)", int(s))
+}
+
+// ParseScheme returns the scheme with given name.
+func ParseScheme(name string) (Scheme, error) {
+	for s, n := range schemeNames {
+		if n == name {
+			return s, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown scheme %q", name)
+}
+
+// MarshalJSON encodes scheme by name.
+func (s Scheme) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// UnmarshalJSON decodes scheme by name.
+func (s *Scheme) UnmarshalJSON(in []byte) error {
+	var n string
+	if err := json.Unmarshal(in, &n); err != nil {
+		return err
+	}
+	v, err := ParseScheme(n)
+	if err != nil {
+		return err
+	}
+	*s = v
+	return nil
+}
+
+// counts returns number of signatures, hashed messages and public keys of a circuit.
+func (s Scheme) counts(size int) (sigs, msgs, pks int) {
+	switch s {
+	case Loop:
+		return size, size, size
+	case Aggregate:
+		return 1, size, size
+	case FastAggregate:
+		return 1, 1, size
+	}
+	return 1, 1, 1
+}
+
+// Config identifies a circuit variant.
+type Config struct {
+	Curve  CurvePair `json:"curve"`
+	Scheme Scheme    `json:"scheme"`
+	Size   int       `json:"size"`
+	// PkCommitment replaces public key inputs with a commitment to the keys.
+	PkCommitment PkCommitment `json:"pkCommitment,omitempty"`
+}
+
+func (c Config) validate() error {
+	if _, ok := curvePairNames[c.Curve]; !ok {
+		return fmt.Errorf("unknown curve pair %s", c.Curve)
+	}
+	if _, ok := schemeNames[c.Scheme]; !ok {
+		return fmt.Errorf("unknown scheme %s", c.Scheme)
+	}
+	if _, ok := pkCommitmentNames[c.PkCommitment]; !ok {
+		return fmt.Errorf("unknown public key commitment %s", c.PkCommitment)
+	}
+	if c.Size < 1 {
+		return errors.New("size must be positive")
+	}
+	if c.Scheme == Single && c.Size != 1 {
+		return errors.New("size of single scheme must be one")
+	}
+	return nil
+}
+
+// New returns an empty circuit of the variant to be compiled.
+func New(c Config) (frontend.Circuit, error) {
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	sigs, msgs, pks := c.Scheme.counts(c.Size)
+	switch c.Curve {
+	case BN254:
+		return newBN254Circuit(c.Scheme, sigs, msgs, pks, c.PkCommitment), nil
+	case BLS12381InBN254:
+		return newBLS12381Circuit(c.Scheme, sigs, msgs, pks, c.PkCommitment), nil
+	}
+	return newBLS12377Circuit(c.Scheme, sigs, msgs, pks, c.PkCommitment), nil
+}
+
+// Assign returns the circuit of the variant assigned with given signatures.
+func Assign(c Config, in *Input) (frontend.Circuit, error) {
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	if err := in.check(c); err != nil {
+		return nil, err
+	}
+	switch c.Curve {
+	case BN254:
+		return assignBN254(c.Scheme, c.PkCommitment, in)
+	case BLS12381InBN254:
+		return assignBLS12381(c.Scheme, c.PkCommitment, in)
+	}
+	return assignBLS12377(c.Scheme, c.PkCommitment, in)
+}
+
+// decodePoint decodes a point in gnark-crypto encoding, trailing bytes are rejected.
+func decodePoint(p interface{ SetBytes([]byte) (int, error) }, in []byte) error {
+	n, err := p.SetBytes(in)
+	if err != nil {
+		return err
+	}
+	if n != len(in) {
+		return errors.New("trailing bytes after point encoding")
+	}
+	return nil
+}
+
+func signatureError(i int, err error) error {
+	return fmt.Errorf("signature %d: %w", i, err)
+}
+
+func publicKeyError(i int, err error) error {
+	return fmt.Errorf("public key %d: %w", i, err)
+}
@@ -0,0 +1,249 @@
+package circuits
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fp"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bn254"
+	"github.com/consensys/gnark/std/algebra/native/sw_bls12377"
+	"github.com/consensys/gnark/test"
+)
+
+var seedFlag = flag.String("seed", "", "hex seed of generated inputs, random if empty")
+
+// testSeed returns the seed of -seed or a random one. The seed is logged if the test fails so
+// that the inputs can be generated again.
+func testSeed(t *testing.T) []byte {
+	seed, err := hex.DecodeString(strings.TrimPrefix(*seedFlag, "0x"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(seed) == 0 {
+		seed = make([]byte, 32)
+		if _, err := rand.Read(seed); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Cleanup(func() {
+		if t.Failed() {
+			t.Logf("inputs derived from -seed=%x", seed)
+		}
+	})
+	return seed
+}
+
+// testInput signs size messages with keys derived from seed, all messages are equal if
+// sameMessage is set.
+func testInput(t *testing.T, curve CurvePair, size int, sameMessage bool, seed []byte) *Input {
+	in, err := GenerateInput(curve, size, sameMessage, seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return in
+}
+
+func testCircuit(t *testing.T, c Config) {
+	assert := test.NewAssert(t)
+	seed := testSeed(t)
+	in := testInput(t, c.Curve, c.Size, c.Scheme == FastAggregate, seed)
+	circuit, err := New(c)
+	assert.NoError(err)
+	assignment, err := Assign(c, in)
+	assert.NoError(err)
+	assert.NoError(test.IsSolved(circuit, assignment, c.Curve.Field()))
+
+	// signature off the curve must not verify
+	setOffCurveSig(t, c, in, assignment)
+	assert.Error(test.IsSolved(circuit, assignment, c.Curve.Field()))
+
+	// signature of another message must not verify
+	in.Signatures[0].Signature = testInput(t, c.Curve, 1, false, seedItem(seed, "other", 0)).Signatures[0].Signature
+	assignment, err = Assign(c, in)
+	assert.NoError(err)
+	assert.Error(test.IsSolved(circuit, assignment, c.Curve.Field()))
+}
+
+// setOffCurveSig replaces the first signature of assignment with the first signature of in
+// whose y coordinate is doubled, a point off the curve.
+func setOffCurveSig(t *testing.T, c Config, in *Input, assignment frontend.Circuit) {
+	var err error
+	switch a := assignment.(type) {
+	case *BN254Circuit:
+		var sigs []bn254.G1Affine
+		if sigs, _, err = decodeBN254(c.Scheme, in); err == nil {
+			sigs[0].Y.Double(&sigs[0].Y)
+			a.Sig[0] = sw_bn254.NewG1Affine(sigs[0])
+		}
+	case *BLS12381Circuit:
+		var sigs []bls12381.G1Affine
+		if sigs, _, err = decodeBLS12381(c.Scheme, in); err == nil {
+			sigs[0].Y.Double(&sigs[0].Y)
+			a.Sig[0] = sw_bls12381.NewG1Affine(sigs[0])
+		}
+	case *BLS12377Circuit:
+		var sigs []bls12377.G1Affine
+		if sigs, _, err = decodeBLS12377(c.Scheme, in); err == nil {
+			sigs[0].Y.Double(&sigs[0].Y)
+			a.Sig[0].Assign(&sigs[0])
+		}
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBLS12377Circuits(t *testing.T) {
+	for _, c := range []Config{
+		{BLS12377InBW6761, Single, 1, NoPkCommitment},
+		{BLS12377InBW6761, Loop, 2, NoPkCommitment},
+		{BLS12377InBW6761, Aggregate, 3, NoPkCommitment},
+		{BLS12377InBW6761, FastAggregate, 3, NoPkCommitment},
+		{BLS12377InBW6761, Loop, 2, MiMCPkCommitment},
+		{BLS12377InBW6761, Aggregate, 3, MiMCPkCommitment},
+		{BLS12377InBW6761, FastAggregate, 2, SHA256PkCommitment},
+	} {
+		t.Run(fmt.Sprintf("%s/%s", c.Scheme, c.PkCommitment), func(t *testing.T) {
+			testCircuit(t, c)
+		})
+	}
+}
+
+type g1BLS12377Circuit struct {
+	P sw_bls12377.G1Affine
+}
+
+func (c *g1BLS12377Circuit) Define(api frontend.API) error {
+	AssertIsOnG1BLS12377(api, c.P)
+	return nil
+}
+
+// TestBLS12377SubgroupCheck checks the curve and subgroup check of signatures, which the native
+// pairing of sw_bls12377 does not make.
+func TestBLS12377SubgroupCheck(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, _, g1, _ := bls12377.Generators()
+	var p bls12377.G1Affine
+	p.ScalarMultiplication(&g1, big.NewInt(0).SetBytes(testSeed(t)))
+	offCurve := p
+	offCurve.Y.Double(&offCurve.Y)
+	var notInG1 bls12377.G1Affine
+	for {
+		notInG1.X.SetRandom()
+		var y2 fp.Element
+		y2.Square(&notInG1.X).Mul(&y2, &notInG1.X).Add(&y2, new(fp.Element).SetOne())
+		if notInG1.Y.Sqrt(&y2) != nil && !notInG1.IsInSubGroup() {
+			break
+		}
+	}
+	for _, tc := range []struct {
+		name  string
+		p     bls12377.G1Affine
+		valid bool
+	}{
+		{"in G1", p, true},
+		{"off curve", offCurve, false},
+		{"not in G1", notInG1, false},
+		{"identity", bls12377.G1Affine{}, false},
+	} {
+		var w g1BLS12377Circuit
+		w.P.Assign(&tc.p)
+		err := test.IsSolved(&g1BLS12377Circuit{}, &w, BLS12377InBW6761.Field())
+		if tc.valid {
+			assert.NoError(err, tc.name)
+		} else {
+			assert.Error(err, tc.name)
+		}
+	}
+}
+
+type g2AddBLS12377Circuit struct {
+	P, Q, R sw_bls12377.G2Affine
+}
+
+func (c *g2AddBLS12377Circuit) Define(api frontend.API) error {
+	sum := addG2BLS12377(api, c.P, c.Q)
+	sum.X.AssertIsEqual(api, c.R.X)
+	sum.Y.AssertIsEqual(api, c.R.Y)
+	return nil
+}
+
+// TestBLS12377FastAggregateKeys checks that the sum of duplicate keys cannot be set by the
+// prover. The unchecked addition takes λ = 0 for p = q and returns (-2p.x, -p.y).
+func TestBLS12377FastAggregateKeys(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, _, _, g2 := bls12377.Generators()
+	var p, q, sum, free bls12377.G2Affine
+	p.ScalarMultiplication(&g2, big.NewInt(0).SetBytes(testSeed(t)))
+	q.Double(&p)
+	sum.Add(&p, &q)
+	free.X.Double(&p.X).Neg(&free.X)
+	free.Y.Neg(&p.Y)
+	for _, tc := range []struct {
+		name    string
+		p, q, r bls12377.G2Affine
+		valid   bool
+	}{
+		{"distinct keys", p, q, sum, true},
+		{"duplicate keys", p, p, free, false},
+	} {
+		var w g2AddBLS12377Circuit
+		w.P.Assign(&tc.p)
+		w.Q.Assign(&tc.q)
+		w.R.Assign(&tc.r)
+		err := test.IsSolved(&g2AddBLS12377Circuit{}, &w, BLS12377InBW6761.Field())
+		if tc.valid {
+			assert.NoError(err, tc.name)
+		} else {
+			assert.Error(err, tc.name)
+		}
+	}
+}
+
+func TestEmulatedCircuits(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping emulated pairing circuits in short mode")
+	}
+	for _, c := range []Config{
+		{BN254, Aggregate, 2, NoPkCommitment},
+		{BN254, FastAggregate, 2, NoPkCommitment},
+		{BLS12381InBN254, Single, 1, NoPkCommitment},
+		{BN254, FastAggregate, 2, MiMCPkCommitment},
+	} {
+		t.Run(fmt.Sprintf("%s/%s/%s", c.Curve, c.Scheme, c.PkCommitment), func(t *testing.T) {
+			testCircuit(t, c)
+		})
+	}
+}
+
+func TestConfig(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, c := range []Config{
+		{BN254, Single, 2, NoPkCommitment},
+		{BN254, Loop, 0, NoPkCommitment},
+		{CurvePair(-1), Loop, 1, NoPkCommitment},
+		{BN254, Scheme(-1), 1, NoPkCommitment},
+		{BN254, Single, 1, PkCommitment(-1)},
+	} {
+		_, err := New(c)
+		assert.Error(err)
+	}
+	in := testInput(t, BN254, 2, false, testSeed(t))
+	_, err := Assign(Config{BN254, FastAggregate, 2, NoPkCommitment}, in)
+	assert.Error(err, "distinct messages must be rejected in fast aggregate scheme")
+	_, err = Assign(Config{BN254, Aggregate, 3, NoPkCommitment}, in)
+	assert.Error(err, "input size must match")
+	in.Signatures[1].PublicKey.Bytes = in.Signatures[1].PublicKey.Bytes[1:]
+	_, err = Assign(Config{BN254, Aggregate, 2, NoPkCommitment}, in)
+	assert.Error(err, "malformed public key must be rejected")
+}
@@ -0,0 +1,311 @@
+package circuits
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	bn254mimc "github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+	bw6761mimc "github.com/consensys/gnark-crypto/ecc/bw6-761/fr/mimc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/std/hash/sha2"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// PkCommitment names how public keys are committed when they are not public inputs.
+type PkCommitment int
+
+const (
+	// NoPkCommitment exposes public keys as public inputs.
+	NoPkCommitment PkCommitment = iota
+	// MiMCPkCommitment exposes the MiMC hash over the circuit scalar field of the coordinates
+	// X.A0, X.A1, Y.A0, Y.A1 of every key. Emulated coordinates are hashed as their canonical
+	// limbs, least significant first.
+	MiMCPkCommitment
+	// SHA256PkCommitment exposes SHA-256 of the keys in gnark-crypto uncompressed encoding,
+	// X.A1 | X.A0 | Y.A1 | Y.A0 big endian, as two public inputs holding the high and the low
+	// 128 bits of the digest.
+	SHA256PkCommitment
+)
+
+var pkCommitmentNames = map[PkCommitment]string{
+	NoPkCommitment:     "none",
+	MiMCPkCommitment:   "mimc",
+	SHA256PkCommitment: "sha256",
+}
+
+// String returns name of the commitment.
+func (k PkCommitment) String() string {
+	if name, ok := pkCommitmentNames[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("PkCommitment(%d)", int(k))
+}
+
+// ParsePkCommitment returns the commitment with given name.
+func ParsePkCommitment(name string) (PkCommitment, error) {
+	for k, n := range pkCommitmentNames {
+		if n == name {
+			return k, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown public key commitment %q", name)
+}
+
+// MarshalJSON encodes commitment by name.
+func (k PkCommitment) MarshalJSON() ([]byte, error) {
+	return json.Marshal(k.String())
+}
+
+// UnmarshalJSON decodes commitment by name.
+func (k *PkCommitment) UnmarshalJSON(in []byte) error {
+	var n string
+	if err := json.Unmarshal(in, &n); err != nil {
+		return err
+	}
+	v, err := ParsePkCommitment(n)
+	if err != nil {
+		return err
+	}
+	*k = v
+	return nil
+}
+
+// size returns number of public inputs of the commitment.
+func (k PkCommitment) size() int {
+	switch k {
+	case MiMCPkCommitment:
+		return 1
+	case SHA256PkCommitment:
+		return 2
+	}
+	return 0
+}
+
+// Commitment is a public key commitment input. It is wrapped in a struct as circuits without
+// commitment leave their slice of commitments empty.
+type Commitment struct {
+	Value frontend.Variable
+}
+
+// assertPkCommitmentEmulated asserts the commitment to public keys given by their coordinates
+// X.A0, X.A1, Y.A0, Y.A1, which must be canonical.
+func assertPkCommitmentEmulated[T emulated.FieldParams](api frontend.API, k PkCommitment, coords []*emulated.Element[T], commitment []Commitment) error {
+	f, err := emulated.NewField[T](api)
+	if err != nil {
+		return err
+	}
+	for _, e := range coords {
+		f.AssertIsInRange(e)
+	}
+	if k == SHA256PkCommitment {
+		var params T
+		bits := make([][]frontend.Variable, len(coords))
+		for i := range coords {
+			bits[i] = f.ToBits(coords[i])
+		}
+		return assertSHA256Commitment(api, bits, (params.Modulus().BitLen()+7)/8, commitment)
+	}
+	var limbs []frontend.Variable
+	for _, e := range coords {
+		limbs = append(limbs, e.Limbs...)
+	}
+	return assertMiMCCommitment(api, limbs, commitment[0].Value)
+}
+
+// assertPkCommitment asserts the commitment to public keys given by their native coordinates
+// X.A0, X.A1, Y.A0, Y.A1 of size bytes.
+func assertPkCommitment(api frontend.API, k PkCommitment, coords []frontend.Variable, size int, commitment []Commitment) error {
+	if k == SHA256PkCommitment {
+		bits := make([][]frontend.Variable, len(coords))
+		for i := range coords {
+			bits[i] = api.ToBinary(coords[i])
+		}
+		return assertSHA256Commitment(api, bits, size, commitment)
+	}
+	return assertMiMCCommitment(api, coords, commitment[0].Value)
+}
+
+// assertMiMCCommitment asserts that commitment is MiMC of the variables.
+func assertMiMCCommitment(api frontend.API, vars []frontend.Variable, commitment frontend.Variable) error {
+	h, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	h.Write(vars...)
+	api.AssertIsEqual(h.Sum(), commitment)
+	return nil
+}
+
+// assertSHA256Commitment asserts that commitment is SHA-256 of the keys serialized as
+// X.A1 | X.A0 | Y.A1 | Y.A0. Coordinates are given by their little endian bits and written
+// in size bytes big endian.
+func assertSHA256Commitment(api frontend.API, coords [][]frontend.Variable, size int, commitment []Commitment) error {
+	bf, err := uints.New[uints.U32](api)
+	if err != nil {
+		return err
+	}
+	h, err := sha2.New(api)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(coords); i += 4 {
+		for _, j := range []int{1, 0, 3, 2} {
+			bits := coords[i+j]
+			for len(bits) < 8*size {
+				bits = append(bits, 0)
+			}
+			b := make([]uints.U8, size)
+			for k := range b {
+				b[k] = bf.ByteValueOf(api.FromBinary(bits[8*(size-1-k) : 8*(size-k)]...))
+			}
+			h.Write(b)
+		}
+	}
+	digest := h.Sum()
+	for i := range commitment {
+		var v frontend.Variable = 0
+		for _, b := range digest[16*i : 16*(i+1)] {
+			v = api.Add(api.Mul(v, 256), b.Val)
+		}
+		api.AssertIsEqual(v, commitment[i].Value)
+	}
+	return nil
+}
+
+// emulatedLimbs appends the limbs of v as the emulated field T splits them.
+func emulatedLimbs[T emulated.FieldParams](limbs []*big.Int, v *big.Int) []*big.Int {
+	var params T
+	mask := new(big.Int).Lsh(big.NewInt(1), params.BitsPerLimb())
+	mask.Sub(mask, big.NewInt(1))
+	for i := uint(0); i < params.NbLimbs(); i++ {
+		l := new(big.Int).Rsh(v, i*params.BitsPerLimb())
+		limbs = append(limbs, l.And(l, mask))
+	}
+	return limbs
+}
+
+// mimcSum returns MiMC of the values, each written as a block of blockSize bytes.
+func mimcSum(h interface {
+	Write([]byte) (int, error)
+	Sum([]byte) []byte
+}, blockSize int, values []*big.Int) *big.Int {
+	block := make([]byte, blockSize)
+	for _, v := range values {
+		h.Write(v.FillBytes(block))
+	}
+	return new(big.Int).SetBytes(h.Sum(nil))
+}
+
+// sha256Sum returns the high and low 128 bits of SHA-256 of the concatenated keys.
+func sha256Sum(keys [][]byte) []*big.Int {
+	h := sha256.New()
+	for _, k := range keys {
+		h.Write(k)
+	}
+	digest := h.Sum(nil)
+	return []*big.Int{new(big.Int).SetBytes(digest[:16]), new(big.Int).SetBytes(digest[16:])}
+}
+
+// CommitmentBN254 returns the public key commitment inputs of a BN254 circuit.
+func CommitmentBN254(k PkCommitment, pks []bn254.G2Affine) []*big.Int {
+	switch k {
+	case MiMCPkCommitment:
+		var limbs []*big.Int
+		for i := range pks {
+			for _, c := range []*big.Int{
+				pks[i].X.A0.BigInt(new(big.Int)), pks[i].X.A1.BigInt(new(big.Int)),
+				pks[i].Y.A0.BigInt(new(big.Int)), pks[i].Y.A1.BigInt(new(big.Int)),
+			} {
+				limbs = emulatedLimbs[emulated.BN254Fp](limbs, c)
+			}
+		}
+		return []*big.Int{mimcSum(bn254mimc.NewMiMC(), bn254mimc.BlockSize, limbs)}
+	case SHA256PkCommitment:
+		keys := make([][]byte, len(pks))
+		for i := range pks {
+			b := pks[i].RawBytes()
+			keys[i] = b[:]
+		}
+		return sha256Sum(keys)
+	}
+	return nil
+}
+
+// CommitmentBLS12381 returns the public key commitment inputs of a BLS12-381 in BN254 circuit.
+func CommitmentBLS12381(k PkCommitment, pks []bls12381.G2Affine) []*big.Int {
+	switch k {
+	case MiMCPkCommitment:
+		var limbs []*big.Int
+		for i := range pks {
+			for _, c := range []*big.Int{
+				pks[i].X.A0.BigInt(new(big.Int)), pks[i].X.A1.BigInt(new(big.Int)),
+				pks[i].Y.A0.BigInt(new(big.Int)), pks[i].Y.A1.BigInt(new(big.Int)),
+			} {
+				limbs = emulatedLimbs[emulated.BLS12381Fp](limbs, c)
+			}
+		}
+		return []*big.Int{mimcSum(bn254mimc.NewMiMC(), bn254mimc.BlockSize, limbs)}
+	case SHA256PkCommitment:
+		keys := make([][]byte, len(pks))
+		for i := range pks {
+			b := pks[i].RawBytes()
+			keys[i] = b[:]
+		}
+		return sha256Sum(keys)
+	}
+	return nil
+}
+
+// CommitmentBLS12377 returns the public key commitment inputs of a BLS12-377 in BW6-761 circuit.
+func CommitmentBLS12377(k PkCommitment, pks []bls12377.G2Affine) []*big.Int {
+	switch k {
+	case MiMCPkCommitment:
+		var coords []*big.Int
+		for i := range pks {
+			coords = append(coords,
+				pks[i].X.A0.BigInt(new(big.Int)), pks[i].X.A1.BigInt(new(big.Int)),
+				pks[i].Y.A0.BigInt(new(big.Int)), pks[i].Y.A1.BigInt(new(big.Int)),
+			)
+		}
+		return []*big.Int{mimcSum(bw6761mimc.NewMiMC(), bw6761mimc.BlockSize, coords)}
+	case SHA256PkCommitment:
+		keys := make([][]byte, len(pks))
+		for i := range pks {
+			b := pks[i].RawBytes()
+			keys[i] = b[:]
+		}
+		return sha256Sum(keys)
+	}
+	return nil
+}
+
+// PkCommitment returns the public key commitment inputs of the input for circuits of the
+// variant.
+func (in *Input) PkCommitment(c Config) ([]*big.Int, error) {
+	switch c.Curve {
+	case BN254:
+		pks, err := decodePublicKeysBN254(in)
+		if err != nil {
+			return nil, err
+		}
+		return CommitmentBN254(c.PkCommitment, pks), nil
+	case BLS12381InBN254:
+		pks, err := decodePublicKeysBLS12381(in)
+		if err != nil {
+			return nil, err
+		}
+		return CommitmentBLS12381(c.PkCommitment, pks), nil
+	}
+	pks, err := decodePublicKeysBLS12377(in)
+	if err != nil {
+		return nil, err
+	}
+	return CommitmentBLS12377(c.PkCommitment, pks), nil
+}
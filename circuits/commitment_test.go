@@ -0,0 +1,142 @@
+package circuits
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bn254"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/test"
+)
+
+// commitmentCircuit checks the commitment of public keys without verifying signatures.
+type commitmentCircuit[T emulated.FieldParams] struct {
+	kind       PkCommitment
+	Coords     []emulated.Element[T]
+	Commitment []Commitment `gnark:",public"`
+}
+
+func newCommitmentCircuit[T emulated.FieldParams](k PkCommitment, pks int) *commitmentCircuit[T] {
+	return &commitmentCircuit[T]{
+		kind:       k,
+		Coords:     make([]emulated.Element[T], 4*pks),
+		Commitment: make([]Commitment, k.size()),
+	}
+}
+
+func (c *commitmentCircuit[T]) Define(api frontend.API) error {
+	coords := make([]*emulated.Element[T], len(c.Coords))
+	for i := range c.Coords {
+		coords[i] = &c.Coords[i]
+	}
+	return assertPkCommitmentEmulated(api, c.kind, coords, c.Commitment)
+}
+
+func (c *commitmentCircuit[T]) assign(values []*big.Int) {
+	for i := range values {
+		c.Commitment[i].Value = values[i]
+	}
+}
+
+func TestCommitmentEmulated(t *testing.T) {
+	for _, k := range []PkCommitment{MiMCPkCommitment, SHA256PkCommitment} {
+		t.Run(k.String(), func(t *testing.T) {
+			assert := test.NewAssert(t)
+
+			in := testInput(t, BN254, 2, false, testSeed(t))
+			pks, err := decodePublicKeysBN254(in)
+			assert.NoError(err)
+			w := newCommitmentCircuit[emulated.BN254Fp](k, 2)
+			for i := range pks {
+				pk := sw_bn254.NewG2Affine(pks[i])
+				copy(w.Coords[4*i:], []emulated.Element[emulated.BN254Fp]{pk.X.A0, pk.X.A1, pk.Y.A0, pk.Y.A1})
+			}
+			w.assign(CommitmentBN254(k, pks))
+			circuit := newCommitmentCircuit[emulated.BN254Fp](k, 2)
+			assert.NoError(test.IsSolved(circuit, w, BN254.Field()))
+			w.Commitment[0].Value = new(big.Int).Add(w.Commitment[0].Value.(*big.Int), big.NewInt(1))
+			assert.Error(test.IsSolved(circuit, w, BN254.Field()))
+
+			in = testInput(t, BLS12381InBN254, 2, false, testSeed(t))
+			pks381, err := decodePublicKeysBLS12381(in)
+			assert.NoError(err)
+			w381 := newCommitmentCircuit[emulated.BLS12381Fp](k, 2)
+			for i := range pks381 {
+				pk := sw_bls12381.NewG2Affine(pks381[i])
+				copy(w381.Coords[4*i:], []emulated.Element[emulated.BLS12381Fp]{pk.X.A0, pk.X.A1, pk.Y.A0, pk.Y.A1})
+			}
+			w381.assign(CommitmentBLS12381(k, pks381))
+			circuit381 := newCommitmentCircuit[emulated.BLS12381Fp](k, 2)
+			assert.NoError(test.IsSolved(circuit381, w381, BLS12381InBN254.Field()))
+
+			// keys in another order commit to another value
+			pks381[0], pks381[1] = pks381[1], pks381[0]
+			w381.assign(CommitmentBLS12381(k, pks381))
+			assert.Error(test.IsSolved(circuit381, w381, BLS12381InBN254.Field()))
+		})
+	}
+}
+
+func TestCommitmentBLS12377(t *testing.T) {
+	for _, k := range []PkCommitment{MiMCPkCommitment, SHA256PkCommitment} {
+		t.Run(k.String(), func(t *testing.T) {
+			assert := test.NewAssert(t)
+			c := Config{BLS12377InBW6761, Aggregate, 2, k}
+			seed := testSeed(t)
+			in := testInput(t, c.Curve, c.Size, false, seed)
+			circuit, err := New(c)
+			assert.NoError(err)
+			w, err := Assign(c, in)
+			assert.NoError(err)
+			commitment, err := in.PkCommitment(c)
+			assert.NoError(err)
+			assigned := w.(*BLS12377Circuit)
+			assert.Equal(0, len(assigned.Pk))
+			assert.Equal(k.size(), len(commitment))
+			for i := range commitment {
+				assert.Equal(commitment[i], assigned.PkCommitment[i].Value)
+			}
+
+			last := len(commitment) - 1
+			assigned.PkCommitment[last].Value = new(big.Int).Add(commitment[last], big.NewInt(1))
+			assert.Error(test.IsSolved(circuit, assigned, c.Curve.Field()))
+
+			// public keys of another input
+			other, err := Assign(c, testInput(t, c.Curve, c.Size, false, seedItem(seed, "other", 0)))
+			assert.NoError(err)
+			assigned.PkCommitment = other.(*BLS12377Circuit).PkCommitment
+			assert.Error(test.IsSolved(circuit, assigned, c.Curve.Field()))
+		})
+	}
+}
+
+func TestInputPkCommitment(t *testing.T) {
+	assert := test.NewAssert(t)
+	in := testInput(t, BN254, 3, false, testSeed(t))
+	pks, err := decodePublicKeysBN254(in)
+	assert.NoError(err)
+	c := Config{BN254, Aggregate, 3, MiMCPkCommitment}
+	commitment, err := in.PkCommitment(c)
+	assert.NoError(err)
+	assert.Equal(CommitmentBN254(MiMCPkCommitment, pks), commitment)
+
+	// SHA-256 commitment is the digest of the uncompressed keys
+	c.PkCommitment = SHA256PkCommitment
+	commitment, err = in.PkCommitment(c)
+	assert.NoError(err)
+	var raw []byte
+	for i := range pks {
+		b := pks[i].RawBytes()
+		raw = append(raw, b[:]...)
+	}
+	digest := sha256.Sum256(raw)
+	assert.Equal(new(big.Int).SetBytes(digest[:16]), commitment[0])
+	assert.Equal(new(big.Int).SetBytes(digest[16:]), commitment[1])
+
+	in.Signatures[2].PublicKey.Bytes = in.Signatures[2].PublicKey.Bytes[1:]
+	_, err = in.PkCommitment(c)
+	assert.Error(err)
+}
@@ -0,0 +1,97 @@
+package circuits
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/consensys/gnark/frontend"
+
+	bls_tools "gnark/aggregate/bls-tools"
+)
+
+// Fixture is an input derived from a seed, so that a failing proof can be reproduced from
+// the seed alone. Its JSON encoding keeps the input for circuits compiled elsewhere.
+type Fixture struct {
+	Seed   HexBytes `json:"seed"`
+	Config Config   `json:"config"`
+	Input  *Input   `json:"input"`
+}
+
+// seedItem derives the bytes of the i-th item of given kind from seed.
+func seedItem(seed []byte, kind string, i int) []byte {
+	h := sha256.New()
+	h.Write(seed)
+	h.Write([]byte(kind))
+	_ = binary.Write(h, binary.BigEndian, uint32(i))
+	return h.Sum(nil)
+}
+
+// GenerateInput signs size messages with keys derived from seed by bls_tools.GenerateKey.
+// Messages are derived from seed too, all of them are equal if sameMessage is set.
+func GenerateInput(c CurvePair, size int, sameMessage bool, seed []byte) (*Input, error) {
+	scheme := bls_tools.NewBasicScheme(c.SchemeCurve())
+	curve := c
+	in := &Input{Curve: &curve}
+	for i := 0; i < size; i++ {
+		msg := seedItem(seed, "message", i)
+		if sameMessage {
+			msg = seedItem(seed, "message", 0)
+		}
+		sk := bls_tools.GenerateKey(scheme.Curve(), seedItem(seed, "key", i))
+		sig, err := scheme.Sign(sk, msg)
+		if err != nil {
+			return nil, err
+		}
+		in.Signatures = append(in.Signatures, SignedMessage{
+			PublicKey: *NewPoint(scheme.Curve().PublicKey(sk)),
+			Message:   msg,
+			Signature: NewPoint(sig),
+		})
+	}
+	return in, nil
+}
+
+// GenerateFixture returns the input of the circuit variant derived from seed.
+func GenerateFixture(c Config, seed []byte) (*Fixture, error) {
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	in, err := GenerateInput(c.Curve, c.Size, c.Scheme == FastAggregate, seed)
+	if err != nil {
+		return nil, err
+	}
+	return &Fixture{Seed: seed, Config: c, Input: in}, nil
+}
+
+// ReadFixture reads a fixture written by WriteFixture.
+func ReadFixture(path string) (*Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	f := new(Fixture)
+	if err := json.Unmarshal(data, f); err != nil {
+		return nil, err
+	}
+	if f.Input == nil {
+		return nil, fmt.Errorf("fixture %s has no input", path)
+	}
+	return f, nil
+}
+
+// WriteFixture writes f in JSON.
+func WriteFixture(path string, f *Fixture) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Assign returns the circuit of the fixture variant assigned with its input.
+func (f *Fixture) Assign() (frontend.Circuit, error) {
+	return Assign(f.Config, f.Input)
+}
@@ -0,0 +1,90 @@
+package circuits
+
+import (
+	"bytes"
+	"flag"
+	"path/filepath"
+	"testing"
+
+	"github.com/consensys/gnark/test"
+
+	bls_tools "gnark/aggregate/bls-tools"
+)
+
+var updateFixtures = flag.Bool("update", false, "regenerate fixtures in testdata")
+
+// fixtureConfigs are the variants of the fixtures in testdata, generated from the seed "fixture".
+var fixtureConfigs = map[string]Config{
+	"fixture_bls12377-in-bw6761.json": {BLS12377InBW6761, Aggregate, 2, NoPkCommitment},
+	"fixture_bls12381-in-bn254.json":  {BLS12381InBN254, Single, 1, NoPkCommitment},
+	"fixture_bn254.json":              {BN254, FastAggregate, 2, NoPkCommitment},
+}
+
+func TestGenerateFixtures(t *testing.T) {
+	if !*updateFixtures {
+		t.Skip("run with -update to regenerate fixtures")
+	}
+	for name, c := range fixtureConfigs {
+		f, err := GenerateFixture(c, []byte("fixture"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := WriteFixture(filepath.Join("testdata", name), f); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// TestFixtureTestdata checks that fixtures are derived from their seed and are valid inputs.
+func TestFixtureTestdata(t *testing.T) {
+	assert := test.NewAssert(t)
+	for name, c := range fixtureConfigs {
+		f, err := ReadFixture(filepath.Join("testdata", name))
+		assert.NoError(err)
+		assert.Equal(c, f.Config, name)
+		generated, err := GenerateFixture(f.Config, f.Seed)
+		assert.NoError(err)
+		assert.Equal(generated, f, "%s is not derived from its seed", name)
+		assert.NoError(f.Input.Validate(f.Config), name)
+	}
+}
+
+func TestFixture(t *testing.T) {
+	assert := test.NewAssert(t)
+	seed := testSeed(t)
+	c := Config{BLS12377InBW6761, Aggregate, 2, NoPkCommitment}
+	f, err := GenerateFixture(c, seed)
+	assert.NoError(err)
+	again, err := GenerateFixture(c, seed)
+	assert.NoError(err)
+	assert.Equal(f, again, "fixture must be derived from the seed only")
+	other, err := GenerateFixture(c, seedItem(seed, "other", 0))
+	assert.NoError(err)
+	assert.NotEqual(f.Input.Signatures[0].PublicKey, other.Input.Signatures[0].PublicKey)
+
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	assert.NoError(WriteFixture(path, f))
+	read, err := ReadFixture(path)
+	assert.NoError(err)
+	assert.Equal(f, read)
+
+	// the fixture replays into the circuit of its variant
+	circuit, err := New(c)
+	assert.NoError(err)
+	assignment, err := read.Assign()
+	assert.NoError(err)
+	assert.NoError(test.IsSolved(circuit, assignment, c.Curve.Field()))
+
+	_, err = GenerateFixture(Config{BLS12377InBW6761, Single, 2, NoPkCommitment}, seed)
+	assert.Error(err, "invalid variant must be rejected")
+}
+
+// The basic scheme of bls-tools hashes messages as the circuits do.
+func TestSchemeCurveDST(t *testing.T) {
+	for _, c := range []CurvePair{BN254, BLS12381InBN254, BLS12377InBW6761} {
+		scheme := bls_tools.NewBasicScheme(c.SchemeCurve())
+		if !bytes.Equal(scheme.DST(), c.DST()) {
+			t.Fatalf("%s: scheme DST %s, circuit uses %s", c, scheme.DST(), c.DST())
+		}
+	}
+}
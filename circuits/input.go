@@ -0,0 +1,348 @@
+package circuits
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// Input is a list of signed messages with public keys of the signers. Its JSON and CBOR
+// schema is documented in INPUT.md. For aggregate schemes the aggregate signature may be
+// given in Signature, otherwise signatures of the entries are aggregated.
+type Input struct {
+	Curve      *CurvePair      `json:"curve,omitempty" cbor:"curve,omitempty"`
+	Signatures []SignedMessage `json:"signatures" cbor:"signatures"`
+	Signature  *Point          `json:"signature,omitempty" cbor:"signature,omitempty"`
+}
+
+// SignedMessage is a message signed with a single key.
+type SignedMessage struct {
+	PublicKey Point    `json:"pubkey" cbor:"pubkey"`
+	Message   HexBytes `json:"message" cbor:"message"`
+	Signature *Point   `json:"signature,omitempty" cbor:"signature,omitempty"`
+}
+
+// ReadInput reads and decodes an input file in JSON or CBOR.
+func ReadInput(path string) (*Input, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return UnmarshalInput(data)
+}
+
+// UnmarshalInput decodes an input in JSON or CBOR. Encoding is detected from the first byte,
+// a JSON object starts with '{' and a CBOR map with major type 5.
+func UnmarshalInput(data []byte) (*Input, error) {
+	in := new(Input)
+	trimmed := bytes.TrimLeft(data, " \t\r\n")
+	switch {
+	case len(trimmed) > 0 && trimmed[0] == '{':
+		if err := json.Unmarshal(data, in); err != nil {
+			return nil, err
+		}
+	case len(data) > 0 && data[0]>>5 == 5:
+		if err := cbor.Unmarshal(data, in); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.New("input is neither a JSON object nor a CBOR map")
+	}
+	return in, nil
+}
+
+// Validate checks that the input fits the circuit variant and that all points
+// are well formed, on curve and in correct subgroup.
+func (in *Input) Validate(c Config) error {
+	if err := c.validate(); err != nil {
+		return err
+	}
+	if err := in.check(c); err != nil {
+		return err
+	}
+	var err error
+	switch c.Curve {
+	case BN254:
+		_, _, err = decodeBN254(c.Scheme, in)
+	case BLS12381InBN254:
+		_, _, err = decodeBLS12381(c.Scheme, in)
+	default:
+		_, _, err = decodeBLS12377(c.Scheme, in)
+	}
+	return err
+}
+
+// check validates the input against the circuit variant without decoding points.
+func (in *Input) check(c Config) error {
+	if in.Curve != nil && *in.Curve != c.Curve {
+		return fmt.Errorf("input is for %s, circuit is for %s", *in.Curve, c.Curve)
+	}
+	if len(in.Signatures) != c.Size {
+		return fmt.Errorf("input has %d signatures, circuit expects %d", len(in.Signatures), c.Size)
+	}
+	if c.Scheme == FastAggregate {
+		for _, s := range in.Signatures[1:] {
+			if !bytes.Equal(s.Message, in.Signatures[0].Message) {
+				return errors.New("messages of fast aggregate scheme must be equal")
+			}
+		}
+	}
+	if in.Signature != nil && c.Scheme != Aggregate && c.Scheme != FastAggregate {
+		return fmt.Errorf("aggregate signature is given for %s scheme", c.Scheme)
+	}
+	return nil
+}
+
+// signatureInputs returns signatures of the entries or the given aggregate signature.
+func (in *Input) signatureInputs(s Scheme) ([]*Point, error) {
+	if in.Signature != nil {
+		return []*Point{in.Signature}, nil
+	}
+	sigs := make([]*Point, len(in.Signatures))
+	for i, m := range in.Signatures {
+		if m.Signature == nil {
+			return nil, fmt.Errorf("signature %d is missing", i)
+		}
+		sigs[i] = m.Signature
+	}
+	return sigs, nil
+}
+
+// MarshalCBOR encodes curve pair by name.
+func (c CurvePair) MarshalCBOR() ([]byte, error) {
+	return cbor.Marshal(c.String())
+}
+
+// UnmarshalCBOR decodes curve pair by name.
+func (c *CurvePair) UnmarshalCBOR(in []byte) error {
+	var s string
+	if err := cbor.Unmarshal(in, &s); err != nil {
+		return err
+	}
+	v, err := ParseCurvePair(s)
+	if err != nil {
+		return err
+	}
+	*c = v
+	return nil
+}
+
+// HexBytes is a byte string encoded in hex in JSON with optional 0x prefix
+// and as a byte string in CBOR.
+type HexBytes []byte
+
+// MarshalJSON encodes bytes in 0x prefixed hex.
+func (h HexBytes) MarshalJSON() ([]byte, error) {
+	return json.Marshal("0x" + hex.EncodeToString(h))
+}
+
+// UnmarshalJSON decodes hex string with optional 0x prefix.
+func (h *HexBytes) UnmarshalJSON(in []byte) error {
+	var s string
+	if err := json.Unmarshal(in, &s); err != nil {
+		return err
+	}
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return err
+	}
+	*h = b
+	return nil
+}
+
+// Point is a curve point of an input, given either by its encoding or by affine coordinates.
+// Encoding is the gnark-crypto one, compressed or uncompressed, which is the zcash layout.
+// Coordinates are x, y for G1 and x.A0, x.A1, y.A0, y.A1 for G2, as decimal or 0x prefixed hex.
+type Point struct {
+	Bytes  []byte
+	Coords []string
+}
+
+// NewPoint returns a point given by its encoding.
+func NewPoint(encoding []byte) *Point {
+	return &Point{Bytes: encoding}
+}
+
+type g1Coords struct {
+	X string `json:"X" cbor:"X"`
+	Y string `json:"Y" cbor:"Y"`
+}
+
+type fp2Coord struct {
+	A0 string `json:"A0" cbor:"A0"`
+	A1 string `json:"A1" cbor:"A1"`
+}
+
+type g2Coords struct {
+	X fp2Coord `json:"X" cbor:"X"`
+	Y fp2Coord `json:"Y" cbor:"Y"`
+}
+
+// coordsValue returns coordinates in object form.
+func (p Point) coordsValue() (interface{}, error) {
+	switch len(p.Coords) {
+	case 2:
+		return g1Coords{p.Coords[0], p.Coords[1]}, nil
+	case 4:
+		return g2Coords{fp2Coord{p.Coords[0], p.Coords[1]}, fp2Coord{p.Coords[2], p.Coords[3]}}, nil
+	}
+	return nil, fmt.Errorf("point must have 2 or 4 coordinates, has %d", len(p.Coords))
+}
+
+// setCoordsValue sets coordinates from a decoded object of G1 or G2 form.
+func (p *Point) setCoordsValue(x, y interface{}) error {
+	p.Coords = p.Coords[:0]
+	for _, v := range []interface{}{x, y} {
+		switch c := v.(type) {
+		case string:
+			p.Coords = append(p.Coords, c)
+		case map[string]interface{}:
+			a0, ok0 := c["A0"].(string)
+			a1, ok1 := c["A1"].(string)
+			if !ok0 || !ok1 || len(c) != 2 {
+				return errors.New("Fp2 coordinate must have string A0 and A1")
+			}
+			p.Coords = append(p.Coords, a0, a1)
+		default:
+			return errors.New("coordinate must be a string or an Fp2 object")
+		}
+	}
+	if len(p.Coords) != 2 && len(p.Coords) != 4 {
+		return errors.New("coordinates must be both in Fp or both in Fp2")
+	}
+	return nil
+}
+
+// MarshalJSON encodes the point as hex string of its encoding or as coordinates object.
+func (p Point) MarshalJSON() ([]byte, error) {
+	if p.Bytes != nil {
+		return HexBytes(p.Bytes).MarshalJSON()
+	}
+	v, err := p.coordsValue()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON decodes a hex string or a coordinates object.
+func (p *Point) UnmarshalJSON(in []byte) error {
+	*p = Point{}
+	var h HexBytes
+	if err := h.UnmarshalJSON(in); err == nil {
+		p.Bytes = h
+		return nil
+	}
+	var v map[string]interface{}
+	if err := json.Unmarshal(in, &v); err != nil {
+		return errors.New("point must be a hex string or a coordinates object")
+	}
+	if len(v) != 2 {
+		return errors.New("coordinates object must have X and Y only")
+	}
+	return p.setCoordsValue(v["X"], v["Y"])
+}
+
+// MarshalCBOR encodes the point as byte string of its encoding or as coordinates map.
+func (p Point) MarshalCBOR() ([]byte, error) {
+	if p.Bytes != nil {
+		return cbor.Marshal(p.Bytes)
+	}
+	v, err := p.coordsValue()
+	if err != nil {
+		return nil, err
+	}
+	return cbor.Marshal(v)
+}
+
+// UnmarshalCBOR decodes a byte string or a coordinates map.
+func (p *Point) UnmarshalCBOR(in []byte) error {
+	*p = Point{}
+	if err := cbor.Unmarshal(in, &p.Bytes); err == nil {
+		return nil
+	}
+	p.Bytes = nil
+	var v map[string]interface{}
+	if err := cbor.Unmarshal(in, &v); err != nil {
+		return errors.New("point must be a byte string or a coordinates map")
+	}
+	if len(v) != 2 {
+		return errors.New("coordinates map must have X and Y only")
+	}
+	for _, k := range []string{"X", "Y"} {
+		// nested maps are decoded with interface keys
+		if m, ok := v[k].(map[interface{}]interface{}); ok {
+			c := make(map[string]interface{}, len(m))
+			for mk, mv := range m {
+				s, ok := mk.(string)
+				if !ok {
+					return errors.New("coordinate keys must be strings")
+				}
+				c[s] = mv
+			}
+			v[k] = c
+		}
+	}
+	return p.setCoordsValue(v["X"], v["Y"])
+}
+
+// curvePoint is an affine point of gnark-crypto.
+type curvePoint interface {
+	SetBytes([]byte) (int, error)
+	IsOnCurve() bool
+	IsInSubGroup() bool
+	IsInfinity() bool
+}
+
+// decode sets p from the point and checks that it is on curve and in correct subgroup.
+// Coordinates must be canonical, set writes them into p.
+func (pt *Point) decode(p curvePoint, modulus *big.Int, nbCoords int, set func(v []*big.Int)) error {
+	switch {
+	case pt == nil || (pt.Bytes == nil && pt.Coords == nil):
+		return errors.New("point is missing")
+	case pt.Bytes != nil && pt.Coords != nil:
+		return errors.New("point must be given either by encoding or by coordinates")
+	case pt.Bytes != nil:
+		// SetBytes checks subgroup membership
+		return decodePoint(p, pt.Bytes)
+	}
+	if len(pt.Coords) != nbCoords {
+		return fmt.Errorf("point must have %d coordinates, has %d", nbCoords, len(pt.Coords))
+	}
+	v := make([]*big.Int, nbCoords)
+	for i, s := range pt.Coords {
+		var ok bool
+		if v[i], ok = new(big.Int).SetString(s, 0); !ok {
+			return fmt.Errorf("invalid coordinate %q", s)
+		}
+		if v[i].Sign() < 0 || v[i].Cmp(modulus) >= 0 {
+			return fmt.Errorf("coordinate %q is not in base field", s)
+		}
+	}
+	set(v)
+	if !p.IsOnCurve() {
+		return errors.New("point is not on curve")
+	}
+	if !p.IsInSubGroup() {
+		return errors.New("point is not in correct subgroup")
+	}
+	return nil
+}
+
+// decodePublicKey decodes a public key which must not be the point at infinity.
+func (pt *Point) decodePublicKey(p curvePoint, modulus *big.Int, set func(v []*big.Int)) error {
+	if err := pt.decode(p, modulus, 4, set); err != nil {
+		return err
+	}
+	if p.IsInfinity() {
+		return errors.New("public key is the point at infinity")
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package circuits
+
+import (
+	"encoding/json"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fp"
+	"github.com/consensys/gnark/test"
+	"github.com/fxamacker/cbor/v2"
+)
+
+// coordsInput returns the input with points given by affine coordinates.
+func coordsInput(t *testing.T, in *Input) *Input {
+	out := &Input{Curve: in.Curve}
+	for _, s := range in.Signatures {
+		var pk bls12377.G2Affine
+		var sig bls12377.G1Affine
+		if err := decodePoint(&pk, s.PublicKey.Bytes); err != nil {
+			t.Fatal(err)
+		}
+		if err := decodePoint(&sig, s.Signature.Bytes); err != nil {
+			t.Fatal(err)
+		}
+		out.Signatures = append(out.Signatures, SignedMessage{
+			PublicKey: Point{Coords: []string{pk.X.A0.String(), pk.X.A1.String(), pk.Y.A0.String(), pk.Y.A1.String()}},
+			Message:   s.Message,
+			Signature: &Point{Coords: []string{sig.X.String(), sig.Y.String()}},
+		})
+	}
+	return out
+}
+
+func TestInputEncoding(t *testing.T) {
+	assert := test.NewAssert(t)
+	c := Config{BLS12377InBW6761, Aggregate, 2, NoPkCommitment}
+	in := testInput(t, c.Curve, c.Size, false, testSeed(t))
+	in.Curve = &c.Curve
+	for name, in := range map[string]*Input{"bytes": in, "coordinates": coordsInput(t, in)} {
+		assert.Run(func(assert *test.Assert) {
+			assert.NoError(in.Validate(c))
+
+			data, err := json.Marshal(in)
+			assert.NoError(err)
+			decoded, err := UnmarshalInput(data)
+			assert.NoError(err)
+			assert.Equal(in, decoded)
+			assert.NoError(decoded.Validate(c))
+
+			data, err = cbor.Marshal(in)
+			assert.NoError(err)
+			decoded, err = UnmarshalInput(data)
+			assert.NoError(err)
+			assert.Equal(in, decoded)
+			assert.NoError(decoded.Validate(c))
+		}, name)
+	}
+	_, err := UnmarshalInput([]byte("[]"))
+	assert.Error(err, "input must be an object")
+}
+
+func TestInputInvalidPoints(t *testing.T) {
+	assert := test.NewAssert(t)
+	c := Config{BLS12377InBW6761, Loop, 2, NoPkCommitment}
+	valid := coordsInput(t, testInput(t, c.Curve, c.Size, false, testSeed(t)))
+	assert.NoError(valid.Validate(c))
+
+	p := fp.Modulus()
+	for name, corrupt := range map[string]func(in *Input){
+		"not on curve": func(in *Input) {
+			in.Signatures[0].Signature.Coords[1] = "1"
+		},
+		"non canonical": func(in *Input) {
+			y, _ := new(big.Int).SetString(in.Signatures[0].Signature.Coords[1], 10)
+			in.Signatures[0].Signature.Coords[1] = y.Add(y, p).String()
+		},
+		"negative coordinate": func(in *Input) {
+			in.Signatures[1].Signature.Coords[0] = "-1"
+		},
+		"G1 public key": func(in *Input) {
+			in.Signatures[0].PublicKey = *in.Signatures[1].Signature
+		},
+		"infinity public key": func(in *Input) {
+			in.Signatures[0].PublicKey = Point{Coords: []string{"0", "0", "0", "0"}}
+		},
+		"missing signature": func(in *Input) {
+			in.Signatures[1].Signature = nil
+		},
+		"both forms": func(in *Input) {
+			in.Signatures[1].Signature.Bytes = []byte{0}
+		},
+		"aggregate signature of loop": func(in *Input) {
+			in.Signature = in.Signatures[0].Signature
+		},
+		"other curve": func(in *Input) {
+			curve := BN254
+			in.Curve = &curve
+		},
+	} {
+		in := coordsInput(t, testInput(t, c.Curve, c.Size, false, testSeed(t)))
+		corrupt(in)
+		assert.Error(in.Validate(c), name)
+	}
+
+	// point not in subgroup: (0, 1) is on curve y² = x³ + 1 but has order 2
+	in := testInput(t, c.Curve, c.Size, false, testSeed(t))
+	in.Signatures[0].Signature = &Point{Coords: []string{"0", "1"}}
+	assert.Error(in.Validate(c), "point of order 2 must be rejected")
+}
+
+func TestInputTestdata(t *testing.T) {
+	assert := test.NewAssert(t)
+	files, err := filepath.Glob(filepath.Join("testdata", "input_*"))
+	assert.NoError(err)
+	assert.NotEmpty(files)
+	for _, f := range files {
+		in, err := ReadInput(f)
+		assert.NoError(err, f)
+		c := Config{*in.Curve, Aggregate, len(in.Signatures), NoPkCommitment}
+		assert.NoError(in.Validate(c), f)
+		_, err = Assign(c, in)
+		assert.NoError(err, f)
+	}
+}
@@ -0,0 +1,100 @@
+// Command blsbench compiles, sets up and proves circuits package variants at several sizes
+// and writes constraint counts, timings, peak resident memory and key and proof sizes in CSV
+// or JSON.
+//
+// Usage:
+//
+//	blsbench -variants bn254/loop,bls12377/aggregate -sizes 1,2,8 -format csv -out report.csv
+//
+// Emulated pairings cost millions of constraints each, -max-constraints skips setup and
+// proving of larger circuits and compilation of sizes estimated above the limit.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"gnark/bench"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "blsbench: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	names := make([]string, len(bench.Variants))
+	for i, v := range bench.Variants {
+		names[i] = v.Name
+	}
+	sizes := make([]string, len(bench.Sizes))
+	for i, s := range bench.Sizes {
+		sizes[i] = strconv.Itoa(s)
+	}
+	fs := flag.NewFlagSet("blsbench", flag.ContinueOnError)
+	variantList := fs.String("variants", strings.Join(names, ","), "comma separated variants")
+	sizeList := fs.String("sizes", strings.Join(sizes, ","), "comma separated numbers of signatures")
+	format := fs.String("format", "csv", "report format: csv or json")
+	out := fs.String("out", "", "report file, standard output if empty")
+	var opts bench.Options
+	fs.BoolVar(&opts.CompileOnly, "compile-only", false, "skip setup and proving")
+	fs.IntVar(&opts.MaxConstraints, "max-constraints", 0, "skip setup and proving above this constraint count, 0 for no limit")
+	fs.Int64Var(&opts.Seed, "seed", 1, "seed of signing keys")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var variants []bench.Variant
+	for _, name := range strings.Split(*variantList, ",") {
+		v, err := bench.VariantByName(strings.TrimSpace(name))
+		if err != nil {
+			return err
+		}
+		variants = append(variants, v)
+	}
+	var ns []int
+	for _, s := range strings.Split(*sizeList, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil || n < 1 {
+			return fmt.Errorf("invalid size %q", s)
+		}
+		ns = append(ns, n)
+	}
+	write := bench.WriteCSV
+	switch *format {
+	case "csv":
+	case "json":
+		write = bench.WriteJSON
+	default:
+		return fmt.Errorf("unknown format %q", *format)
+	}
+
+	results := bench.RunAll(variants, ns, opts, func(r bench.Result) {
+		status := fmt.Sprintf("%d constraints, compile %s, setup %s, prove %s, peak RSS %d MiB",
+			r.Constraints, r.CompileTime, r.SetupTime, r.ProveTime, r.PeakRSS>>20)
+		switch {
+		case r.Error != "":
+			status = "error: " + r.Error
+		case r.Skipped != "":
+			status += ", skipped " + r.Skipped
+		}
+		fmt.Fprintf(os.Stderr, "%s size %d: %s\n", r.Variant, r.Size, status)
+	})
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	return write(w, results)
+}
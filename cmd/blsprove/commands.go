@@ -0,0 +1,265 @@
+package main
+
+import (
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+
+	"gnark/circuits"
+)
+
+// Artifact file names in the output directory.
+const (
+	configFile       = "circuit.json"
+	circuitFile      = "circuit.r1cs"
+	provingKeyFile   = "proving.key"
+	verifyingKeyFile = "verifying.key"
+	proofFile        = "proof.bin"
+	publicFile       = "public.wtns"
+	vkJSONFile       = "verifying_key.json"
+	solidityFile     = "Verifier.sol"
+)
+
+func newFlagSet(name string) (*flag.FlagSet, *string) {
+	fs := flag.NewFlagSet("blsprove "+name, flag.ContinueOnError)
+	out := fs.String("out", "build", "artifact directory")
+	return fs, out
+}
+
+func runCompile(args []string) error {
+	fs, out := newFlagSet("compile")
+	curve := fs.String("curve", "bn254", "curve pair: bn254, bls12381-in-bn254 or bls12377-in-bw6761")
+	scheme := fs.String("scheme", "single", "scheme: single, loop, aggregate or fast-aggregate")
+	size := fs.Int("size", 1, "number of signatures")
+	commitment := fs.String("pk-commitment", "none", "public key commitment replacing public key inputs: none, mimc or sha256")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	var cfg circuits.Config
+	var err error
+	if cfg.Curve, err = circuits.ParseCurvePair(*curve); err != nil {
+		return err
+	}
+	if cfg.Scheme, err = circuits.ParseScheme(*scheme); err != nil {
+		return err
+	}
+	if cfg.PkCommitment, err = circuits.ParsePkCommitment(*commitment); err != nil {
+		return err
+	}
+	cfg.Size = *size
+	circuit, err := circuits.New(cfg)
+	if err != nil {
+		return err
+	}
+	ccs, err := frontend.Compile(cfg.Curve.Field(), r1cs.NewBuilder, circuit)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(*out, 0o755); err != nil {
+		return err
+	}
+	if err := writeJSON(filepath.Join(*out, configFile), cfg); err != nil {
+		return err
+	}
+	if err := writeArtifact(filepath.Join(*out, circuitFile), ccs); err != nil {
+		return err
+	}
+	fmt.Printf("compiled %s %s circuit of size %d: %d constraints, %d public inputs\n",
+		cfg.Curve, cfg.Scheme, cfg.Size, ccs.GetNbConstraints(), ccs.GetNbPublicVariables()-1)
+	return nil
+}
+
+func runSetup(args []string) error {
+	fs, out := newFlagSet("setup")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	cfg, err := readConfig(*out)
+	if err != nil {
+		return err
+	}
+	ccs := groth16.NewCS(cfg.Curve.ID())
+	if err := readArtifact(filepath.Join(*out, circuitFile), ccs); err != nil {
+		return err
+	}
+	pk, vk, err := groth16.Setup(ccs)
+	if err != nil {
+		return err
+	}
+	if err := writeArtifact(filepath.Join(*out, provingKeyFile), pk); err != nil {
+		return err
+	}
+	return writeArtifact(filepath.Join(*out, verifyingKeyFile), vk)
+}
+
+func runProve(args []string) error {
+	fs, out := newFlagSet("prove")
+	input := fs.String("input", "", "JSON or CBOR file of public keys, messages and signatures, see circuits/INPUT.md")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *input == "" {
+		return errors.New("input file must be given")
+	}
+	cfg, err := readConfig(*out)
+	if err != nil {
+		return err
+	}
+	in, err := circuits.ReadInput(*input)
+	if err != nil {
+		return err
+	}
+	assignment, err := circuits.Assign(cfg, in)
+	if err != nil {
+		return err
+	}
+	w, err := frontend.NewWitness(assignment, cfg.Curve.Field())
+	if err != nil {
+		return err
+	}
+	public, err := w.Public()
+	if err != nil {
+		return err
+	}
+	ccs := groth16.NewCS(cfg.Curve.ID())
+	if err := readArtifact(filepath.Join(*out, circuitFile), ccs); err != nil {
+		return err
+	}
+	pk := groth16.NewProvingKey(cfg.Curve.ID())
+	if err := readArtifact(filepath.Join(*out, provingKeyFile), pk); err != nil {
+		return err
+	}
+	proof, err := groth16.Prove(ccs, pk, w)
+	if err != nil {
+		return err
+	}
+	if err := writeArtifact(filepath.Join(*out, proofFile), proof); err != nil {
+		return err
+	}
+	return writeArtifact(filepath.Join(*out, publicFile), public)
+}
+
+func runFixture(args []string) error {
+	fs, out := newFlagSet("fixture")
+	seed := fs.String("seed", "", "hex seed keys and messages are derived from")
+	input := fs.String("input", "signatures.json", "input file to write")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	seedBytes, err := hex.DecodeString(strings.TrimPrefix(*seed, "0x"))
+	if err != nil {
+		return err
+	}
+	if len(seedBytes) == 0 {
+		return errors.New("seed must be given")
+	}
+	cfg, err := readConfig(*out)
+	if err != nil {
+		return err
+	}
+	f, err := circuits.GenerateFixture(cfg, seedBytes)
+	if err != nil {
+		return err
+	}
+	if err := writeJSON(*input, f.Input); err != nil {
+		return err
+	}
+	fmt.Printf("wrote %d signatures derived from seed %x to %s\n", len(f.Input.Signatures), seedBytes, *input)
+	return nil
+}
+
+func runVerify(args []string) error {
+	fs, out := newFlagSet("verify")
+	proofPath := fs.String("proof", "", "proof file, defaults to "+proofFile+" in artifact directory")
+	publicPath := fs.String("public", "", "public witness file, defaults to "+publicFile+" in artifact directory")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *proofPath == "" {
+		*proofPath = filepath.Join(*out, proofFile)
+	}
+	if *publicPath == "" {
+		*publicPath = filepath.Join(*out, publicFile)
+	}
+	cfg, err := readConfig(*out)
+	if err != nil {
+		return err
+	}
+	vk := groth16.NewVerifyingKey(cfg.Curve.ID())
+	if err := readArtifact(filepath.Join(*out, verifyingKeyFile), vk); err != nil {
+		return err
+	}
+	proof := groth16.NewProof(cfg.Curve.ID())
+	if err := readArtifact(*proofPath, proof); err != nil {
+		return err
+	}
+	public, err := witness.New(cfg.Curve.Field())
+	if err != nil {
+		return err
+	}
+	if err := readArtifact(*publicPath, public); err != nil {
+		return err
+	}
+	if err := groth16.Verify(proof, vk, public); err != nil {
+		return err
+	}
+	fmt.Println("proof is valid")
+	return nil
+}
+
+// exportedVK is the JSON form of a verifying key with its circuit variant.
+type exportedVK struct {
+	circuits.Config
+	NbPublicInputs int                  `json:"nbPublicInputs"`
+	Key            groth16.VerifyingKey `json:"key"`
+}
+
+func runExportVK(args []string) error {
+	fs, out := newFlagSet("export-vk")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	cfg, err := readConfig(*out)
+	if err != nil {
+		return err
+	}
+	vk := groth16.NewVerifyingKey(cfg.Curve.ID())
+	if err := readArtifact(filepath.Join(*out, verifyingKeyFile), vk); err != nil {
+		return err
+	}
+	return writeJSON(filepath.Join(*out, vkJSONFile), exportedVK{cfg, vk.NbPublicWitness(), vk})
+}
+
+func runExportSolidity(args []string) error {
+	fs, out := newFlagSet("export-solidity")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	cfg, err := readConfig(*out)
+	if err != nil {
+		return err
+	}
+	if cfg.Curve.ID() != ecc.BN254 {
+		return fmt.Errorf("solidity verifier is only available for BN254 proofs, %s proofs are on %s", cfg.Curve, cfg.Curve.ID())
+	}
+	vk := groth16.NewVerifyingKey(cfg.Curve.ID())
+	if err := readArtifact(filepath.Join(*out, verifyingKeyFile), vk); err != nil {
+		return err
+	}
+	f, err := os.Create(filepath.Join(*out, solidityFile))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return vk.ExportSolidity(f)
+}
@@ -0,0 +1,68 @@
+// Command blsprove compiles BLS signature verification circuits, runs Groth16 setup,
+// proves signatures read from a file, verifies proofs and exports verifying keys.
+//
+// Usage:
+//
+//	blsprove compile -curve bn254 -scheme aggregate -size 4 -out build
+//	blsprove setup -out build
+//	blsprove fixture -seed 0x01 -input signatures.json -out build
+//	blsprove prove -input signatures.json -out build
+//	blsprove verify -out build
+//	blsprove export-vk -out build
+//	blsprove export-solidity -out build
+//
+// Every command reads and writes artifacts in the output directory. The circuit variant
+// given to compile is stored in circuit.json and used by the following commands.
+// Setup is not a multi party ceremony and its keys are meant for testing only.
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+)
+
+type command struct {
+	run   func(args []string) error
+	usage string
+}
+
+var commands = map[string]command{
+	"compile":         {runCompile, "compile circuit of given curve pair, scheme and size"},
+	"setup":           {runSetup, "run Groth16 setup of compiled circuit"},
+	"fixture":         {runFixture, "write an input of compiled circuit derived from a seed"},
+	"prove":           {runProve, "prove signatures of an input file"},
+	"verify":          {runVerify, "verify proof against its public inputs"},
+	"export-vk":       {runExportVK, "write verifying key in JSON"},
+	"export-solidity": {runExportSolidity, "write Solidity verifier contract, BN254 only"},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "blsprove: unknown command %q\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+	if err := cmd.run(os.Args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "blsprove %s: %v\n", os.Args[1], err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: blsprove <command> [flags]")
+	fmt.Fprintln(os.Stderr, "commands:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-16s %s\n", name, commands[name].usage)
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gnark/circuits"
+)
+
+// TestCommands runs every command on the smallest BLS12-377 circuit and checks that the proof
+// verifies, that it does not verify against other public inputs and that a signature over
+// another message is not proven.
+func TestCommands(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping Groth16 setup and proofs in short mode")
+	}
+	dir := t.TempDir()
+	input := filepath.Join(dir, "signatures.json")
+	for _, args := range [][]string{
+		{"compile", "-curve", "bls12377-in-bw6761", "-scheme", "single", "-pk-commitment", "mimc"},
+		{"setup"},
+		{"fixture", "-seed", "0x01", "-input", input},
+		{"prove", "-input", input},
+		{"verify"},
+		{"export-vk"},
+	} {
+		if err := commands[args[0]].run(append(args[1:], "-out", dir)); err != nil {
+			t.Fatalf("%s: %v", args[0], err)
+		}
+	}
+	if err := runExportSolidity([]string{"-out", dir}); err == nil {
+		t.Fatal("solidity verifier of a BW6-761 proof must be rejected")
+	}
+
+	// public inputs with the last byte of the commitment changed
+	public, err := os.ReadFile(filepath.Join(dir, publicFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	public[len(public)-1] ^= 1
+	tampered := filepath.Join(dir, "tampered.wtns")
+	if err := os.WriteFile(tampered, public, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := runVerify([]string{"-out", dir, "-public", tampered}); err == nil {
+		t.Fatal("proof must not verify against other public inputs")
+	}
+
+	// signature of the fixture over another message
+	in, err := circuits.ReadInput(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	in.Signatures[0].Message = append(in.Signatures[0].Message, '!')
+	if err := writeJSON(input, in); err != nil {
+		t.Fatal(err)
+	}
+	if err := runProve([]string{"-out", dir, "-input", input}); err == nil {
+		t.Fatal("signature over another message must not be proven")
+	}
+}
@@ -0,0 +1,93 @@
+// Command prover-server serves Groth16 proving of BLS signature circuits over HTTP.
+//
+// Usage:
+//
+//	prover-server -addr :8080 -workers 2 -circuit aggregate4=build/aggregate4 -circuit single=build/single
+//
+// Each -circuit flag names an artifact directory written by blsprove compile and setup.
+// Circuits and keys are loaded once at startup. The API is described in package prover.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"gnark/prover"
+)
+
+// circuitFlags collects repeated name=dir flags.
+type circuitFlags map[string]string
+
+func (f circuitFlags) String() string {
+	var s []string
+	for name, dir := range f {
+		s = append(s, name+"="+dir)
+	}
+	return strings.Join(s, ",")
+}
+
+func (f circuitFlags) Set(v string) error {
+	name, dir, ok := strings.Cut(v, "=")
+	if !ok || name == "" || dir == "" {
+		return errors.New("circuit must be given as name=dir")
+	}
+	if _, ok := f[name]; ok {
+		return fmt.Errorf("circuit %q is given twice", name)
+	}
+	f[name] = dir
+	return nil
+}
+
+func main() {
+	dirs := circuitFlags{}
+	addr := flag.String("addr", ":8080", "listen address")
+	workers := flag.Int("workers", 1, "number of jobs proved concurrently")
+	queue := flag.Int("queue", 64, "number of jobs waiting for a worker")
+	ttl := flag.Duration("job-ttl", time.Hour, "how long a finished job is kept")
+	maxJobs := flag.Int("max-jobs", 1024, "number of finished jobs kept")
+	flag.Var(dirs, "circuit", "circuit name and artifact directory as name=dir, repeatable")
+	flag.Parse()
+	if len(dirs) == 0 {
+		fmt.Fprintln(os.Stderr, "prover-server: at least one -circuit must be given")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	loaded := make(map[string]*prover.Circuit, len(dirs))
+	for name, dir := range dirs {
+		c, err := prover.LoadCircuit(dir)
+		if err != nil {
+			log.Fatalf("prover-server: circuit %s: %v", name, err)
+		}
+		if c.VK == nil {
+			log.Printf("circuit %s has no verifying key, proofs are not checked", name)
+		}
+		log.Printf("loaded circuit %s: %s %s of size %d, %d constraints",
+			name, c.Config.Curve, c.Config.Scheme, c.Config.Size, c.CS.GetNbConstraints())
+		loaded[name] = c
+	}
+
+	s := prover.NewServer(loaded, prover.Options{Workers: *workers, QueueSize: *queue, JobTTL: *ttl, MaxJobs: *maxJobs})
+	srv := &http.Server{Addr: *addr, Handler: s.Handler(), ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		stop := make(chan os.Signal, 1)
+		signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+		<-stop
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		srv.Shutdown(ctx)
+	}()
+	log.Printf("listening on %s with %d workers", *addr, *workers)
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Fatalf("prover-server: %v", err)
+	}
+}
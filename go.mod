@@ -5,6 +5,8 @@ go 1.20
 require (
 	github.com/consensys/gnark v0.9.0
 	github.com/consensys/gnark-crypto v0.12.1
+	github.com/fxamacker/cbor/v2 v2.5.0
+	github.com/rs/zerolog v1.30.0
 	golang.org/x/crypto v0.12.0
 	golang.org/x/sys v0.11.0
 )
@@ -14,13 +16,11 @@ require (
 	github.com/blang/semver/v4 v4.0.0 // indirect
 	github.com/consensys/bavard v0.1.13 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
-	github.com/fxamacker/cbor/v2 v2.5.0 // indirect
 	github.com/google/pprof v0.0.0-20230821062121-407c9e7a662f // indirect
 	github.com/mattn/go-colorable v0.1.13 // indirect
 	github.com/mattn/go-isatty v0.0.19 // indirect
 	github.com/mmcloughlin/addchain v0.4.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/rs/zerolog v1.30.0 // indirect
 	github.com/stretchr/testify v1.8.4 // indirect
 	github.com/x448/float16 v0.8.4 // indirect
 	golang.org/x/exp v0.0.0-20230817173708-d852ddb80c63 // indirect
@@ -0,0 +1,50 @@
+// Package circuittest runs the witnesses of the circuit tests in the test engine and with the
+// Groth16 and PLONK backends.
+package circuittest
+
+import (
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+// Case is a witness of a circuit and whether it satisfies it.
+type Case struct {
+	Name    string
+	Witness frontend.Circuit
+	Valid   bool
+}
+
+// Solve solves every case in the test engine over the scalar field of curve, which checks the
+// assertions shared by every backend.
+func Solve(assert *test.Assert, circuit frontend.Circuit, curve ecc.ID, cases []Case) {
+	for _, tc := range cases {
+		tc := tc
+		assert.Run(func(assert *test.Assert) {
+			err := test.IsSolved(circuit, tc.Witness, curve.ScalarField())
+			if tc.Valid {
+				assert.NoError(err)
+			} else {
+				assert.Error(err)
+			}
+		}, tc.Name)
+	}
+}
+
+// CheckBackends compiles circuit over curve for backends, Groth16 and PLONK if none is given,
+// and solves every case, or proves them with the prover_checks build tag.
+func CheckBackends(assert *test.Assert, circuit frontend.Circuit, curve ecc.ID, cases []Case, backends ...backend.ID) {
+	if len(backends) == 0 {
+		backends = []backend.ID{backend.GROTH16, backend.PLONK}
+	}
+	opts := []test.TestingOption{test.WithCurves(curve), test.WithBackends(backends[0], backends[1:]...), test.NoTestEngine()}
+	for _, tc := range cases {
+		if tc.Valid {
+			opts = append(opts, test.WithValidAssignment(tc.Witness))
+		} else {
+			opts = append(opts, test.WithInvalidAssignment(tc.Witness))
+		}
+	}
+	assert.CheckCircuit(circuit, opts...)
+}
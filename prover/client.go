@@ -0,0 +1,89 @@
+package prover
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Client calls the HTTP API of a server.
+type Client struct {
+	// URL is the base URL of the server.
+	URL string
+	// HTTP is the client used for requests, http.DefaultClient if nil.
+	HTTP *http.Client
+}
+
+// Circuits returns circuits loaded by the server.
+func (c *Client) Circuits(ctx context.Context) ([]CircuitInfo, error) {
+	var infos []CircuitInfo
+	return infos, c.do(ctx, http.MethodGet, "/v1/circuits", nil, &infos)
+}
+
+// Submit queues a prove job.
+func (c *Client) Submit(ctx context.Context, r *Request) (*Job, error) {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	job := new(Job)
+	return job, c.do(ctx, http.MethodPost, "/v1/jobs", body, job)
+}
+
+// Job returns status of a job.
+func (c *Client) Job(ctx context.Context, id string) (*Job, error) {
+	job := new(Job)
+	return job, c.do(ctx, http.MethodGet, "/v1/jobs/"+id, nil, job)
+}
+
+// Wait polls the job every interval until it is done or failed.
+func (c *Client) Wait(ctx context.Context, id string, interval time.Duration) (*Job, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		job, err := c.Job(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if job.Status == StatusDone || job.Status == StatusFailed {
+			return job, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body []byte, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(c.URL, "/")+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	hc := c.HTTP
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		var e apiError
+		if err := json.NewDecoder(resp.Body).Decode(&e); err != nil || e.Error == "" {
+			return fmt.Errorf("%s %s: %s", method, path, resp.Status)
+		}
+		return errors.New(e.Error)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
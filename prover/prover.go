@@ -0,0 +1,156 @@
+// Package prover runs Groth16 proving of circuits package variants behind an HTTP API.
+//
+// Circuits and proving keys are loaded once, prove requests are queued as jobs and handled
+// by a fixed number of workers. Clients submit a job, poll its status and read the proof
+// with public inputs once the job is done. Finished jobs are dropped after a time to live
+// and beyond a maximum count, see Options.
+//
+// API:
+//
+//	GET  /v1/circuits      list of loaded circuits
+//	POST /v1/jobs          submit {"circuit": name, "input": input}, returns the queued job
+//	GET  /v1/jobs/{id}     job status, with proof and public inputs when done
+//
+// Input is the JSON schema of circuits.Input.
+package prover
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	bn254fr "github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	bw6761fr "github.com/consensys/gnark-crypto/ecc/bw6-761/fr"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
+
+	"gnark/circuits"
+)
+
+// Artifact file names of a directory written by blsprove compile and setup.
+const (
+	configFile       = "circuit.json"
+	circuitFile      = "circuit.r1cs"
+	provingKeyFile   = "proving.key"
+	verifyingKeyFile = "verifying.key"
+)
+
+// Circuit is a compiled circuit variant with its keys. Verifying key is optional,
+// when set every proof is verified before it is returned.
+type Circuit struct {
+	Config circuits.Config
+	CS     constraint.ConstraintSystem
+	PK     groth16.ProvingKey
+	VK     groth16.VerifyingKey
+}
+
+// LoadCircuit reads a circuit from an artifact directory of blsprove. Verifying key is
+// read if present.
+func LoadCircuit(dir string) (*Circuit, error) {
+	b, err := os.ReadFile(filepath.Join(dir, configFile))
+	if err != nil {
+		return nil, fmt.Errorf("circuit is not compiled: %w", err)
+	}
+	c := new(Circuit)
+	if err := json.Unmarshal(b, &c.Config); err != nil {
+		return nil, err
+	}
+	id := c.Config.Curve.ID()
+	c.CS = groth16.NewCS(id)
+	if err := readArtifact(filepath.Join(dir, circuitFile), c.CS); err != nil {
+		return nil, err
+	}
+	c.PK = groth16.NewProvingKey(id)
+	if err := readArtifact(filepath.Join(dir, provingKeyFile), c.PK); err != nil {
+		return nil, err
+	}
+	vk := groth16.NewVerifyingKey(id)
+	switch err := readArtifact(filepath.Join(dir, verifyingKeyFile), vk); {
+	case err == nil:
+		c.VK = vk
+	case !os.IsNotExist(err):
+		return nil, err
+	}
+	return c, nil
+}
+
+func readArtifact(path string, r io.ReaderFrom) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err := r.ReadFrom(bufio.NewReader(f)); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// Result is a proof with its public inputs.
+type Result struct {
+	// Proof is the gnark binary encoding of the Groth16 proof.
+	Proof circuits.HexBytes `json:"proof"`
+	// Public is the gnark binary encoding of the public witness.
+	Public circuits.HexBytes `json:"public"`
+	// PublicInputs are the public inputs in decimal, in circuit order.
+	PublicInputs []string `json:"publicInputs"`
+}
+
+// prove assigns the input and proves it.
+func (c *Circuit) prove(in *circuits.Input) (*Result, error) {
+	assignment, err := circuits.Assign(c.Config, in)
+	if err != nil {
+		return nil, err
+	}
+	w, err := frontend.NewWitness(assignment, c.Config.Curve.Field())
+	if err != nil {
+		return nil, err
+	}
+	public, err := w.Public()
+	if err != nil {
+		return nil, err
+	}
+	proof, err := groth16.Prove(c.CS, c.PK, w)
+	if err != nil {
+		return nil, err
+	}
+	if c.VK != nil {
+		if err := groth16.Verify(proof, c.VK, public); err != nil {
+			return nil, fmt.Errorf("proof does not verify: %w", err)
+		}
+	}
+	return newResult(proof, public)
+}
+
+func newResult(proof groth16.Proof, public witness.Witness) (*Result, error) {
+	var r Result
+	var buf bytes.Buffer
+	if _, err := proof.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	r.Proof = buf.Bytes()
+	var err error
+	if r.Public, err = public.MarshalBinary(); err != nil {
+		return nil, err
+	}
+	switch v := public.Vector().(type) {
+	case bn254fr.Vector:
+		for i := range v {
+			r.PublicInputs = append(r.PublicInputs, v[i].BigInt(new(big.Int)).String())
+		}
+	case bw6761fr.Vector:
+		for i := range v {
+			r.PublicInputs = append(r.PublicInputs, v[i].BigInt(new(big.Int)).String())
+		}
+	default:
+		return nil, fmt.Errorf("unsupported witness vector %T", v)
+	}
+	return &r, nil
+}
@@ -0,0 +1,182 @@
+package prover
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/test"
+
+	"gnark/circuits"
+)
+
+// testCircuit compiles single BLS12-377 signature circuit. Groth16 setup takes about a minute,
+// in short mode a dummy proving key is used and proofs are not verified.
+func testCircuit(t *testing.T) *Circuit {
+	c := &Circuit{Config: circuits.Config{Curve: circuits.BLS12377InBW6761, Scheme: circuits.Single, Size: 1}}
+	circuit, err := circuits.New(c.Config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.CS, err = frontend.Compile(c.Config.Curve.Field(), r1cs.NewBuilder, circuit); err != nil {
+		t.Fatal(err)
+	}
+	if testing.Short() {
+		c.PK, err = groth16.DummySetup(c.CS)
+	} else {
+		c.PK, c.VK, err = groth16.Setup(c.CS)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func testInput(t *testing.T, msg []byte) *circuits.Input {
+	var sk fr.Element
+	if _, err := sk.SetRandom(); err != nil {
+		t.Fatal(err)
+	}
+	k := sk.BigInt(new(big.Int))
+	_, _, _, g2 := bls12377.Generators()
+	hm, err := bls12377.HashToG1(msg, circuits.BLS12377InBW6761.DST())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk := new(bls12377.G2Affine).ScalarMultiplication(&g2, k).Marshal()
+	sig := new(bls12377.G1Affine).ScalarMultiplication(&hm, k).Marshal()
+	return &circuits.Input{Signatures: []circuits.SignedMessage{
+		{PublicKey: *circuits.NewPoint(pk), Message: msg, Signature: circuits.NewPoint(sig)},
+	}}
+}
+
+func TestServer(t *testing.T) {
+	assert := test.NewAssert(t)
+	c := testCircuit(t)
+	s := NewServer(map[string]*Circuit{"single": c}, Options{Workers: 2})
+	defer s.Close()
+	ts := httptest.NewServer(s.Handler())
+	defer ts.Close()
+	client := &Client{URL: ts.URL}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	infos, err := client.Circuits(ctx)
+	assert.NoError(err)
+	assert.Equal([]CircuitInfo{{"single", c.Config, c.CS.GetNbConstraints()}}, infos)
+
+	job, err := client.Submit(ctx, &Request{Circuit: "single", Input: testInput(t, []byte("message"))})
+	assert.NoError(err)
+	assert.Equal(StatusQueued, job.Status)
+	job, err = client.Wait(ctx, job.ID, 100*time.Millisecond)
+	assert.NoError(err)
+	assert.Equal(StatusDone, job.Status, job.Error)
+	assert.Equal(c.CS.GetNbPublicVariables()-1, len(job.Result.PublicInputs))
+	assert.NotNil(job.Finished)
+
+	if c.VK != nil {
+		proof := groth16.NewProof(c.Config.Curve.ID())
+		_, err := proof.ReadFrom(bytes.NewReader(job.Result.Proof))
+		assert.NoError(err)
+		public, err := witness.New(c.Config.Curve.Field())
+		assert.NoError(err)
+		assert.NoError(public.UnmarshalBinary(job.Result.Public))
+		assert.NoError(groth16.Verify(proof, c.VK, public))
+	}
+}
+
+// panicCircuit has no constraint system, groth16.Prove panics on it.
+var panicCircuit = &Circuit{Config: circuits.Config{Curve: circuits.BLS12377InBW6761, Scheme: circuits.Single, Size: 1}}
+
+func TestServerRecovers(t *testing.T) {
+	assert := test.NewAssert(t)
+	s := NewServer(map[string]*Circuit{"single": panicCircuit}, Options{})
+	defer s.Close()
+	ts := httptest.NewServer(s.Handler())
+	defer ts.Close()
+	client := &Client{URL: ts.URL}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	for i := 0; i < 2; i++ {
+		job, err := client.Submit(ctx, &Request{Circuit: "single", Input: testInput(t, []byte("message"))})
+		assert.NoError(err)
+		job, err = client.Wait(ctx, job.ID, 10*time.Millisecond)
+		assert.NoError(err)
+		assert.Equal(StatusFailed, job.Status)
+		assert.Contains(job.Error, "panicked")
+	}
+}
+
+func TestServerEvicts(t *testing.T) {
+	assert := test.NewAssert(t)
+	wait := func(s *Server, id string) {
+		for {
+			if job, ok := s.Job(id); !ok || job.Finished != nil {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	submit := func(s *Server) string {
+		job, err := s.Submit(&Request{Circuit: "single", Input: testInput(t, []byte("message"))})
+		assert.NoError(err)
+		wait(s, job.ID)
+		return job.ID
+	}
+
+	s := NewServer(map[string]*Circuit{"single": panicCircuit}, Options{MaxJobs: 2})
+	defer s.Close()
+	first, second, third := submit(s), submit(s), submit(s)
+	_, ok := s.Job(first)
+	assert.False(ok, "oldest job beyond MaxJobs")
+	for _, id := range []string{second, third} {
+		_, ok := s.Job(id)
+		assert.True(ok, "job within MaxJobs")
+	}
+
+	s = NewServer(map[string]*Circuit{"single": panicCircuit}, Options{JobTTL: 50 * time.Millisecond})
+	defer s.Close()
+	id := submit(s)
+	_, ok = s.Job(id)
+	assert.True(ok, "job within TTL")
+	time.Sleep(100 * time.Millisecond)
+	_, ok = s.Job(id)
+	assert.False(ok, "job beyond TTL")
+}
+
+func TestServerRejects(t *testing.T) {
+	assert := test.NewAssert(t)
+	c := &Circuit{Config: circuits.Config{Curve: circuits.BLS12377InBW6761, Scheme: circuits.Single, Size: 1}}
+	s := NewServer(map[string]*Circuit{"single": c}, Options{})
+	defer s.Close()
+	ts := httptest.NewServer(s.Handler())
+	defer ts.Close()
+	client := &Client{URL: ts.URL}
+	ctx := context.Background()
+
+	in := testInput(t, []byte("message"))
+	_, err := client.Submit(ctx, &Request{Circuit: "loop", Input: in})
+	assert.Error(err, "unknown circuit")
+	_, err = client.Submit(ctx, &Request{Circuit: "single"})
+	assert.Error(err, "missing input")
+
+	var b [10]byte
+	rand.Read(b[:])
+	in.Signatures[0].Signature = circuits.NewPoint(b[:])
+	_, err = client.Submit(ctx, &Request{Circuit: "single", Input: in})
+	assert.Error(err, "malformed signature")
+
+	_, err = client.Job(ctx, "unknown")
+	assert.Error(err, "unknown job")
+}
@@ -0,0 +1,297 @@
+package prover
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gnark/circuits"
+)
+
+// Status of a job.
+type Status string
+
+const (
+	StatusQueued  Status = "queued"
+	StatusRunning Status = "running"
+	StatusDone    Status = "done"
+	StatusFailed  Status = "failed"
+)
+
+// Job is a prove request and its outcome.
+type Job struct {
+	ID       string     `json:"id"`
+	Circuit  string     `json:"circuit"`
+	Status   Status     `json:"status"`
+	Error    string     `json:"error,omitempty"`
+	Result   *Result    `json:"result,omitempty"`
+	Created  time.Time  `json:"created"`
+	Finished *time.Time `json:"finished,omitempty"`
+
+	input *circuits.Input
+}
+
+// Request is the body of a job submission.
+type Request struct {
+	Circuit string          `json:"circuit"`
+	Input   *circuits.Input `json:"input"`
+}
+
+// CircuitInfo describes a loaded circuit.
+type CircuitInfo struct {
+	Name string `json:"name"`
+	circuits.Config
+	NbConstraints int `json:"nbConstraints"`
+}
+
+// Options configures a server.
+type Options struct {
+	// Workers is the number of jobs proved concurrently, one if not set.
+	Workers int
+	// QueueSize is the number of jobs waiting for a worker, submissions beyond are rejected.
+	// 64 if not set.
+	QueueSize int
+	// JobTTL is how long a finished job is kept, one hour if not set.
+	JobTTL time.Duration
+	// MaxJobs is the number of finished jobs kept, the oldest ones are dropped beyond.
+	// 1024 if not set.
+	MaxJobs int
+}
+
+// ErrQueueFull is returned when a job is submitted to a full queue.
+var ErrQueueFull = errors.New("job queue is full")
+
+// Server queues prove jobs of loaded circuits and runs them with a pool of workers.
+type Server struct {
+	circuits map[string]*Circuit
+	queue    chan *Job
+	wg       sync.WaitGroup
+
+	mu       sync.Mutex
+	jobs     map[string]*Job
+	finished []*Job // in the order they finished
+	ttl      time.Duration
+	maxJobs  int
+}
+
+// NewServer returns a server of given circuits by name and starts its workers.
+func NewServer(circuits map[string]*Circuit, opts Options) *Server {
+	if opts.Workers < 1 {
+		opts.Workers = 1
+	}
+	if opts.QueueSize < 1 {
+		opts.QueueSize = 64
+	}
+	if opts.JobTTL <= 0 {
+		opts.JobTTL = time.Hour
+	}
+	if opts.MaxJobs < 1 {
+		opts.MaxJobs = 1024
+	}
+	s := &Server{
+		circuits: circuits,
+		queue:    make(chan *Job, opts.QueueSize),
+		jobs:     make(map[string]*Job),
+		ttl:      opts.JobTTL,
+		maxJobs:  opts.MaxJobs,
+	}
+	s.wg.Add(opts.Workers)
+	for i := 0; i < opts.Workers; i++ {
+		go s.work(s.queue)
+	}
+	return s
+}
+
+// Close stops accepting jobs and waits for queued jobs to finish.
+func (s *Server) Close() {
+	s.mu.Lock()
+	close(s.queue)
+	s.queue = nil
+	s.mu.Unlock()
+	s.wg.Wait()
+}
+
+// Submit validates the input against the circuit and queues a job.
+func (s *Server) Submit(r *Request) (*Job, error) {
+	c, ok := s.circuits[r.Circuit]
+	if !ok {
+		return nil, fmt.Errorf("unknown circuit %q", r.Circuit)
+	}
+	if r.Input == nil {
+		return nil, errors.New("input is missing")
+	}
+	if err := r.Input.Validate(c.Config); err != nil {
+		return nil, err
+	}
+	id, err := newID()
+	if err != nil {
+		return nil, err
+	}
+	job := &Job{ID: id, Circuit: r.Circuit, Status: StatusQueued, Created: time.Now(), input: r.Input}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.evict(time.Now())
+	if s.queue == nil {
+		return nil, errors.New("server is closed")
+	}
+	select {
+	case s.queue <- job:
+	default:
+		return nil, ErrQueueFull
+	}
+	s.jobs[id] = job
+	v := *job
+	return &v, nil
+}
+
+// Job returns a copy of the job with given id.
+func (s *Server) Job(id string) (*Job, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.evict(time.Now())
+	job, ok := s.jobs[id]
+	if !ok {
+		return nil, false
+	}
+	v := *job
+	return &v, true
+}
+
+// Circuits returns loaded circuits sorted by name.
+func (s *Server) Circuits() []CircuitInfo {
+	infos := make([]CircuitInfo, 0, len(s.circuits))
+	for name, c := range s.circuits {
+		infos = append(infos, CircuitInfo{name, c.Config, c.CS.GetNbConstraints()})
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos
+}
+
+func (s *Server) work(queue <-chan *Job) {
+	defer s.wg.Done()
+	for job := range queue {
+		s.update(job, func(j *Job) { j.Status = StatusRunning })
+		result, err := s.prove(job)
+		s.update(job, func(j *Job) {
+			now := time.Now()
+			j.Finished = &now
+			j.input = nil
+			s.finished = append(s.finished, j)
+			s.evict(now)
+			if err != nil {
+				j.Status, j.Error = StatusFailed, err.Error()
+				return
+			}
+			j.Status, j.Result = StatusDone, result
+		})
+	}
+}
+
+// prove proves the job. A panic of the prover fails the job instead of the server.
+func (s *Server) prove(job *Job) (result *Result, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("prover panicked: %v", r)
+		}
+	}()
+	return s.circuits[job.Circuit].prove(job.input)
+}
+
+// evict drops finished jobs older than the TTL and the oldest ones beyond MaxJobs.
+// It must be called with mu held.
+func (s *Server) evict(now time.Time) {
+	n := 0
+	for ; n < len(s.finished); n++ {
+		j := s.finished[n]
+		if len(s.finished)-n <= s.maxJobs && now.Sub(*j.Finished) <= s.ttl {
+			break
+		}
+		delete(s.jobs, j.ID)
+		s.finished[n] = nil
+	}
+	s.finished = s.finished[n:]
+}
+
+func (s *Server) update(job *Job, f func(*Job)) {
+	s.mu.Lock()
+	f(job)
+	s.mu.Unlock()
+}
+
+func newID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b[:]), nil
+}
+
+// Handler returns the HTTP API of the server.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/circuits", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+			return
+		}
+		writeJSON(w, http.StatusOK, s.Circuits())
+	})
+	mux.HandleFunc("/v1/jobs", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+			return
+		}
+		var req Request
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize)).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		job, err := s.Submit(&req)
+		switch {
+		case errors.Is(err, ErrQueueFull):
+			writeError(w, http.StatusServiceUnavailable, err)
+		case err != nil:
+			writeError(w, http.StatusBadRequest, err)
+		default:
+			writeJSON(w, http.StatusAccepted, job)
+		}
+	})
+	mux.HandleFunc("/v1/jobs/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+			return
+		}
+		job, ok := s.Job(strings.TrimPrefix(r.URL.Path, "/v1/jobs/"))
+		if !ok {
+			writeError(w, http.StatusNotFound, errors.New("unknown job"))
+			return
+		}
+		writeJSON(w, http.StatusOK, job)
+	})
+	return mux
+}
+
+// maxRequestSize bounds the body of a job submission.
+const maxRequestSize = 16 << 20
+
+// apiError is the body of an error response.
+type apiError struct {
+	Error string `json:"error"`
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	writeJSON(w, code, apiError{err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}